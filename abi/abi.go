@@ -2,20 +2,32 @@ package abi
 
 import (
 	"bytes"
-	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/go-chain/go-tron/address"
 	"io/ioutil"
-	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type ABI struct {
 	Constructor Function
-	Functions   map[string]Function
-	Events      map[string]Event
+
+	// Functions are keyed by signature, such as transfer(address,uint256), as overloaded
+	// functions share a name. Function looks them up by name.
+	Functions map[string]Function
+
+	Events map[string]Event
+	Errors map[string]Error
+
+	// Fallback and Receive are the fallback and receive functions of the contract, nil if it
+	// has none.
+	Fallback *Function
+	Receive  *Function
 }
 
 func ReadFile(path string) (ABI, error) {
@@ -32,9 +44,25 @@ func ReadFile(path string) (ABI, error) {
 	return abi, nil
 }
 
+// UnmarshalJSON decodes an ABI from a JSON array of entries, as returned by nodes or output by
+// solc, or from an artifact of Hardhat or Foundry, whose ABI is in its "abi" field.
 func (a *ABI) UnmarshalJSON(data []byte) error {
 	a.Functions = make(map[string]Function)
 	a.Events = make(map[string]Event)
+	a.Errors = make(map[string]Error)
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(trimmed, &artifact); err != nil {
+			return err
+		}
+		if artifact.ABI == nil {
+			return errors.New("abi: object has no abi field")
+		}
+		data = artifact.ABI
+	}
 
 	type entry struct {
 		Type       string  `json:"type"`
@@ -49,33 +77,101 @@ func (a *ABI) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	// Nodes capitalize types and mutabilities while compilers do not, so both are accepted.
 	for _, entry := range entries {
-		switch entry.Type {
-		case "Constructor":
+		entry.Mutability = strings.ToLower(entry.Mutability)
+
+		switch strings.ToLower(entry.Type) {
+		case "constructor":
 			a.Constructor = Function{
 				Name:       entry.Name,
 				Mutability: entry.Mutability,
 				Inputs:     entry.Inputs,
 				Outputs:    entry.Outputs,
 			}
-		case "Function":
-			a.Functions[entry.Name] = Function{
+		case "function":
+			fn := Function{
 				Name:       entry.Name,
 				Mutability: entry.Mutability,
 				Inputs:     entry.Inputs,
 				Outputs:    entry.Outputs,
 			}
-		case "Event":
+			a.Functions[fn.Signature()] = fn
+		case "event":
 			a.Events[entry.Name] = Event{
 				Name:   entry.Name,
 				Inputs: entry.Inputs,
 			}
+		case "error":
+			a.Errors[entry.Name] = Error{
+				Name:   entry.Name,
+				Inputs: entry.Inputs,
+			}
+		case "fallback":
+			a.Fallback = &Function{Mutability: entry.Mutability}
+		case "receive":
+			a.Receive = &Function{Mutability: entry.Mutability}
 		}
 	}
 
 	return nil
 }
 
+// AmbiguousFunctionError is returned when a function is looked up by the name of overloaded
+// functions.
+type AmbiguousFunctionError struct {
+	Name       string
+	Signatures []string
+}
+
+func (e *AmbiguousFunctionError) Error() string {
+	return fmt.Sprintf("abi: %s is overloaded, use one of %s", e.Name, strings.Join(e.Signatures, ", "))
+}
+
+// Function returns a function by its signature, such as transfer(address,uint256), or by its
+// name if it is not overloaded, in which case an *AmbiguousFunctionError is returned.
+func (a ABI) Function(name string) (Function, error) {
+	if strings.Contains(name, "(") {
+		fn, ok := a.Functions[strings.Replace(name, " ", "", -1)]
+		if !ok {
+			return Function{}, fmt.Errorf("abi: no function %s", name)
+		}
+		return fn, nil
+	}
+
+	var matches []Function
+	for _, fn := range a.Functions {
+		if fn.Name == name {
+			matches = append(matches, fn)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Function{}, fmt.Errorf("abi: no function %s", name)
+	case 1:
+		return matches[0], nil
+	default:
+		sigs := make([]string, len(matches))
+		for i, fn := range matches {
+			sigs[i] = fn.Signature()
+		}
+		sort.Strings(sigs)
+		return Function{}, &AmbiguousFunctionError{Name: name, Signatures: sigs}
+	}
+}
+
+// MethodBySelector returns the function with a selector, such as the first four bytes of the
+// calldata of a transaction, false if the contract has none.
+func (a ABI) MethodBySelector(b [4]byte) (Function, bool) {
+	for _, fn := range a.Functions {
+		if fn.Selector() == b {
+			return fn, true
+		}
+	}
+	return Function{}, false
+}
+
 type Function struct {
 	Name       string
 	Mutability string
@@ -92,13 +188,21 @@ func (f Function) Signature() string {
 		if i > 0 {
 			str.WriteRune(',')
 		}
-		str.Write([]byte(in.Type))
+		str.WriteString(in.canonicalType())
 	}
 	str.WriteRune(')')
 
 	return str.String()
 }
 
+// Selector returns the selector of the function, the first four bytes of the keccak256 hash of
+// its signature, which calldata starts with.
+func (f Function) Selector() [4]byte {
+	var sel [4]byte
+	copy(sel[:], crypto.Keccak256([]byte(f.Signature())))
+	return sel
+}
+
 // Payable returns if the function accepts Tron.
 func (f Function) Payable() bool {
 	return f.Mutability == "payable"
@@ -116,74 +220,95 @@ func (f Function) Immutable() bool {
 
 const alignment = 32
 
+// EncodeArguments encodes arguments of the function by the types of its inputs. Integers may
+// be any Go integer or *big.Int, strings and bytes a string or []byte, fixed size byte arrays a
+// byte array or []byte, arrays any slice or array of values of their element type, and tuples
+// a struct whose fields are named or tagged after the components, or a []interface{} of their
+// values. Functions without inputs, whose types are not known, encode the arguments by their
+// Go types. An error is returned if the arguments do not match the inputs.
+func (f Function) EncodeArguments(args ...interface{}) ([]byte, error) {
+	if len(f.Inputs) == 0 {
+		return encodeUntyped(args)
+	}
+
+	if len(f.Inputs) != len(args) {
+		return nil, fmt.Errorf("abi: %s takes %d arguments, got %d", f.Name, len(f.Inputs), len(args))
+	}
+
+	types, err := parseTypes(f.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeTuple(types, args)
+}
+
+// Encode is EncodeArguments for arguments known to match the inputs, such as those of the
+// functions a package calls itself. It panics if they do not.
 func (f Function) Encode(args ...interface{}) []byte {
-	var buf bytes.Buffer
-	for _, arg := range args {
+	bs, err := f.EncodeArguments(args...)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// encodeUntyped encodes arguments by their Go types, for functions whose inputs are not known.
+// Addresses are encoded as address, bools as bool, strings as string, byte slices as bytes and
+// integers as int256 if negative and uint256 otherwise.
+func encodeUntyped(args []interface{}) ([]byte, error) {
+	types := make([]typ, len(args))
+	for i, arg := range args {
 		switch arg := arg.(type) {
-		case uint8, uint16, uint32, uint64:
-			leftPad(&buf, 0x00, alignment-binary.Size(arg))
-			binary.Write(&buf, binary.BigEndian, arg)
 		case address.Address:
-			leftPad(&buf, 0x00, alignment-len(arg)+1)
-			buf.Write(arg[1:])
-		case *big.Int:
-			b := arg.Bytes()
-			switch arg.Sign() {
-			case -1:
-				leftPad(&buf, 0xff, alignment-len(b))
-			default:
-				leftPad(&buf, 0x00, alignment-len(b))
-			}
-			buf.Write(arg.Bytes())
+			types[i] = typ{kind: kindAddress}
+		case bool:
+			types[i] = typ{kind: kindBool}
+		case string:
+			types[i] = typ{kind: kindString}
+		case []byte:
+			types[i] = typ{kind: kindBytes}
 		default:
-			panic("abi: cannot encode given argument, unsupported type")
+			n, ok := toBig(arg)
+			if !ok {
+				return nil, fmt.Errorf("abi: cannot encode argument %d of type %T", i, arg)
+			}
+
+			types[i] = typ{kind: kindUint, size: 256}
+			if n.Sign() < 0 {
+				types[i].kind = kindInt
+			}
 		}
 	}
-	return buf.Bytes()
-}
 
-func leftPad(buf *bytes.Buffer, b byte, n int) {
-	var fill [alignment]byte
-	for i := range fill {
-		fill[i] = b
-	}
-	buf.Write(fill[:n])
+	return encodeTuple(types, args)
 }
 
+// Decode decodes the return data of the function by the types of its outputs. Integers are
+// decoded as *big.Int, addresses as address.Address, bytes32 as [32]byte, other fixed size
+// byte arrays and bytes as []byte, strings as string and arrays and tuples as []interface{}.
 func (f Function) Decode(b []byte) ([]interface{}, error) {
-	result := make([]interface{}, 0, len(f.Outputs))
+	types, err := parseTypes(f.Outputs)
+	if err != nil {
+		return nil, err
+	}
 
-	r := bytes.NewReader(b)
+	return decodeTuple(types, b)
+}
 
-	var bs [alignment]byte
-	for _, out := range f.Outputs {
-		switch out.Type {
-		case TypeBool:
-			if _, err := r.Read(bs[:]); err != nil {
-				return nil, err
-			}
+// DecodeArguments decodes the arguments of the function from calldata, with or without its
+// selector, by the types of its inputs, as Decode decodes return data.
+func (f Function) DecodeArguments(data []byte) ([]interface{}, error) {
+	if sel := f.Selector(); len(data)%alignment == 4 && bytes.Equal(data[:4], sel[:]) {
+		data = data[4:]
+	}
 
-			switch b[alignment-1] {
-			case 0:
-				result = append(result, false)
-			default:
-				result = append(result, true)
-			}
-		case TypeBytes32:
-			var slice [32]byte
-			if _, err := r.Read(slice[:]); err != nil {
-				return nil, err
-			}
-			result = append(result, slice)
-		case TypeUint256:
-			if _, err := r.Read(bs[:]); err != nil {
-				return nil, err
-			}
-			result = append(result, big.NewInt(0).SetBytes(b[:]))
-		}
+	types, err := parseTypes(f.Inputs)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return decodeTuple(types, data)
 }
 
 func (f Function) GetOutputIndex(name string) int {
@@ -200,10 +325,51 @@ type Event struct {
 	Inputs []Value
 }
 
+// Error is a custom error which a contract may revert with.
+type Error struct {
+	Name   string
+	Inputs []Value
+}
+
+// Signature returns the signature of the error, such as InsufficientBalance(uint256,uint256).
+func (e Error) Signature() string {
+	return Function{Name: e.Name, Inputs: e.Inputs}.Signature()
+}
+
 type Value struct {
 	Name    string    `json:"name"`
 	Type    ValueType `json:"type"`
 	Indexed bool      `json:"indexed"`
+
+	// Components are the components of tuple types, such as tuple or tuple[].
+	Components []Value `json:"components"`
+}
+
+// canonicalType returns the type as written in signatures, where tuples are the types of
+// their components in parentheses, such as (address,uint256)[], and the aliases uint, int and
+// byte are replaced by uint256, int256 and bytes1, such as uint256[3] for uint[3].
+func (v Value) canonicalType() string {
+	t := string(v.Type)
+
+	base, dims := t, ""
+	if i := strings.IndexByte(t, '['); i >= 0 {
+		base, dims = t[:i], t[i:]
+	}
+
+	switch base {
+	case "tuple":
+		types := make([]string, len(v.Components))
+		for i, c := range v.Components {
+			types[i] = c.canonicalType()
+		}
+		return "(" + strings.Join(types, ",") + ")" + dims
+	case "uint", "int":
+		return base + "256" + dims
+	case "byte":
+		return "bytes1" + dims
+	default:
+		return t
+	}
 }
 
 type ValueType string
@@ -214,12 +380,24 @@ const (
 	TypeUint256 ValueType = "uint256"
 )
 
+// Unmarshal decodes the return data of a function into the fields of the struct v points to,
+// selected by an abi tag naming an output or giving its index as "$0". Tuples are unmarshaled
+// into structs, as the arguments of Encode are.
 func Unmarshal(data []byte, fn Function, v interface{}) error {
-	values, err := fn.Decode(data)
+	types, err := parseTypes(fn.Outputs)
+	if err != nil {
+		return err
+	}
+
+	values, err := decodeTuple(types, data)
 	if err != nil {
 		return err
 	}
 
+	if v == nil {
+		return nil
+	}
+
 	reflected := reflect.ValueOf(v).Elem()
 	t := reflect.TypeOf(v).Elem()
 
@@ -237,15 +415,19 @@ func Unmarshal(data []byte, fn Function, v interface{}) error {
 			}
 		default:
 			index = fn.GetOutputIndex(selector)
+			if index == -1 {
+				continue
+			}
 		}
 
-		if index == -1 {
-			continue
+		if index < 0 || index >= len(values) {
+			return fmt.Errorf("abi: field %s: %s has %d outputs", t.Field(i).Name, fn.Name, len(values))
 		}
 
-		// TODO(271): Assure value is assignable to structure field.
-		reflected.Field(i).Set(reflect.ValueOf(values[index]))
+		if err := assign(reflected.Field(i), types[index], values[index]); err != nil {
+			return fmt.Errorf("abi: field %s: %v", t.Field(i).Name, err)
+		}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
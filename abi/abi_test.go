@@ -0,0 +1,232 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// words joins 32 byte words given in hex.
+func words(ws ...string) string {
+	return strings.Join(ws, "")
+}
+
+// word left pads a hex value to 32 bytes.
+func word(value string) string {
+	return strings.Repeat("0", 64-len(value)) + value
+}
+
+// rightWord right pads a hex value to 32 bytes.
+func rightWord(value string) string {
+	return value + strings.Repeat("0", 64-len(value))
+}
+
+// TestEncode checks calldata against the examples of the Solidity ABI specification and
+// calls of well known contracts.
+func TestEncode(t *testing.T) {
+	usdt, err := address.FromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		Target   address.Address
+		CallData []byte
+	}
+
+	tests := []struct {
+		name     string
+		fn       Function
+		args     []interface{}
+		selector string
+		want     string
+	}{
+		{
+			name:     "transfer",
+			fn:       Function{Name: "transfer", Inputs: []Value{{Type: "address"}, {Type: TypeUint256}}},
+			args:     []interface{}{usdt, big.NewInt(1000000)},
+			selector: "a9059cbb",
+			want:     words(word("a614f803b6fd780986a42c78ec9c7f77e6ded13c"), word("f4240")),
+		},
+		{
+			name:     "static",
+			fn:       Function{Name: "baz", Inputs: []Value{{Type: "uint32"}, {Type: TypeBool}}},
+			args:     []interface{}{uint32(69), true},
+			selector: "cdcd77c0",
+			want:     words(word("45"), word("1")),
+		},
+		{
+			name:     "fixed bytes array",
+			fn:       Function{Name: "bar", Inputs: []Value{{Type: "bytes3[2]"}}},
+			args:     []interface{}{[][]byte{[]byte("abc"), []byte("def")}},
+			selector: "fce353f6",
+			want:     words(rightWord("616263"), rightWord("646566")),
+		},
+		{
+			name:     "dynamic",
+			fn:       Function{Name: "sam", Inputs: []Value{{Type: "bytes"}, {Type: TypeBool}, {Type: "uint256[]"}}},
+			args:     []interface{}{[]byte("dave"), true, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+			selector: "a5643bf2",
+			want: words(
+				word("60"), word("1"), word("a0"),
+				word("4"), rightWord("64617665"),
+				word("3"), word("1"), word("2"), word("3"),
+			),
+		},
+		{
+			name: "mixed",
+			fn: Function{Name: "f", Inputs: []Value{
+				{Type: TypeUint256}, {Type: "uint32[]"}, {Type: "bytes10"}, {Type: "bytes"},
+			}},
+			args:     []interface{}{big.NewInt(0x123), []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			selector: "8be65246",
+			want: words(
+				word("123"), word("80"), rightWord("31323334353637383930"), word("e0"),
+				word("2"), word("456"), word("789"),
+				word("d"), rightWord("48656c6c6f2c20776f726c6421"),
+			),
+		},
+		{
+			name: "tuple array",
+			fn: Function{Name: "aggregate", Inputs: []Value{{Type: "tuple[]", Components: []Value{
+				{Name: "target", Type: "address"}, {Name: "callData", Type: "bytes"},
+			}}}},
+			args:     []interface{}{[]call{{Target: usdt, CallData: []byte{0x18, 0x16, 0x0d, 0xdd}}}},
+			selector: "252dba42",
+			want: words(
+				word("20"), word("1"), word("20"),
+				word("a614f803b6fd780986a42c78ec9c7f77e6ded13c"), word("40"),
+				word("4"), rightWord("18160ddd"),
+			),
+		},
+		{
+			name: "negative int",
+			fn:   Function{Name: "f", Inputs: []Value{{Type: "int256"}, {Type: "int32"}}},
+			args: []interface{}{big.NewInt(-1), int32(-2)},
+			want: words(strings.Repeat("f", 64), strings.Repeat("f", 63)+"e"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.selector != "" {
+				if sel := test.fn.Selector(); hex.EncodeToString(sel[:]) != test.selector {
+					t.Fatalf("selector of %s is %x, want %s", test.fn.Signature(), sel, test.selector)
+				}
+			}
+
+			data, err := test.fn.EncodeArguments(test.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(data); got != test.want {
+				t.Fatalf("%s encodes to\n%s\nwant\n%s", test.fn.Signature(), got, test.want)
+			}
+		})
+	}
+}
+
+func TestEncodeArgumentsErrors(t *testing.T) {
+	transfer := Function{Name: "transfer", Inputs: []Value{{Type: "address"}, {Type: TypeUint256}}}
+
+	tests := []struct {
+		name string
+		fn   Function
+		args []interface{}
+	}{
+		{"too few arguments", transfer, []interface{}{big.NewInt(1)}},
+		{"out of range", Function{Name: "f", Inputs: []Value{{Type: "uint8"}}}, []interface{}{256}},
+		{"negative uint", Function{Name: "f", Inputs: []Value{{Type: TypeUint256}}}, []interface{}{big.NewInt(-1)}},
+		{"wrong type", transfer, []interface{}{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", big.NewInt(1)}},
+		{"invalid input type", Function{Name: "f", Inputs: []Value{{Type: "uint7"}}}, []interface{}{1}},
+		{"unsupported untyped", Function{Name: "f"}, []interface{}{1.5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.fn.EncodeArguments(test.args...); err == nil {
+				t.Fatalf("%s encoded %v", test.fn.Signature(), test.args)
+			}
+		})
+	}
+}
+
+func TestEncodePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("encoding too few arguments did not panic")
+		}
+	}()
+
+	fn := Function{Name: "transfer", Inputs: []Value{{Type: "address"}, {Type: TypeUint256}}}
+	fn.Encode(big.NewInt(1))
+}
+
+func TestUnmarshalIndex(t *testing.T) {
+	fn := Function{Name: "balanceOf", Outputs: []Value{{Name: "balance", Type: TypeUint256}}}
+	data, err := hex.DecodeString(word("2a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ok struct {
+		Balance *big.Int `abi:"$0"`
+	}
+	if err := Unmarshal(data, fn, &ok); err != nil {
+		t.Fatal(err)
+	}
+	if ok.Balance.Int64() != 42 {
+		t.Fatalf("balance is %s, want 42", ok.Balance)
+	}
+
+	var past struct {
+		Balance *big.Int `abi:"$1"`
+	}
+	if err := Unmarshal(data, fn, &past); err == nil {
+		t.Fatal("unmarshaled output $1 of a function with one output")
+	}
+
+	var negative struct {
+		Balance *big.Int `abi:"$-1"`
+	}
+	if err := Unmarshal(data, fn, &negative); err == nil {
+		t.Fatal("unmarshaled output $-1")
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	fn := Function{
+		Name:   "transfer",
+		Inputs: []Value{{Type: "address"}, {Type: TypeUint256}},
+	}
+	to, err := address.FromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if err != nil {
+		b.Fatal(err)
+	}
+	amount := big.NewInt(1000000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		fn.Encode(to, amount)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	fn := Function{
+		Name:    "balances",
+		Outputs: []Value{{Type: TypeBool}, {Type: TypeUint256}, {Type: TypeBytes32}},
+	}
+	data := make([]byte, 3*32)
+	data[31] = 1
+	data[63] = 0xff
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := fn.Decode(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
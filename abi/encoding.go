@@ -0,0 +1,618 @@
+package abi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// kind is the category of an ABI type.
+type kind int
+
+const (
+	kindBool kind = iota
+	kindUint
+	kindInt
+	kindAddress
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindSlice
+	kindArray
+	kindTuple
+)
+
+// typ is a parsed ABI type. Size is the number of bits of integers or bytes of fixed size
+// byte arrays, length the length of fixed size arrays, elem the element of arrays and fields
+// and names the types and names of the components of tuples.
+type typ struct {
+	kind   kind
+	size   int
+	length int
+	elem   *typ
+	fields []typ
+	names  []string
+}
+
+// parseType parses an ABI type such as uint256, bytes, address[2][] or tuple[], whose
+// components are those of a tuple. Arrays may be nested to any depth, the last dimension being
+// the outermost, so uint8[2][3] is three arrays of two uint8.
+func parseType(str string, components []Value) (typ, error) {
+	if strings.HasSuffix(str, "]") {
+		i := strings.LastIndexByte(str, '[')
+		if i < 0 {
+			return typ{}, fmt.Errorf("abi: invalid type %s", str)
+		}
+
+		elem, err := parseType(str[:i], components)
+		if err != nil {
+			return typ{}, err
+		}
+
+		if str[i+1:len(str)-1] == "" {
+			return typ{kind: kindSlice, elem: &elem}, nil
+		}
+
+		length, err := strconv.Atoi(str[i+1 : len(str)-1])
+		if err != nil || length <= 0 {
+			return typ{}, fmt.Errorf("abi: invalid array length in %s", str)
+		}
+		return typ{kind: kindArray, length: length, elem: &elem}, nil
+	}
+
+	switch {
+	case str == "tuple":
+		fields, err := parseTypes(components)
+		if err != nil {
+			return typ{}, err
+		}
+		names := make([]string, len(components))
+		for i, c := range components {
+			names[i] = c.Name
+		}
+		return typ{kind: kindTuple, fields: fields, names: names}, nil
+	case str == "bool":
+		return typ{kind: kindBool}, nil
+	case str == "address":
+		return typ{kind: kindAddress}, nil
+	case str == "trcToken":
+		// TRC10 token ids are encoded as uint256.
+		return typ{kind: kindUint, size: 256}, nil
+	case str == "string":
+		return typ{kind: kindString}, nil
+	case str == "bytes":
+		return typ{kind: kindBytes}, nil
+	case str == "byte":
+		return typ{kind: kindFixedBytes, size: 1}, nil
+	case strings.HasPrefix(str, "bytes"):
+		size, err := strconv.Atoi(str[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return typ{}, fmt.Errorf("abi: invalid type %s", str)
+		}
+		return typ{kind: kindFixedBytes, size: size}, nil
+	case strings.HasPrefix(str, "uint"):
+		size, err := intSize(str[len("uint"):])
+		if err != nil {
+			return typ{}, fmt.Errorf("abi: invalid type %s", str)
+		}
+		return typ{kind: kindUint, size: size}, nil
+	case strings.HasPrefix(str, "int"):
+		size, err := intSize(str[len("int"):])
+		if err != nil {
+			return typ{}, fmt.Errorf("abi: invalid type %s", str)
+		}
+		return typ{kind: kindInt, size: size}, nil
+	default:
+		return typ{}, fmt.Errorf("abi: unsupported type %s", str)
+	}
+}
+
+// intSize parses the number of bits of an integer type, which defaults to 256.
+func intSize(str string) (int, error) {
+	if str == "" {
+		return 256, nil
+	}
+
+	size, err := strconv.Atoi(str)
+	if err != nil || size < 8 || size > 256 || size%8 != 0 {
+		return 0, errors.New("abi: invalid integer size")
+	}
+	return size, nil
+}
+
+// dynamic returns whether values of the type are encoded in the tail, with an offset to them
+// in the head.
+func (t typ) dynamic() bool {
+	switch t.kind {
+	case kindBytes, kindString, kindSlice:
+		return true
+	case kindArray:
+		return t.elem.dynamic()
+	case kindTuple:
+		for _, f := range t.fields {
+			if f.dynamic() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// headSize returns the number of bytes the type takes in the head.
+func (t typ) headSize() int {
+	if t.dynamic() {
+		return alignment
+	}
+
+	switch t.kind {
+	case kindArray:
+		return t.length * t.elem.headSize()
+	case kindTuple:
+		var size int
+		for _, f := range t.fields {
+			size += f.headSize()
+		}
+		return size
+	default:
+		return alignment
+	}
+}
+
+// parseTypes parses the types of values.
+func parseTypes(values []Value) ([]typ, error) {
+	types := make([]typ, len(values))
+	for i, v := range values {
+		t, err := parseType(string(v.Type), v.Components)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return types, nil
+}
+
+// encodeTuple encodes values as a tuple of types: the head of each value, with offsets for the
+// dynamic ones, followed by the tails of the dynamic ones.
+func encodeTuple(types []typ, values []interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("abi: expected %d values, got %d", len(types), len(values))
+	}
+
+	var headSize int
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		enc, err := encodeValue(t, values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if t.dynamic() {
+			head = append(head, uintWord(uint64(headSize+len(tail)))...)
+			tail = append(tail, enc...)
+		} else {
+			head = append(head, enc...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+// encodeValue encodes a value of a type, its head for static types and its tail for dynamic
+// types.
+func encodeValue(t typ, v interface{}) ([]byte, error) {
+	switch t.kind {
+	case kindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("abi: cannot encode %T as bool", v)
+		}
+		if b {
+			return uintWord(1), nil
+		}
+		return uintWord(0), nil
+	case kindUint, kindInt:
+		n, ok := toBig(v)
+		if !ok {
+			return nil, fmt.Errorf("abi: cannot encode %T as an integer", v)
+		}
+		return encodeInt(t, n)
+	case kindAddress:
+		addr, ok := v.(address.Address)
+		if !ok {
+			return nil, fmt.Errorf("abi: cannot encode %T as address", v)
+		}
+		w := make([]byte, alignment)
+		copy(w[alignment-len(addr)+1:], addr[1:])
+		return w, nil
+	case kindFixedBytes:
+		return encodeFixedBytes(t, v)
+	case kindBytes, kindString:
+		var b []byte
+		switch v := v.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return nil, fmt.Errorf("abi: cannot encode %T as bytes or string", v)
+		}
+		out := uintWord(uint64(len(b)))
+		out = append(out, b...)
+		return append(out, make([]byte, (alignment-len(b)%alignment)%alignment)...), nil
+	case kindSlice, kindArray:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("abi: cannot encode %T as an array", v)
+		}
+		if t.kind == kindArray && rv.Len() != t.length {
+			return nil, fmt.Errorf("abi: expected array of %d values, got %d", t.length, rv.Len())
+		}
+
+		types := make([]typ, rv.Len())
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			types[i] = *t.elem
+			values[i] = rv.Index(i).Interface()
+		}
+
+		enc, err := encodeTuple(types, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.kind == kindSlice {
+			return append(uintWord(uint64(len(values))), enc...), nil
+		}
+		return enc, nil
+	case kindTuple:
+		values, err := tupleValues(t, v)
+		if err != nil {
+			return nil, err
+		}
+		return encodeTuple(t.fields, values)
+	default:
+		return nil, errors.New("abi: unsupported type")
+	}
+}
+
+// tupleValues returns the values of the components of a tuple from a struct, or a pointer to
+// one, or from a slice of the values in order. Fields of a struct are matched to components by
+// their abi tag, or else by name ignoring case.
+func tupleValues(t typ, v interface{}) ([]interface{}, error) {
+	if values, ok := v.([]interface{}); ok {
+		return values, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("abi: cannot encode %T as a tuple", v)
+	}
+
+	values := make([]interface{}, len(t.fields))
+	for i, name := range t.names {
+		field, ok := structField(rv, name)
+		if !ok {
+			return nil, fmt.Errorf("abi: %s has no field for component %s", rv.Type(), name)
+		}
+		values[i] = field.Interface()
+	}
+	return values, nil
+}
+
+// structField returns the exported field of a struct for a component of a tuple.
+func structField(rv reflect.Value, name string) (reflect.Value, bool) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			continue
+		}
+		if tag, ok := t.Field(i).Tag.Lookup("abi"); ok {
+			if tag == name {
+				return rv.Field(i), true
+			}
+			continue
+		}
+		if strings.EqualFold(t.Field(i).Name, name) {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// encodeInt encodes an integer in two's complement, checking that it fits the type.
+func encodeInt(t typ, n *big.Int) ([]byte, error) {
+	if t.kind == kindUint && (n.Sign() < 0 || n.BitLen() > t.size) {
+		return nil, fmt.Errorf("abi: %s does not fit uint%d", n, t.size)
+	}
+
+	if t.kind == kindInt {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("abi: %s does not fit int%d", n, t.size)
+		}
+
+		if n.Sign() < 0 {
+			n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 8*alignment))
+		}
+	}
+
+	w := make([]byte, alignment)
+	b := n.Bytes()
+	copy(w[alignment-len(b):], b)
+	return w, nil
+}
+
+// encodeFixedBytes encodes a fixed size byte array, which is right padded. An integer is
+// accepted for bytes32, as a whole word.
+func encodeFixedBytes(t typ, v interface{}) ([]byte, error) {
+	w := make([]byte, alignment)
+
+	switch v := v.(type) {
+	case *big.Int:
+		if t.size != alignment || v.Sign() < 0 || v.BitLen() > 8*alignment {
+			return nil, fmt.Errorf("abi: cannot encode integer as bytes%d", t.size)
+		}
+		b := v.Bytes()
+		copy(w[alignment-len(b):], b)
+		return w, nil
+	case []byte:
+		if len(v) > t.size {
+			return nil, fmt.Errorf("abi: %d bytes do not fit bytes%d", len(v), t.size)
+		}
+		copy(w, v)
+		return w, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 || rv.Len() > t.size {
+		return nil, fmt.Errorf("abi: cannot encode %T as bytes%d", v, t.size)
+	}
+	reflect.Copy(reflect.ValueOf(w), rv)
+	return w, nil
+}
+
+// toBig converts an integer to a big integer.
+func toBig(v interface{}) (*big.Int, bool) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, v != nil
+	case big.Int:
+		return &v, true
+	case params.Sun:
+		return big.NewInt(int64(v)), true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	default:
+		return nil, false
+	}
+}
+
+// uintWord encodes an unsigned integer as a word.
+func uintWord(n uint64) []byte {
+	w := make([]byte, alignment)
+	binary.BigEndian.PutUint64(w[alignment-8:], n)
+	return w
+}
+
+var errMalformed = errors.New("abi: malformed data")
+
+// decodeTuple decodes values of types from data which starts at the head of the tuple.
+func decodeTuple(types []typ, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+
+	pos := 0
+	for i, t := range types {
+		var err error
+		if t.dynamic() {
+			var offset uint64
+			if offset, err = readWord(data, pos); err != nil {
+				return nil, err
+			}
+			if offset > uint64(len(data)) {
+				return nil, errMalformed
+			}
+			values[i], err = decodeValue(t, data[offset:])
+		} else {
+			if pos+t.headSize() > len(data) {
+				return nil, errMalformed
+			}
+			values[i], err = decodeValue(t, data[pos:])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pos += t.headSize()
+	}
+
+	return values, nil
+}
+
+// decodeValue decodes a value of a type from the start of data.
+func decodeValue(t typ, data []byte) (interface{}, error) {
+	switch t.kind {
+	case kindSlice:
+		n, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(data)/alignment) {
+			return nil, errMalformed
+		}
+		return decodeArray(*t.elem, int(n), data[alignment:])
+	case kindArray:
+		return decodeArray(*t.elem, t.length, data)
+	case kindBytes, kindString:
+		n, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(data)-alignment) {
+			return nil, errMalformed
+		}
+		b := data[alignment : alignment+int(n)]
+		if t.kind == kindString {
+			return string(b), nil
+		}
+		return append([]byte(nil), b...), nil
+	case kindTuple:
+		return decodeTuple(t.fields, data)
+	}
+
+	if len(data) < alignment {
+		return nil, errMalformed
+	}
+	w := data[:alignment]
+
+	switch t.kind {
+	case kindBool:
+		return w[alignment-1] != 0, nil
+	case kindAddress:
+		var addr address.Address
+		addr[0] = params.AddressPrefix
+		copy(addr[1:], w[alignment-len(addr)+1:])
+		return addr, nil
+	case kindUint:
+		return new(big.Int).SetBytes(w), nil
+	case kindInt:
+		n := new(big.Int).SetBytes(w)
+		if w[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 8*alignment))
+		}
+		return n, nil
+	case kindFixedBytes:
+		if t.size == alignment {
+			var b [32]byte
+			copy(b[:], w)
+			return b, nil
+		}
+		return append([]byte(nil), w[:t.size]...), nil
+	default:
+		return nil, errors.New("abi: unsupported type")
+	}
+}
+
+// decodeArray decodes n elements of a type, encoded as a tuple.
+func decodeArray(elem typ, n int, data []byte) ([]interface{}, error) {
+	types := make([]typ, n)
+	for i := range types {
+		types[i] = elem
+	}
+	return decodeTuple(types, data)
+}
+
+// readWord reads the word at a position, which must fit in 64 bits.
+func readWord(data []byte, pos int) (uint64, error) {
+	if pos+alignment > len(data) {
+		return 0, errMalformed
+	}
+
+	for _, b := range data[pos : pos+alignment-8] {
+		if b != 0 {
+			return 0, errMalformed
+		}
+	}
+
+	return binary.BigEndian.Uint64(data[pos+alignment-8 : pos+alignment]), nil
+}
+
+// assign sets dst to a decoded value of a type. Tuples are assigned to structs by the names of
+// their components, arrays to slices or arrays of any element type their elements can be
+// assigned to and integers to Go integers they fit; other values must be assignable to dst.
+func assign(dst reflect.Value, t typ, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(dst.Type()) {
+		dst.Set(rv)
+		return nil
+	}
+
+	if n, ok := v.(*big.Int); ok {
+		return assignInt(dst, n)
+	}
+
+	values, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("abi: cannot assign %s to %s", rv.Type(), dst.Type())
+	}
+
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), t, v)
+	case t.kind == kindTuple && dst.Kind() == reflect.Struct:
+		for i, name := range t.names {
+			field, ok := structField(dst, name)
+			if !ok {
+				continue
+			}
+			if err := assign(field, t.fields[i], values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case (t.kind == kindSlice || t.kind == kindArray) && dst.Kind() == reflect.Slice:
+		dst.Set(reflect.MakeSlice(dst.Type(), len(values), len(values)))
+		for i, elem := range values {
+			if err := assign(dst.Index(i), *t.elem, elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case (t.kind == kindSlice || t.kind == kindArray) && dst.Kind() == reflect.Array:
+		if dst.Len() != len(values) {
+			return fmt.Errorf("abi: cannot assign %d values to %s", len(values), dst.Type())
+		}
+		for i, elem := range values {
+			if err := assign(dst.Index(i), *t.elem, elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("abi: cannot assign %s to %s", rv.Type(), dst.Type())
+	}
+}
+
+// assignInt sets dst, a Go integer, to an integer which must fit it.
+func assignInt(dst reflect.Value, n *big.Int) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return fmt.Errorf("abi: %s does not fit %s", n, dst.Type())
+		}
+		dst.SetInt(n.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("abi: %s does not fit %s", n, dst.Type())
+		}
+		dst.SetUint(n.Uint64())
+		return nil
+	default:
+		return fmt.Errorf("abi: cannot assign *big.Int to %s", dst.Type())
+	}
+}
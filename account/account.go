@@ -4,23 +4,72 @@ package account
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/go-chain/go-tron"
 	"github.com/go-chain/go-tron/address"
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+var (
+	// ErrInvalidKeyLength is returned when an imported private key is not 32 bytes.
+	ErrInvalidKeyLength = errors.New("account: private key must be 32 bytes")
+
+	// ErrZeroKey is returned when an imported private key is all zeros.
+	ErrZeroKey = errors.New("account: private key is zero")
+
+	// ErrKeyOutOfRange is returned when an imported private key is not less than the
+	// order of the secp256k1 curve.
+	ErrKeyOutOfRange = errors.New("account: private key is out of range")
+
+	// ErrInvalidWIF is returned when a WIF string cannot be decoded or is not for secp256k1.
+	ErrInvalidWIF = errors.New("account: invalid wif")
+
+	// ErrWatchOnly is returned when a watch only account is asked to sign.
+	ErrWatchOnly = errors.New("account: watch only account cannot sign")
+)
+
+// AddressMismatchError is returned when the address derived from an imported key
+// does not match the address the caller expected.
+type AddressMismatchError struct {
+	Expected address.Address
+	Derived  address.Address
+}
+
+func (e *AddressMismatchError) Error() string {
+	return fmt.Sprintf("account: derived address %s does not match expected address %s",
+		e.Derived.ToBase58(), e.Expected.ToBase58())
+}
+
 type Account interface {
 	Address() address.Address
 	tron.Signer
 }
 
+// WatchOnly is the account of an address whose key is not held. It can make constant calls on
+// behalf of the address but cannot sign.
+type WatchOnly address.Address
+
+func (w WatchOnly) Address() address.Address {
+	return address.Address(w)
+}
+
+func (w WatchOnly) Sign(tron.Signable) error {
+	return ErrWatchOnly
+}
+
 // LocalAccount is a private key address pair.
 // TODO(271): Add more functionality to this.
 type LocalAccount struct {
 	addr address.Address
 	priv *ecdsa.PrivateKey
+	meta Metadata
 }
 
 func NewLocalAccount() *LocalAccount {
@@ -37,6 +86,7 @@ func NewLocalAccount() *LocalAccount {
 
 	acc.addr = address.FromPublicKey(&pub)
 	acc.priv = privateKey
+	acc.meta = Metadata{CreatedAt: time.Now(), Source: SourceGenerated}
 
 	return acc
 }
@@ -47,8 +97,29 @@ func (a *LocalAccount) PrivateKey() string {
 
 
 // FromPrivateKeyHex derives an account from a hexadecimal private key string.
-func FromPrivateKeyHex(hex string) (*LocalAccount, error) {
-	priv, err := crypto.HexToECDSA(hex)
+func FromPrivateKeyHex(str string) (*LocalAccount, error) {
+	bs, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := FromPrivateKeyBytes(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	acc.meta.Source = SourceHex
+	return acc, nil
+}
+
+// FromPrivateKeyBytes derives an account from a raw 32 byte private key. Keys which are
+// zero or not less than the curve order are rejected with ErrZeroKey and ErrKeyOutOfRange.
+func FromPrivateKeyBytes(bs []byte) (*LocalAccount, error) {
+	if err := validateKey(bs); err != nil {
+		return nil, err
+	}
+
+	priv, err := crypto.ToECDSA(bs)
 	if err != nil {
 		return nil, err
 	}
@@ -56,9 +127,54 @@ func FromPrivateKeyHex(hex string) (*LocalAccount, error) {
 	return &LocalAccount{
 		addr: address.FromPublicKey(&priv.PublicKey),
 		priv: priv,
+		meta: Metadata{CreatedAt: time.Now(), Source: SourceBytes},
 	}, nil
 }
 
+// FromWIF derives an account from a wallet import format string.
+func FromWIF(str string) (*LocalAccount, error) {
+	wif, err := btcutil.DecodeWIF(str)
+	if err != nil {
+		return nil, ErrInvalidWIF
+	}
+
+	acc, err := FromPrivateKeyBytes(wif.PrivKey.Serialize())
+	if err != nil {
+		return nil, err
+	}
+
+	acc.meta.Source = SourceWIF
+	return acc, nil
+}
+
+// validateKey checks that a raw private key is a valid secp256k1 scalar.
+func validateKey(bs []byte) error {
+	if len(bs) != 32 {
+		return ErrInvalidKeyLength
+	}
+
+	d := new(big.Int).SetBytes(bs)
+
+	switch {
+	case d.Sign() == 0:
+		return ErrZeroKey
+	case d.Cmp(btcec.S256().N) >= 0:
+		return ErrKeyOutOfRange
+	}
+
+	return nil
+}
+
+// Verify checks that the account's address matches an expected address, returning an
+// *AddressMismatchError if it does not. This is useful for catching typos in imported keys.
+func (a *LocalAccount) Verify(expected address.Address) error {
+	if a.addr != expected {
+		return &AddressMismatchError{Expected: expected, Derived: a.addr}
+	}
+
+	return nil
+}
+
 // Address returns the address of the account.
 func (a *LocalAccount) Address() address.Address {
 	return a.addr
@@ -0,0 +1,119 @@
+package account
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+)
+
+// keyOneAddress is the address of the private key 1.
+const keyOneAddress = "417e5f4552091a69125d5dfcb7b8c2659029395bdf"
+
+func TestFromPrivateKeyBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		err  error
+	}{
+		{"valid", strings.Repeat("00", 31) + "01", nil},
+		{"zero", strings.Repeat("00", 32), ErrZeroKey},
+		{"curve order", "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", ErrKeyOutOfRange},
+		{"above curve order", strings.Repeat("ff", 32), ErrKeyOutOfRange},
+		{"31 bytes", strings.Repeat("00", 30) + "01", ErrInvalidKeyLength},
+		{"33 bytes", strings.Repeat("00", 32) + "01", ErrInvalidKeyLength},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bs, err := hex.DecodeString(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			acc, err := FromPrivateKeyBytes(bs)
+			if err != test.err {
+				t.Fatalf("error is %v, want %v", err, test.err)
+			}
+			if err == nil && acc.Address().ToBase16() != keyOneAddress {
+				t.Fatalf("address is %s, want %s", acc.Address().ToBase16(), keyOneAddress)
+			}
+		})
+	}
+}
+
+func TestFromWIF(t *testing.T) {
+	tests := []struct {
+		name string
+		wif  string
+		err  error
+	}{
+		{"compressed", "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn", nil},
+		{"uncompressed", "5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf", nil},
+		{"bad checksum", "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWo", ErrInvalidWIF},
+		{"not base58", "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoW0", ErrInvalidWIF},
+		{"empty", "", ErrInvalidWIF},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			acc, err := FromWIF(test.wif)
+			if err != test.err {
+				t.Fatalf("error is %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if acc.Address().ToBase16() != keyOneAddress {
+				t.Fatalf("address is %s, want %s", acc.Address().ToBase16(), keyOneAddress)
+			}
+			if acc.Metadata().Source != SourceWIF {
+				t.Fatalf("source is %v, want %v", acc.Metadata().Source, SourceWIF)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	acc, err := FromPrivateKeyHex(strings.Repeat("00", 31) + "01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := address.FromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = acc.Verify(expected)
+	mismatch, ok := err.(*AddressMismatchError)
+	if !ok {
+		t.Fatalf("error is %v, want *AddressMismatchError", err)
+	}
+	if mismatch.Expected != expected || mismatch.Derived != acc.Address() {
+		t.Fatalf("mismatch is %+v", mismatch)
+	}
+
+	if err := acc.Verify(acc.Address()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	acc, err := FromPrivateKeyHex("000000000000000000000000000000000000000000000000000000000000010f")
+	if err != nil {
+		b.Fatal(err)
+	}
+	tx := tron.Transaction{Id: strings.Repeat("ab", 32)}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tx.Signatures = nil
+		if err := acc.Sign(&tx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package account
+
+import "time"
+
+// Source describes how the key material of an account was obtained.
+type Source string
+
+const (
+	SourceGenerated Source = "generated"
+	SourceBytes     Source = "bytes"
+	SourceHex       Source = "hex"
+	SourceWIF       Source = "wif"
+)
+
+// Metadata is optional descriptive information attached to an account. None of it is
+// used when signing, it exists so that tooling can present accounts to humans.
+type Metadata struct {
+	Label     string    `json:"label,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Source    Source    `json:"source,omitempty"`
+}
+
+// HasTag returns if the metadata contains the provided tag.
+func (m Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata returns the metadata of the account.
+func (a *LocalAccount) Metadata() Metadata {
+	return a.meta
+}
+
+// SetLabel sets the human readable label of the account.
+func (a *LocalAccount) SetLabel(label string) {
+	a.meta.Label = label
+}
+
+// AddTag adds a tag to the account if it is not already present.
+func (a *LocalAccount) AddTag(tag string) {
+	if a.meta.HasTag(tag) {
+		return
+	}
+	a.meta.Tags = append(a.meta.Tags, tag)
+}
@@ -7,19 +7,18 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/btcsuite/btcutil/base58"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron/params"
 	"golang.org/x/crypto/sha3"
 )
 
-// All addresses are prefixed with 0x41 so that when they are encoded into base 58 they
-// start with 'T'.
-const prefix byte = 0x41
+// All addresses are prefixed so that when they are encoded into base 58 they start with 'T'.
+const prefix = params.AddressPrefix
 
 // Address is a public identifier for an account that exists on the Tron network.
-type Address [21]byte
+type Address [params.AddressLength]byte
 
-var Zero = Address([21]byte{})
+var Zero = Address{}
 
 func FromPublicKey(pub *ecdsa.PublicKey) Address {
 	// TODO(271): Remove dependencies for go-ethereum.
@@ -40,49 +39,43 @@ func FromPublicKey(pub *ecdsa.PublicKey) Address {
 	return addr
 }
 
-// FromBase16 parses a base 16 (hexadecimal) string into an address.
-func FromBase16(str string) (Address, error) {
-	bs, err := hex.DecodeString(str)
-	if err != nil {
-		return Zero, err
-	}
-
-	if len(bs) != 21 {
-		return Zero, fmt.Errorf("address: hex string is invalid length (%d)", len(bs))
-	}
-
-	var addr Address
-	copy(addr[:], bs)
-
-	return addr, err
-}
-
-// FromBase58 parses a base 58 checked string into an address.
-func FromBase58(str string) (Address, error) {
-	bs, check, err := base58.CheckDecode(str)
-	if err != nil {
-		return Zero, err
-	}
-
-	if check != prefix {
-		return Zero, fmt.Errorf("address: invalid prefix (%d)", check)
-	}
-
-	var addr Address
-	addr[0] = prefix
-	copy(addr[1:], bs)
-
-	return addr, nil
-}
-
 // ToBase16 encodes the address into a base 16 string.
 func (a Address) ToBase16() string {
-	return hex.EncodeToString(a[:])
+	var buf [params.AddressBase16Length]byte
+	return string(a.AppendBase16(buf[:0]))
 }
 
 // ToBase58 encodes the address into a checked base 58 string.
 func (a Address) ToBase58() string {
-	return base58.CheckEncode(a[1:], prefix)
+	var buf [maxBase58Len]byte
+	return string(a.AppendBase58(buf[:0]))
+}
+
+// Parse parses an address from either its base 16 or its base 58 checked encoding, as the
+// node uses depending on whether a request was made with visible addresses.
+func Parse(str string) (Address, error) {
+	switch len(str) {
+	case params.AddressBase16Length:
+		return FromBase16(str)
+	case params.AddressBase58Length:
+		return FromBase58(str)
+	default:
+		return Zero, fmt.Errorf("address: unexpected length of string (%d)", len(str))
+	}
+}
+
+// DecodeString decodes an address field of a node response into bytes. Strings the length of
+// a base 58 checked address are decoded as such and anything else as hex, without checking
+// its length, as the witness of the genesis block is longer than an address.
+func DecodeString(str string) ([]byte, error) {
+	if len(str) == params.AddressBase58Length {
+		addr, err := FromBase58(str)
+		if err != nil {
+			return nil, err
+		}
+		return addr[:], nil
+	}
+	return hex.DecodeString(str)
 }
 
 func (a *Address) UnmarshalJSON(b []byte) error {
@@ -91,20 +84,7 @@ func (a *Address) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	var (
-		addr Address
-		err  error
-	)
-
-	switch len(str) {
-	case 42:
-		addr, err = FromBase16(str)
-	case 34:
-		addr, err = FromBase58(str)
-	default:
-		return fmt.Errorf("address: unexpected length of json string (%d)", len(str))
-	}
-
+	addr, err := Parse(str)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,177 @@
+package address
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/go-chain/go-tron/params"
+)
+
+// Addresses are encoded and decoded with fixed size buffers rather than through btcutil's
+// base58 package, which allocates for every intermediate value. Indexers encode and decode
+// addresses millions of times, so these paths avoid allocating anything but the result.
+
+const (
+	alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// checkedLen is the length of an address with its 4 byte checksum appended.
+	checkedLen = 25
+
+	// maxBase58Len is the longest base 58 encoding of a checked address.
+	maxBase58Len = 35
+)
+
+var (
+	// ErrChecksum is returned when a base 58 string does not match its checksum.
+	ErrChecksum = errors.New("address: invalid checksum")
+
+	// ErrInvalidBase58 is returned when a string is not the base 58 encoding of an address.
+	ErrInvalidBase58 = errors.New("address: invalid base 58 string")
+)
+
+var decodeMap = func() (m [256]byte) {
+	for i := range m {
+		m[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		m[alphabet[i]] = byte(i)
+	}
+	return m
+}()
+
+// checksum returns the first 4 bytes of the double SHA-256 digest of the payload.
+func checksum(payload []byte) (sum [4]byte) {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	copy(sum[:], second[:4])
+	return sum
+}
+
+// AppendBase58 appends the base 58 checked encoding of the address to dst. It does not
+// allocate if dst has enough capacity.
+func (a Address) AppendBase58(dst []byte) []byte {
+	var checked [checkedLen]byte
+	checked[0] = prefix
+	copy(checked[1:], a[1:])
+	sum := checksum(checked[:len(a)])
+	copy(checked[len(a):], sum[:])
+
+	// Repeatedly multiply the digits by 256 and add each input byte, most significant first.
+	var digits [maxBase58Len]byte
+	high := len(digits) - 1
+	for _, v := range checked {
+		carry := int(v)
+		j := len(digits) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 256 * int(digits[j])
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+		high = j
+	}
+
+	// The prefix is never zero, so there are no leading zero bytes to encode as '1'.
+	i := 0
+	for i < len(digits) && digits[i] == 0 {
+		i++
+	}
+
+	for ; i < len(digits); i++ {
+		dst = append(dst, alphabet[digits[i]])
+	}
+	return dst
+}
+
+// AppendBase16 appends the base 16 encoding of the address to dst. It does not allocate if
+// dst has enough capacity.
+func (a Address) AppendBase16(dst []byte) []byte {
+	var buf [params.AddressBase16Length]byte
+	hex.Encode(buf[:], a[:])
+	return append(dst, buf[:]...)
+}
+
+// FromBase58 parses a base 58 checked string into an address. Only the canonical encoding is
+// accepted, so each address has exactly one string form. It does not allocate unless the
+// string is invalid.
+func FromBase58(str string) (Address, error) {
+	// A leading '1' encodes a leading zero byte, which the prefix rules out, and would
+	// otherwise be absorbed by the decoding below.
+	if len(str) != params.AddressBase58Length || str[0] == alphabet[0] {
+		return Zero, ErrInvalidBase58
+	}
+
+	// Repeatedly multiply the bytes by 58 and add each digit, most significant first.
+	var checked [checkedLen]byte
+	for i := 0; i < len(str); i++ {
+		carry := int(decodeMap[str[i]])
+		if carry == 0xff {
+			return Zero, ErrInvalidBase58
+		}
+
+		for j := len(checked) - 1; j >= 0; j-- {
+			carry += 58 * int(checked[j])
+			checked[j] = byte(carry)
+			carry >>= 8
+		}
+
+		if carry != 0 {
+			return Zero, ErrInvalidBase58
+		}
+	}
+
+	var addr Address
+	copy(addr[:], checked[:len(addr)])
+
+	if sum := checksum(addr[:]); sum != [4]byte{checked[21], checked[22], checked[23], checked[24]} {
+		return Zero, ErrChecksum
+	}
+
+	if addr[0] != prefix {
+		return Zero, fmt.Errorf("address: invalid prefix (%d)", addr[0])
+	}
+
+	return addr, nil
+}
+
+// FromBase16 parses a base 16 (hexadecimal) string into an address. It does not allocate
+// unless the string is invalid.
+func FromBase16(str string) (Address, error) {
+	if len(str)%2 != 0 {
+		return Zero, hex.ErrLength
+	}
+
+	if len(str) != params.AddressBase16Length {
+		return Zero, fmt.Errorf("address: hex string is invalid length (%d)", len(str)/2)
+	}
+
+	var addr Address
+	for i := range addr {
+		hi, ok := fromHexChar(str[2*i])
+		if !ok {
+			return Zero, hex.InvalidByteError(str[2*i])
+		}
+
+		lo, ok := fromHexChar(str[2*i+1])
+		if !ok {
+			return Zero, hex.InvalidByteError(str[2*i+1])
+		}
+
+		addr[i] = hi<<4 | lo
+	}
+
+	return addr, nil
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,95 @@
+package address
+
+import "testing"
+
+const benchAddress = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+func mustFromBase58(b *testing.B, str string) Address {
+	addr, err := FromBase58(str)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return addr
+}
+
+func BenchmarkFromBase58(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := FromBase58(benchAddress); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromBase16(b *testing.B) {
+	str := mustFromBase58(b, benchAddress).ToBase16()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := FromBase16(str); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToBase58(b *testing.B) {
+	addr := mustFromBase58(b, benchAddress)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		addr.ToBase58()
+	}
+}
+
+func BenchmarkToBase16(b *testing.B) {
+	addr := mustFromBase58(b, benchAddress)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		addr.ToBase16()
+	}
+}
+
+func BenchmarkAppendBase58(b *testing.B) {
+	addr := mustFromBase58(b, benchAddress)
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = addr.AppendBase58(buf[:0])
+	}
+}
+
+func TestFromBase58(t *testing.T) {
+	tests := []struct {
+		str string
+		err bool
+	}{
+		{benchAddress, false},
+		{"1" + benchAddress, true},
+		{"11" + benchAddress, true},
+		{benchAddress[:len(benchAddress)-1], true},
+		{benchAddress[:len(benchAddress)-1] + "u", true},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj60", true},
+		{"", true},
+	}
+
+	for _, test := range tests {
+		addr, err := FromBase58(test.str)
+		if test.err {
+			if err == nil {
+				t.Errorf("FromBase58(%q) = %s, want error", test.str, addr.ToBase58())
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("FromBase58(%q) failed: %v", test.str, err)
+			continue
+		}
+
+		if got := addr.ToBase58(); got != test.str {
+			t.Errorf("FromBase58(%q) encodes back to %s", test.str, got)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package approvals finds the outstanding TRC20 allowances granted by an address, so that an
+// address can be reviewed for approvals that should be revoked.
+package approvals
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// ApprovalTopic is the topic of the TRC20 event Approval(address,address,uint256).
+const ApprovalTopic = "8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
+var (
+	allowanceFunction = abi.Function{
+		Name:       "allowance",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "owner", Type: "address"}, {Name: "spender", Type: "address"}},
+		Outputs:    []abi.Value{{Name: "remaining", Type: abi.TypeUint256}},
+	}
+
+	approveFunction = abi.Function{
+		Name:       "approve",
+		Mutability: "nonpayable",
+		Inputs:     []abi.Value{{Name: "spender", Type: "address"}, {Name: "value", Type: abi.TypeUint256}},
+		Outputs:    []abi.Value{{Name: "success", Type: abi.TypeBool}},
+	}
+)
+
+// Allowance is an amount of a token that a spender may transfer on behalf of the owner.
+type Allowance struct {
+	Token   address.Address
+	Spender address.Address
+
+	// Amount is the allowance remaining now, which may be less than was approved if the
+	// spender has used some of it.
+	Amount *big.Int
+
+	// Block and TxId identify the most recent approval found for the spender.
+	Block uint64
+	TxId  string
+}
+
+// Scan finds the Approval events emitted for an owner within a range of block heights, end
+// exclusive, and returns the allowances which are still outstanding. The node has no index of
+// events, so every block in the range is requested; ranges should be kept to the heights the
+// owner was active in.
+//
+// Only TRC20 approvals are reported, TRC721 approvals have the same signature but index the
+// token id and are skipped.
+func Scan(c *client.Client, owner address.Address, start, end uint64) ([]Allowance, error) {
+	type key struct {
+		token, spender address.Address
+	}
+
+	ownerTopic := topic(owner)
+
+	latest := make(map[key]Allowance)
+	for n := start; n < end; n++ {
+		infos, err := c.GetTransactionInfoByBlockNum(n)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range infos {
+			logs, err := info.Logs()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, log := range logs {
+				if len(log.Topics) != 3 || log.Topics[0] != ApprovalTopic || log.Topics[1] != ownerTopic {
+					continue
+				}
+
+				if len(log.Topics[2]) != len(ownerTopic) {
+					continue
+				}
+
+				token, err := evmAddress(log.Address)
+				if err != nil {
+					return nil, err
+				}
+
+				spender, err := evmAddress(log.Topics[2][24:])
+				if err != nil {
+					return nil, err
+				}
+
+				latest[key{token, spender}] = Allowance{
+					Token:   token,
+					Spender: spender,
+					Block:   n,
+					TxId:    info.Id,
+				}
+			}
+		}
+	}
+
+	var allowances []Allowance
+	for _, a := range latest {
+		amount, err := allowance(c, owner, a.Token, a.Spender)
+		if err != nil {
+			return nil, err
+		}
+
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		a.Amount = amount
+		allowances = append(allowances, a)
+	}
+
+	sort.Slice(allowances, func(i, j int) bool {
+		return allowances[i].Block < allowances[j].Block
+	})
+
+	return allowances, nil
+}
+
+// RevokeTransactions creates and signs a transaction for each allowance which approves its
+// spender for zero. The transactions are not broadcast.
+func RevokeTransactions(c *client.Client, owner account.Account, allowances []Allowance, feeLimit params.Sun) ([]tron.Transaction, error) {
+	txs := make([]tron.Transaction, 0, len(allowances))
+	for _, a := range allowances {
+		tx, err := c.CallContract(owner, client.CallContractInput{
+			Address:   a.Token,
+			Function:  approveFunction,
+			Arguments: []interface{}{a.Spender, new(big.Int)},
+			FeeLimit:  uint64(feeLimit),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// allowance queries the current allowance of a spender.
+func allowance(c *client.Client, owner, token, spender address.Address) (*big.Int, error) {
+	var result struct {
+		Remaining *big.Int `abi:"remaining"`
+	}
+
+	_, err := c.CallContract(account.WatchOnly(owner), client.CallContractInput{
+		Address:   token,
+		Function:  allowanceFunction,
+		Arguments: []interface{}{owner, spender},
+		Result:    &result,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Remaining == nil {
+		return new(big.Int), nil
+	}
+
+	return result.Remaining, nil
+}
+
+// topic returns the topic of an indexed address, which is its 20 byte EVM form left padded
+// to 32 bytes.
+func topic(addr address.Address) string {
+	return strings.Repeat("0", 24) + hex.EncodeToString(addr[1:])
+}
+
+// evmAddress parses the 20 byte hex form of an address used in logs.
+func evmAddress(s string) (address.Address, error) {
+	return address.FromBase16(hex.EncodeToString([]byte{params.AddressPrefix}) + s)
+}
@@ -13,7 +13,22 @@ type BlockHeader struct {
 		WitnessAddress      string `json:"witness_address"`
 		ParentHash          string `json:"parentHash"`
 		Version             uint64 `json:"version"`
-		Timestamp           uint64 `json:"timestamp"`
+		Timestamp           Time   `json:"timestamp"`
 	} `json:"raw_data"`
 	WitnessSignature string `json:"witness_signature"`
 }
+
+// BlockHeaderOnly is a block without its transactions. It is used where only the chain
+// of headers is of interest so that transaction payloads are not held in memory.
+type BlockHeaderOnly struct {
+	Id          string      `json:"blockId"`
+	BlockHeader BlockHeader `json:"block_header"`
+}
+
+// HeaderOnly returns the block with its transactions stripped.
+func (b *Block) HeaderOnly() BlockHeaderOnly {
+	return BlockHeaderOnly{
+		Id:          b.Id,
+		BlockHeader: b.BlockHeader,
+	}
+}
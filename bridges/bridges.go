@@ -0,0 +1,176 @@
+// Package bridges monitors cross-chain bridge contracts for deposits and withdrawals affecting
+// tracked addresses, correlating the lock on one side of a bridge with the mint on the other.
+//
+// Each side of a bridge is a Source. TronSource watches contracts on Tron through a client,
+// the other side, such as BTTC for the BTTC bridge, is watched through a Source implemented
+// with a client for that chain.
+package bridges
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+// Direction is the direction funds move across a bridge.
+type Direction int
+
+const (
+	// Deposit moves funds from Tron to the other chain.
+	Deposit Direction = iota
+
+	// Withdraw moves funds from the other chain to Tron.
+	Withdraw
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Deposit:
+		return "deposit"
+	case Withdraw:
+		return "withdraw"
+	default:
+		return "unknown"
+	}
+}
+
+// Stage is the step of a transfer that an event records.
+type Stage int
+
+const (
+	// Lock is when funds are locked or burnt on the side they leave.
+	Lock Stage = iota
+
+	// Mint is when funds are minted or released on the side they arrive.
+	Mint
+)
+
+func (s Stage) String() string {
+	switch s {
+	case Lock:
+		return "lock"
+	case Mint:
+		return "mint"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a deposit or withdraw event emitted by a bridge contract.
+type Event struct {
+	// Chain is the name of the chain the event was emitted on.
+	Chain string
+
+	Direction Direction
+	Stage     Stage
+
+	// Ref is shared by the lock and mint events of a transfer, such as the deposit id or the
+	// hash of the lock transaction, and is used to correlate them.
+	Ref string
+
+	// Account is the address the funds leave for a lock or arrive at for a mint, in the form
+	// used by the chain it was emitted on.
+	Account string
+
+	Token  string
+	Amount *big.Int
+
+	Block uint64
+	TxId  string
+}
+
+// Source is one side of a bridge.
+type Source interface {
+	// Chain returns the name of the chain.
+	Chain() string
+
+	// Head returns the height of the latest block.
+	Head(ctx context.Context) (uint64, error)
+
+	// Events returns the bridge events within a range of block heights, end exclusive.
+	Events(ctx context.Context, start, end uint64) ([]Event, error)
+}
+
+// Decoder decodes a log emitted by a bridge contract into an event, returning false if the log
+// is not a deposit or withdraw event. Chain, Block and TxId are filled in by the source.
+type Decoder func(log client.Log) (Event, bool, error)
+
+// TronSource is the Tron side of a bridge, watching the logs of its contracts.
+type TronSource struct {
+	client    *client.Client
+	contracts map[string]Decoder
+}
+
+// NewTronSource creates a source which decodes the logs of each contract with its decoder.
+func NewTronSource(c *client.Client, contracts map[address.Address]Decoder) *TronSource {
+	s := &TronSource{
+		client:    c,
+		contracts: make(map[string]Decoder, len(contracts)),
+	}
+
+	// Logs identify their contract by the 20 byte EVM form of its address.
+	for addr, decode := range contracts {
+		s.contracts[hex.EncodeToString(addr[1:])] = decode
+	}
+
+	return s
+}
+
+func (s *TronSource) Chain() string {
+	return "tron"
+}
+
+func (s *TronSource) Head(ctx context.Context) (uint64, error) {
+	block, err := s.client.GetLatestBlock()
+	if err != nil {
+		return 0, err
+	}
+	return block.BlockHeader.RawData.Number, nil
+}
+
+func (s *TronSource) Events(ctx context.Context, start, end uint64) ([]Event, error) {
+	var events []Event
+	for n := start; n < end; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		infos, err := s.client.GetTransactionInfoByBlockNum(n)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range infos {
+			logs, err := info.Logs()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, log := range logs {
+				decode, ok := s.contracts[log.Address]
+				if !ok {
+					continue
+				}
+
+				event, ok, err := decode(log)
+				if err != nil {
+					return nil, err
+				}
+
+				if !ok {
+					continue
+				}
+
+				event.Chain = s.Chain()
+				event.Block = n
+				event.TxId = info.Id
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,189 @@
+package bridges
+
+import (
+	"context"
+	"sort"
+)
+
+// maxRange is the most blocks of a side that are scanned in a single poll.
+const maxRange = 100
+
+// Transfer is the movement of funds across a bridge, made of the lock on the side the funds
+// leave and, once it has happened, the mint on the side they arrive.
+type Transfer struct {
+	Bridge string
+	Lock   Event
+	Mint   *Event
+}
+
+// Complete returns whether the funds have arrived.
+func (t Transfer) Complete() bool {
+	return t.Mint != nil
+}
+
+// Option configures a monitor.
+type Option func(*Monitor)
+
+// WithStart scans a side of the bridge from the block at the provided height, rather than from
+// its latest block when the monitor is first polled.
+func WithStart(chain string, height uint64) Option {
+	return func(m *Monitor) {
+		for _, s := range m.sides {
+			if s.source.Chain() == chain {
+				s.cursor = height
+				s.started = true
+			}
+		}
+	}
+}
+
+// WithAddresses tracks transfers made from or to the provided addresses.
+func WithAddresses(addrs ...string) Option {
+	return func(m *Monitor) {
+		for _, addr := range addrs {
+			m.Track(addr)
+		}
+	}
+}
+
+type side struct {
+	source  Source
+	cursor  uint64
+	started bool
+}
+
+// Monitor watches both sides of a bridge for transfers affecting tracked addresses. A monitor
+// is not safe for concurrent use.
+type Monitor struct {
+	bridge  string
+	sides   [2]*side
+	tracked map[string]bool
+
+	// pending are the locks which have not yet been minted and orphans are the mints whose
+	// lock has not yet been seen, both by reference.
+	pending map[string]Transfer
+	orphans map[string]Event
+}
+
+// NewMonitor creates a monitor for a bridge between two sources.
+func NewMonitor(bridge string, a, b Source, opts ...Option) *Monitor {
+	m := &Monitor{
+		bridge:  bridge,
+		sides:   [2]*side{{source: a}, {source: b}},
+		tracked: make(map[string]bool),
+		pending: make(map[string]Transfer),
+		orphans: make(map[string]Event),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Track tracks transfers made from or to an address, in the form used by its chain.
+func (m *Monitor) Track(addr string) {
+	m.tracked[addr] = true
+}
+
+// Untrack stops tracking an address. Transfers already pending are still completed.
+func (m *Monitor) Untrack(addr string) {
+	delete(m.tracked, addr)
+}
+
+// Poll scans both sides of the bridge for new events and returns the transfers that were
+// started or completed by them. Each poll scans at most 100 blocks of each side, so a monitor
+// which has fallen behind catches up over several polls.
+func (m *Monitor) Poll(ctx context.Context) ([]Transfer, error) {
+	var events []Event
+	for _, s := range m.sides {
+		head, err := s.source.Head(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.started {
+			s.cursor = head
+			s.started = true
+		}
+
+		end := head + 1
+		if end > s.cursor+maxRange {
+			end = s.cursor + maxRange
+		}
+
+		if end <= s.cursor {
+			continue
+		}
+
+		found, err := s.source.Events(ctx, s.cursor, end)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, found...)
+		s.cursor = end
+	}
+
+	// Locks are handled first so that a mint found in the same poll as its lock completes it.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Stage < events[j].Stage
+	})
+
+	var transfers []Transfer
+	for _, e := range events {
+		switch e.Stage {
+		case Lock:
+			// A lock from an untracked account is still of interest when its mint is to a
+			// tracked one.
+			mint, orphan := m.orphans[e.Ref]
+			if !orphan && !m.tracked[e.Account] {
+				continue
+			}
+
+			t := Transfer{Bridge: m.bridge, Lock: e}
+			if orphan {
+				delete(m.orphans, e.Ref)
+				t.Mint = &mint
+			} else {
+				m.pending[e.Ref] = t
+			}
+			transfers = append(transfers, t)
+		case Mint:
+			t, ok := m.pending[e.Ref]
+			if !ok {
+				if m.tracked[e.Account] {
+					m.orphans[e.Ref] = e
+				}
+				continue
+			}
+
+			delete(m.pending, e.Ref)
+			mint := e
+			t.Mint = &mint
+			transfers = append(transfers, t)
+		}
+	}
+
+	return transfers, nil
+}
+
+// Pending returns the transfers whose funds have not yet arrived, oldest first within each
+// side.
+func (m *Monitor) Pending() []Transfer {
+	transfers := make([]Transfer, 0, len(m.pending))
+	for _, t := range m.pending {
+		transfers = append(transfers, t)
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		a, b := transfers[i].Lock, transfers[j].Lock
+		if a.Chain != b.Chain {
+			return a.Chain < b.Chain
+		}
+		return a.Block < b.Block
+	})
+
+	return transfers
+}
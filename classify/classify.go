@@ -0,0 +1,156 @@
+// Package classify labels addresses by their activity, such as whether they are contracts,
+// exchange wallets or newly activated, for risk scoring before sending funds to them.
+package classify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/labels"
+)
+
+// Class is the kind of an address.
+type Class string
+
+const (
+	// Contract addresses have a contract deployed.
+	Contract Class = "contract"
+
+	// Exchange addresses are wallets of an exchange, as labelled in the exchange namespace.
+	Exchange Class = "exchange"
+
+	// Fresh addresses have not been activated or were activated recently.
+	Fresh Class = "fresh"
+
+	// Inactive addresses have had no activity for a long time.
+	Inactive Class = "inactive"
+
+	// Normal addresses are activated accounts in regular use.
+	Normal Class = "normal"
+)
+
+// Classification is the class of an address along with why it was given.
+type Classification struct {
+	Address address.Address
+	Class   Class
+
+	// Label is the name of the exchange for exchange addresses.
+	Label string
+
+	// Reason describes why the address was given its class.
+	Reason string
+}
+
+// Default ages, see WithFreshAge and WithInactiveAge.
+const (
+	DefaultFreshAge    = 7 * 24 * time.Hour
+	DefaultInactiveAge = 180 * 24 * time.Hour
+)
+
+// Classifier classifies addresses using a node and a set of labels.
+type Classifier struct {
+	client *client.Client
+	labels *labels.Set
+
+	freshAge    time.Duration
+	inactiveAge time.Duration
+}
+
+// Option configures optional behaviour of a classifier.
+type Option func(*Classifier)
+
+// WithLabels sets the labels that exchange wallets are identified by, in the labels.Exchange
+// namespace.
+func WithLabels(set *labels.Set) Option {
+	return func(cl *Classifier) {
+		cl.labels = set
+	}
+}
+
+// WithFreshAge sets how recently an account must have been activated to be fresh.
+func WithFreshAge(d time.Duration) Option {
+	return func(cl *Classifier) {
+		cl.freshAge = d
+	}
+}
+
+// WithInactiveAge sets how long an account must have had no activity to be inactive.
+func WithInactiveAge(d time.Duration) Option {
+	return func(cl *Classifier) {
+		cl.inactiveAge = d
+	}
+}
+
+// New creates a classifier. No exchange labels are included by default as they change over
+// time, they should be loaded from a maintained dataset and set with WithLabels.
+func New(c *client.Client, opts ...Option) *Classifier {
+	cl := &Classifier{
+		client:      c,
+		labels:      labels.New(),
+		freshAge:    DefaultFreshAge,
+		inactiveAge: DefaultInactiveAge,
+	}
+
+	for _, opt := range opts {
+		opt(cl)
+	}
+
+	return cl
+}
+
+// Classify classifies an address. Exchange labels take precedence, followed by contracts and
+// then the activity of the account.
+func (cl *Classifier) Classify(addr address.Address) (*Classification, error) {
+	result := &Classification{Address: addr}
+
+	if name, ok := cl.labels.Get(labels.Exchange, addr); ok {
+		result.Class = Exchange
+		result.Label = name
+		result.Reason = "labelled as " + name
+		return result, nil
+	}
+
+	contract, err := cl.client.IsContract(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if contract {
+		result.Class = Contract
+		result.Reason = "contract is deployed"
+		return result, nil
+	}
+
+	state, err := cl.client.GetAccountState(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case state == nil:
+		result.Class = Fresh
+		result.Reason = "account is not activated"
+	case now.Sub(state.CreateTime.Time()) < cl.freshAge:
+		result.Class = Fresh
+		result.Reason = fmt.Sprintf("account was activated at %s", state.CreateTime.Time().UTC().Format(time.RFC3339))
+	default:
+		// Accounts which have only received funds have no operation time.
+		last := state.LatestOperationTime
+		if last < state.CreateTime {
+			last = state.CreateTime
+		}
+
+		if now.Sub(last.Time()) >= cl.inactiveAge {
+			result.Class = Inactive
+			result.Reason = fmt.Sprintf("account was last active at %s", last.Time().UTC().Format(time.RFC3339))
+		} else {
+			result.Class = Normal
+			result.Reason = "account is active"
+		}
+	}
+
+	return result, nil
+}
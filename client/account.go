@@ -0,0 +1,70 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+)
+
+// maxAccountNameLength is the longest account name, in bytes, that the node accepts.
+const maxAccountNameLength = 200
+
+// CreateAccount creates and signs a transaction which activates a new address, paid for by an
+// existing account. The transaction is not broadcast.
+func (c *Client) CreateAccount(owner account.Account, addr address.Address) (tron.Transaction, error) {
+	var request = struct {
+		Owner   string `json:"owner_address"`
+		Account string `json:"account_address"`
+	}{
+		Owner:   c.encodeAddress(owner.Address()),
+		Account: c.encodeAddress(addr),
+	}
+
+	var tx tron.Transaction
+	if err := c.post("wallet/createaccount", &request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := owner.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
+// UpdateAccount creates and signs a transaction which sets the name of an account. A name can
+// only be set once. The transaction is not broadcast.
+func (c *Client) UpdateAccount(owner account.Account, name string) (tron.Transaction, error) {
+	if len(name) == 0 || len(name) > maxAccountNameLength {
+		return tron.Transaction{}, fmt.Errorf("client: account name must be between 1 and %d bytes (%d)", maxAccountNameLength, len(name))
+	}
+
+	var request = struct {
+		Owner string `json:"owner_address"`
+		Name  string `json:"account_name"`
+	}{
+		Owner: c.encodeAddress(owner.Address()),
+		Name:  c.encodeString(name),
+	}
+
+	var tx tron.Transaction
+	if err := c.post("wallet/updateaccount", &request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := owner.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
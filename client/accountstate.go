@@ -0,0 +1,337 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Vote is a vote cast by an account for a witness.
+type Vote struct {
+	Address address.Address `json:"vote_address"`
+	Count   int64           `json:"vote_count"`
+}
+
+// PermissionKey is a key that may sign for a permission, along with the weight of its signature.
+type PermissionKey struct {
+	Address address.Address `json:"address"`
+	Weight  int64           `json:"weight"`
+}
+
+// Permission is a set of keys that together may sign some or all types of transactions.
+type Permission struct {
+	Type       string          `json:"type"`
+	Id         int32           `json:"id"`
+	Name       string          `json:"permission_name"`
+	Threshold  int64           `json:"threshold"`
+	Operations string          `json:"operations"`
+	Keys       []PermissionKey `json:"keys"`
+}
+
+// AccountState is the full state of an account: its balances, stakes, votes and permissions.
+type AccountState struct {
+	Address address.Address
+	Balance params.Sun
+
+	// Frozen are the balances frozen by the account, both under Stake 1.0 and Stake 2.0.
+	Frozen []FrozenBalance
+
+	// Votes are the votes cast by the account, sorted by witness address.
+	Votes []Vote
+
+	// Assets are the TRC10 balances of the account by token id.
+	Assets map[string]int64
+
+	// CreateTime is when the account was activated and LatestOperationTime when it last sent a
+	// transaction, zero if it never has.
+	CreateTime          tron.Time
+	LatestOperationTime tron.Time
+
+	OwnerPermission   *Permission
+	WitnessPermission *Permission
+	ActivePermissions []Permission
+}
+
+// accountJSON is the account returned by wallet/getaccount.
+type accountJSON struct {
+	Address address.Address `json:"address"`
+	Balance params.Sun      `json:"balance"`
+	Votes   []Vote          `json:"votes"`
+	AssetV2 []V2            `json:"assetV2"`
+
+	CreateTime          tron.Time `json:"create_time"`
+	LatestOperationTime tron.Time `json:"latest_opration_time"`
+
+	Frozen          []frozenJSON `json:"frozen"`
+	AccountResource struct {
+		FrozenForEnergy frozenJSON `json:"frozen_balance_for_energy"`
+	} `json:"account_resource"`
+	FrozenV2 []struct {
+		Type   Resource `json:"type"`
+		Amount int64    `json:"amount"`
+	} `json:"frozenV2"`
+
+	OwnerPermission   *Permission  `json:"owner_permission"`
+	WitnessPermission *Permission  `json:"witness_permission"`
+	ActivePermissions []Permission `json:"active_permission"`
+}
+
+type frozenJSON struct {
+	Amount     int64     `json:"frozen_balance"`
+	ExpireTime tron.Time `json:"expire_time"`
+}
+
+// frozen returns the balances frozen by the account under Stake 1.0 and Stake 2.0.
+func (a *accountJSON) frozen() []FrozenBalance {
+	var balances []FrozenBalance
+
+	for _, f := range a.Frozen {
+		balances = append(balances, FrozenBalance{
+			Resource:   ResourceBandwidth,
+			Amount:     f.Amount,
+			ExpireTime: f.ExpireTime,
+		})
+	}
+
+	if f := a.AccountResource.FrozenForEnergy; f.Amount > 0 {
+		balances = append(balances, FrozenBalance{
+			Resource:   ResourceEnergy,
+			Amount:     f.Amount,
+			ExpireTime: f.ExpireTime,
+		})
+	}
+
+	for _, f := range a.FrozenV2 {
+		if f.Amount == 0 {
+			continue
+		}
+
+		// Bandwidth is the default resource and so its type is omitted.
+		r := f.Type
+		if r == "" {
+			r = ResourceBandwidth
+		}
+
+		balances = append(balances, FrozenBalance{
+			Resource: r,
+			Amount:   f.Amount,
+			V2:       true,
+		})
+	}
+
+	return balances
+}
+
+// getAccountJSON returns the account with the provided address, or nil if it does not exist.
+func (c *Client) getAccountJSON(addr address.Address) (*accountJSON, error) {
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(addr),
+	}
+
+	var account accountJSON
+	if err := c.post("wallet/getaccount", &request, &account); err != nil {
+		return nil, err
+	}
+
+	// Accounts that exist will always have an address returned.
+	if account.Address == address.Zero {
+		return nil, nil
+	}
+
+	return &account, nil
+}
+
+// GetAccountState returns the full state of an account, or nil if the account does not exist.
+func (c *Client) GetAccountState(addr address.Address) (*AccountState, error) {
+	account, err := c.getAccountJSON(addr)
+	if err != nil || account == nil {
+		return nil, err
+	}
+
+	state := &AccountState{
+		Address:             account.Address,
+		Balance:             account.Balance,
+		Frozen:              account.frozen(),
+		Votes:               account.Votes,
+		Assets:              make(map[string]int64, len(account.AssetV2)),
+		CreateTime:          account.CreateTime,
+		LatestOperationTime: account.LatestOperationTime,
+		OwnerPermission:     account.OwnerPermission,
+		WitnessPermission:   account.WitnessPermission,
+		ActivePermissions:   account.ActivePermissions,
+	}
+
+	sort.Slice(state.Votes, func(i, j int) bool {
+		return string(state.Votes[i].Address[:]) < string(state.Votes[j].Address[:])
+	})
+
+	for _, asset := range account.AssetV2 {
+		state.Assets[asset.Key] = asset.Value
+	}
+
+	return state, nil
+}
+
+// AccountSnapshot is the state of an account along with the height of the chain when it was
+// captured. Nodes cannot return the state of an account at a past height, so investigations
+// into how an account changed rely on snapshots captured at the heights of interest.
+type AccountSnapshot struct {
+	// Height is the head of the chain just before the state was queried. The state may
+	// include the effects of blocks produced between the two queries.
+	Height uint64
+
+	// State is nil if the account did not exist.
+	State *AccountState
+}
+
+// SnapshotAccount captures the current state of an account.
+func (c *Client) SnapshotAccount(addr address.Address) (*AccountSnapshot, error) {
+	// An empty id or height requests the latest block.
+	head, err := c.GetBlockHeader("")
+	if err != nil {
+		return nil, err
+	}
+
+	if head == nil {
+		return nil, errors.New("client: node did not return the latest block")
+	}
+
+	state, err := c.GetAccountState(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountSnapshot{Height: head.BlockHeader.RawData.Number, State: state}, nil
+}
+
+// AccountChange is a field of an account's state which differs between two snapshots.
+type AccountChange struct {
+	// Field names the part of the state that changed, for example "balance",
+	// "frozen[ENERGY,v2]", "votes[T...]", "assets[1002000]" or "permissions.active[2]".
+	Field string
+
+	// Old and New are the formatted values, empty if the field was absent.
+	Old string
+	New string
+}
+
+// AccountDiff is the difference between two snapshots of an account.
+type AccountDiff struct {
+	FromHeight uint64
+	ToHeight   uint64
+	Changes    []AccountChange
+}
+
+// DiffAccount compares two snapshots of an account, returning every field of its state which
+// differs. An account which did not exist is compared as if it had an empty state.
+func DiffAccount(from, to *AccountSnapshot) AccountDiff {
+	diff := AccountDiff{FromHeight: from.Height, ToHeight: to.Height}
+
+	a, b := from.State, to.State
+	if a == nil {
+		a = new(AccountState)
+	}
+	if b == nil {
+		b = new(AccountState)
+	}
+
+	change := func(field string, old, new string) {
+		if old != new {
+			diff.Changes = append(diff.Changes, AccountChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	change("balance", fmt.Sprint(a.Balance), fmt.Sprint(b.Balance))
+
+	diffKeyed(frozenByKey(a.Frozen), frozenByKey(b.Frozen), "frozen", change)
+	diffKeyed(votesByKey(a.Votes), votesByKey(b.Votes), "votes", change)
+	diffKeyed(assetsByKey(a.Assets), assetsByKey(b.Assets), "assets", change)
+
+	change("permissions.owner", formatPermission(a.OwnerPermission), formatPermission(b.OwnerPermission))
+	change("permissions.witness", formatPermission(a.WitnessPermission), formatPermission(b.WitnessPermission))
+
+	active := func(perms []Permission) map[string]string {
+		m := make(map[string]string, len(perms))
+		for i := range perms {
+			m[fmt.Sprint(perms[i].Id)] = formatPermission(&perms[i])
+		}
+		return m
+	}
+	diffKeyed(active(a.ActivePermissions), active(b.ActivePermissions), "permissions.active", change)
+
+	return diff
+}
+
+// diffKeyed reports a change for each key whose formatted value differs between two maps.
+func diffKeyed(a, b map[string]string, field string, change func(field, old, new string)) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		change(fmt.Sprintf("%s[%s]", field, k), a[k], b[k])
+	}
+}
+
+func frozenByKey(frozen []FrozenBalance) map[string]string {
+	m := make(map[string]string, len(frozen))
+	for _, f := range frozen {
+		key := string(f.Resource)
+		if f.V2 {
+			key += ",v2"
+		}
+
+		if f.ExpireTime != 0 {
+			m[key] = fmt.Sprintf("%d (expires %s)", f.Amount, f.ExpireTime)
+		} else {
+			m[key] = fmt.Sprint(f.Amount)
+		}
+	}
+	return m
+}
+
+func votesByKey(votes []Vote) map[string]string {
+	m := make(map[string]string, len(votes))
+	for _, v := range votes {
+		m[v.Address.ToBase58()] = fmt.Sprint(v.Count)
+	}
+	return m
+}
+
+func assetsByKey(assets map[string]int64) map[string]string {
+	m := make(map[string]string, len(assets))
+	for k, v := range assets {
+		m[k] = fmt.Sprint(v)
+	}
+	return m
+}
+
+// formatPermission formats a permission so that permissions can be compared as strings.
+func formatPermission(p *Permission) string {
+	if p == nil || reflect.DeepEqual(*p, Permission{}) {
+		return ""
+	}
+
+	s := fmt.Sprintf("%s threshold=%d", p.Name, p.Threshold)
+	if p.Operations != "" {
+		s += " operations=" + p.Operations
+	}
+	for _, k := range p.Keys {
+		s += fmt.Sprintf(" %s:%d", k.Address.ToBase58(), k.Weight)
+	}
+	return s
+}
@@ -0,0 +1,394 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/units"
+)
+
+// FrozenSupply is an amount of a new asset that is frozen for the issuer for a number of days.
+type FrozenSupply struct {
+	Amount int64
+	Days   int64
+}
+
+// AssetIssueInput describes a TRC10 asset to issue. Name, Abbr, Description and Url are plain
+// text.
+type AssetIssueInput struct {
+	Name        string
+	Abbr        string
+	Description string
+	Url         string
+
+	// TotalSupply is in the smallest unit of the asset, and Precision is the number of decimal
+	// places of the asset, at most 6.
+	TotalSupply int64
+	Precision   int32
+
+	// FrozenSupply is the schedule of supply frozen for the issuer.
+	FrozenSupply []FrozenSupply
+
+	// TrxNum sun buys Num of the smallest unit of the asset during the ICO, which runs
+	// between StartTime and EndTime.
+	TrxNum    int64
+	Num       int64
+	StartTime time.Time
+	EndTime   time.Time
+
+	// FreeAssetNetLimit is the bandwidth each account may use for free when transferring the
+	// asset, and PublicFreeAssetNetLimit the total bandwidth for all accounts.
+	FreeAssetNetLimit       int64
+	PublicFreeAssetNetLimit int64
+}
+
+// maxAssetPrecision is the most decimal places that an asset may have.
+const maxAssetPrecision = 6
+
+// CreateAssetIssue creates and signs a transaction which issues a TRC10 asset. An account may
+// only issue one asset. The transaction is not broadcast.
+func (c *Client) CreateAssetIssue(owner account.Account, input AssetIssueInput) (tron.Transaction, error) {
+	switch {
+	case input.Name == "":
+		return tron.Transaction{}, errors.New("client: asset must have a name")
+	case input.TotalSupply <= 0:
+		return tron.Transaction{}, fmt.Errorf("client: asset total supply must be positive (%d)", input.TotalSupply)
+	case input.Precision < 0 || input.Precision > maxAssetPrecision:
+		return tron.Transaction{}, fmt.Errorf("client: asset precision must be between 0 and %d (%d)", maxAssetPrecision, input.Precision)
+	case input.TrxNum <= 0 || input.Num <= 0:
+		return tron.Transaction{}, fmt.Errorf("client: asset ico ratio must be positive (%d:%d)", input.TrxNum, input.Num)
+	case !input.EndTime.After(input.StartTime):
+		return tron.Transaction{}, errors.New("client: asset ico must end after it starts")
+	}
+
+	type frozen struct {
+		Amount int64 `json:"frozen_amount"`
+		Days   int64 `json:"frozen_days"`
+	}
+
+	var request = struct {
+		Owner                   string   `json:"owner_address"`
+		Name                    string   `json:"name"`
+		Abbr                    string   `json:"abbr,omitempty"`
+		Description             string   `json:"description,omitempty"`
+		Url                     string   `json:"url,omitempty"`
+		TotalSupply             int64    `json:"total_supply"`
+		Precision               int32    `json:"precision"`
+		FrozenSupply            []frozen `json:"frozen_supply,omitempty"`
+		TrxNum                  int64    `json:"trx_num"`
+		Num                     int64    `json:"num"`
+		StartTime               int64    `json:"start_time"`
+		EndTime                 int64    `json:"end_time"`
+		FreeAssetNetLimit       int64    `json:"free_asset_net_limit"`
+		PublicFreeAssetNetLimit int64    `json:"public_free_asset_net_limit"`
+	}{
+		Owner:                   c.encodeAddress(owner.Address()),
+		Name:                    c.encodeString(input.Name),
+		Abbr:                    c.encodeString(input.Abbr),
+		Description:             c.encodeString(input.Description),
+		Url:                     c.encodeString(input.Url),
+		TotalSupply:             input.TotalSupply,
+		Precision:               input.Precision,
+		TrxNum:                  input.TrxNum,
+		Num:                     input.Num,
+		StartTime:               millis(input.StartTime),
+		EndTime:                 millis(input.EndTime),
+		FreeAssetNetLimit:       input.FreeAssetNetLimit,
+		PublicFreeAssetNetLimit: input.PublicFreeAssetNetLimit,
+	}
+
+	for _, f := range input.FrozenSupply {
+		if f.Amount <= 0 || f.Days <= 0 {
+			return tron.Transaction{}, fmt.Errorf("client: frozen supply must be positive (%d for %d days)", f.Amount, f.Days)
+		}
+		request.FrozenSupply = append(request.FrozenSupply, frozen{Amount: f.Amount, Days: f.Days})
+	}
+
+	return c.createTransaction(owner, "wallet/createassetissue", &request)
+}
+
+// UpdateAssetInput describes changes to an issued TRC10 asset.
+type UpdateAssetInput struct {
+	Description string
+	Url         string
+
+	// NewLimit and NewPublicLimit replace the free bandwidth limits of the asset, see
+	// AssetIssueInput.
+	NewLimit       int64
+	NewPublicLimit int64
+}
+
+// UpdateAsset creates and signs a transaction which updates the asset issued by an account.
+// The transaction is not broadcast.
+func (c *Client) UpdateAsset(owner account.Account, input UpdateAssetInput) (tron.Transaction, error) {
+	var request = struct {
+		Owner          string `json:"owner_address"`
+		Description    string `json:"description,omitempty"`
+		Url            string `json:"url,omitempty"`
+		NewLimit       int64  `json:"new_limit"`
+		NewPublicLimit int64  `json:"new_public_limit"`
+	}{
+		Owner:          c.encodeAddress(owner.Address()),
+		Description:    c.encodeString(input.Description),
+		Url:            c.encodeString(input.Url),
+		NewLimit:       input.NewLimit,
+		NewPublicLimit: input.NewPublicLimit,
+	}
+
+	return c.createTransaction(owner, "wallet/updateasset", &request)
+}
+
+// UnfreezeAsset creates and signs a transaction which unfreezes the supply of an issued asset
+// whose frozen days have passed. The transaction is not broadcast.
+func (c *Client) UnfreezeAsset(owner account.Account) (tron.Transaction, error) {
+	var request = struct {
+		Owner string `json:"owner_address"`
+	}{
+		Owner: c.encodeAddress(owner.Address()),
+	}
+
+	return c.createTransaction(owner, "wallet/unfreezeasset", &request)
+}
+
+// AssetIssue is an issued TRC10 asset.
+type AssetIssue struct {
+	// Id is the id of the asset, which TransferAsset identifies assets by.
+	Id    string
+	Owner address.Address
+
+	Name        string
+	Abbr        string
+	Description string
+	Url         string
+
+	// TotalSupply is in the smallest unit of the asset, and Precision is the number of decimal
+	// places of the asset.
+	TotalSupply int64
+	Precision   int32
+
+	FrozenSupply []FrozenSupply
+
+	TrxNum    int64
+	Num       int64
+	StartTime time.Time
+	EndTime   time.Time
+
+	FreeAssetNetLimit       int64
+	PublicFreeAssetNetLimit int64
+}
+
+// FormatAmount formats an amount in the smallest unit of the asset as a decimal string, such as
+// "12.5" for 1250 of an asset with a precision of 2.
+func (a AssetIssue) FormatAmount(amount int64) string {
+	str := strconv.FormatInt(amount, 10)
+	if a.Precision <= 0 {
+		return str
+	}
+
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+
+	if n := int(a.Precision) + 1 - len(str); n > 0 {
+		str = strings.Repeat("0", n) + str
+	}
+
+	whole, frac := str[:len(str)-int(a.Precision)], strings.TrimRight(str[len(str)-int(a.Precision):], "0")
+	if frac != "" {
+		whole += "." + frac
+	}
+
+	if negative {
+		whole = "-" + whole
+	}
+
+	return whole
+}
+
+// Amount returns an amount in the smallest unit of the asset as an amount with its precision
+// and abbreviation.
+func (a AssetIssue) Amount(raw int64) units.Amount {
+	return units.FromInt64(raw, int(a.Precision), a.Abbr)
+}
+
+// ParseAmount parses a decimal string into an amount in the smallest unit of the asset. More
+// decimal places than the precision of the asset is an error rather than being rounded.
+func (a AssetIssue) ParseAmount(str string) (int64, error) {
+	whole, frac := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		whole, frac = str[:i], str[i+1:]
+	}
+
+	if len(frac) > int(a.Precision) {
+		return 0, fmt.Errorf("client: amount has more than %d decimal places (%s)", a.Precision, str)
+	}
+
+	if whole == "" || whole == "-" || strings.ContainsAny(frac, "+-") {
+		return 0, fmt.Errorf("client: invalid amount (%s)", str)
+	}
+
+	amount, err := strconv.ParseInt(whole+frac+strings.Repeat("0", int(a.Precision)-len(frac)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("client: invalid amount (%s)", str)
+	}
+
+	return amount, nil
+}
+
+// assetIssueJSON is an asset as returned by the node.
+type assetIssueJSON struct {
+	Id           string          `json:"id"`
+	Owner        address.Address `json:"owner_address"`
+	Name         string          `json:"name"`
+	Abbr         string          `json:"abbr"`
+	Description  string          `json:"description"`
+	Url          string          `json:"url"`
+	TotalSupply  int64           `json:"total_supply"`
+	Precision    int32           `json:"precision"`
+	FrozenSupply []struct {
+		Amount int64 `json:"frozen_amount"`
+		Days   int64 `json:"frozen_days"`
+	} `json:"frozen_supply"`
+	TrxNum                  int64 `json:"trx_num"`
+	Num                     int64 `json:"num"`
+	StartTime               int64 `json:"start_time"`
+	EndTime                 int64 `json:"end_time"`
+	FreeAssetNetLimit       int64 `json:"free_asset_net_limit"`
+	PublicFreeAssetNetLimit int64 `json:"public_free_asset_net_limit"`
+}
+
+// assetIssue converts an asset returned by the node, decoding its text fields.
+func (c *Client) assetIssue(raw assetIssueJSON) (*AssetIssue, error) {
+	asset := &AssetIssue{
+		Id:                      raw.Id,
+		Owner:                   raw.Owner,
+		TotalSupply:             raw.TotalSupply,
+		Precision:               raw.Precision,
+		TrxNum:                  raw.TrxNum,
+		Num:                     raw.Num,
+		StartTime:               time.Unix(0, raw.StartTime*int64(time.Millisecond)),
+		EndTime:                 time.Unix(0, raw.EndTime*int64(time.Millisecond)),
+		FreeAssetNetLimit:       raw.FreeAssetNetLimit,
+		PublicFreeAssetNetLimit: raw.PublicFreeAssetNetLimit,
+	}
+
+	for _, f := range raw.FrozenSupply {
+		asset.FrozenSupply = append(asset.FrozenSupply, FrozenSupply{Amount: f.Amount, Days: f.Days})
+	}
+
+	fields := []struct {
+		src string
+		dst *string
+	}{
+		{raw.Name, &asset.Name},
+		{raw.Abbr, &asset.Abbr},
+		{raw.Description, &asset.Description},
+		{raw.Url, &asset.Url},
+	}
+	for _, f := range fields {
+		str, err := c.decodeString(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = str
+	}
+
+	return asset, nil
+}
+
+// assetIssues converts a list of assets returned by the node.
+func (c *Client) assetIssues(raw []assetIssueJSON) ([]AssetIssue, error) {
+	assets := make([]AssetIssue, 0, len(raw))
+	for _, r := range raw {
+		asset, err := c.assetIssue(r)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, *asset)
+	}
+	return assets, nil
+}
+
+// GetAssetIssueById returns an asset, or nil if it does not exist.
+func (c *Client) GetAssetIssueById(id string) (*AssetIssue, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: id,
+	}
+
+	var raw assetIssueJSON
+	if err := c.post("wallet/getassetissuebyid", &request, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw.Id == "" {
+		return nil, nil
+	}
+
+	return c.assetIssue(raw)
+}
+
+// GetAssetIssueByName returns the asset with a name, or nil if none exists. Names are not
+// unique, and the node returns an error if more than one asset has the name, in which case
+// the asset should be looked up by id.
+func (c *Client) GetAssetIssueByName(name string) (*AssetIssue, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeString(name),
+	}
+
+	var raw assetIssueJSON
+	if err := c.post("wallet/getassetissuebyname", &request, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw.Id == "" {
+		return nil, nil
+	}
+
+	return c.assetIssue(raw)
+}
+
+// GetAssetIssueByAccount returns the assets issued by an account.
+func (c *Client) GetAssetIssueByAccount(addr address.Address) ([]AssetIssue, error) {
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(addr),
+	}
+
+	var response struct {
+		Assets []assetIssueJSON `json:"assetIssue"`
+	}
+	if err := c.post("wallet/getassetissuebyaccount", &request, &response); err != nil {
+		return nil, err
+	}
+
+	return c.assetIssues(response.Assets)
+}
+
+// GetAssetIssueList returns every asset issued on the network.
+func (c *Client) GetAssetIssueList() ([]AssetIssue, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Assets []assetIssueJSON `json:"assetIssue"`
+	}
+	if err := c.post("wallet/getassetissuelist", &request, &response); err != nil {
+		return nil, err
+	}
+
+	return c.assetIssues(response.Assets)
+}
+
+// millis returns a time in milliseconds since the epoch, as the node represents times.
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
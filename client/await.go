@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// AwaitOptions controls how Await polls for the outcome of a transaction.
+type AwaitOptions struct {
+	// Timeout is the maximum amount of time to wait. Zero waits until the context is done.
+	Timeout time.Duration
+
+	// PollInterval is the amount of time to wait between queries. Zero uses the throttle
+	// configured on the client.
+	PollInterval time.Duration
+
+	// Confirmations is the number of blocks, including the block the transaction was
+	// included in, that must be produced before returning. Zero and one both return as soon
+	// as the transaction has been processed.
+	Confirmations uint64
+
+	// Solidified waits until the transaction is in a block that has been solidified.
+	Solidified bool
+}
+
+// AwaitResult is the outcome of a transaction that was awaited.
+type AwaitResult struct {
+	// Info is the information about the processed transaction.
+	Info *TransactionInfo
+
+	// Confirmations is the number of blocks, including the block the transaction was
+	// included in, that had been produced when the wait finished.
+	Confirmations uint64
+
+	// Solidified is whether the transaction was known to be solidified when the wait finished.
+	Solidified bool
+
+	// Elapsed is how long the wait took.
+	Elapsed time.Duration
+
+	// DryRun is the transaction and its estimated fee if it was not broadcast because dry run
+	// is enabled, in which case Info holds the estimate rather than the processed transaction.
+	DryRun *DryRun
+}
+
+// Await waits for a transaction to be processed and, depending on the options, to reach a
+// number of confirmations or be solidified. An error is returned if the context is done or
+// the timeout elapses first, or ErrShutdown if the client is shut down before then.
+func (c *Client) Await(ctx context.Context, id string, opts AwaitOptions) (result *AwaitResult, err error) {
+	start := time.Now()
+	defer func() {
+		c.metrics.ObserveAwait(time.Since(start), err)
+	}()
+
+	if err := c.inflight.begin(id); err != nil {
+		return nil, err
+	}
+	defer c.inflight.end(id)
+
+	if c.dryRuns != nil {
+		if run, ok := c.dryRuns.get(id); ok {
+			return dryRunResult(run), nil
+		}
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = c.throttle
+	}
+
+	result = new(AwaitResult)
+	for {
+		done, err := c.poll(id, opts, result)
+		if err != nil {
+			return nil, err
+		}
+
+		if done {
+			result.Elapsed = time.Since(start)
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.inflight.abort:
+			return nil, ErrShutdown
+		case <-time.After(interval):
+		}
+	}
+}
+
+// poll queries the state of a transaction once, updating the result and reporting whether
+// the conditions of the options have been met.
+func (c *Client) poll(id string, opts AwaitOptions, result *AwaitResult) (bool, error) {
+	if result.Info == nil {
+		info, err := c.transactionInfo("wallet/gettransactioninfobyid", id)
+		if err != nil || info == nil {
+			return false, err
+		}
+		result.Info = info
+	}
+
+	if opts.Confirmations > 1 {
+		latest, err := c.GetLatestBlock()
+		if err != nil {
+			return false, err
+		}
+
+		if n := latest.BlockHeader.RawData.Number; n >= result.Info.BlockNumber {
+			result.Confirmations = n - result.Info.BlockNumber + 1
+		}
+
+		if result.Confirmations < opts.Confirmations {
+			return false, nil
+		}
+	} else {
+		result.Confirmations = 1
+	}
+
+	if opts.Solidified {
+		info, err := c.transactionInfo("walletsolidity/gettransactioninfobyid", id)
+		if err != nil || info == nil {
+			return false, err
+		}
+		result.Solidified = true
+	}
+
+	return true, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// BlockIdentifier identifies a block by both its id and its height.
+type BlockIdentifier struct {
+	Hash   string `json:"hash"`
+	Number uint64 `json:"number"`
+}
+
+// BalanceOperation is a change to the TRX balance of an account, negative for debits.
+type BalanceOperation struct {
+	Index   int64           `json:"operation_identifier"`
+	Address address.Address `json:"address"`
+	Amount  params.Sun      `json:"amount"`
+}
+
+// TransactionBalanceTrace is the balance changes made by a transaction, including its fees.
+type TransactionBalanceTrace struct {
+	TxId       string             `json:"transaction_identifier"`
+	Type       string             `json:"type"`
+	Status     string             `json:"status"`
+	Operations []BalanceOperation `json:"operation"`
+}
+
+// BlockBalanceTrace is the balance changes made by the transactions of a block.
+type BlockBalanceTrace struct {
+	Block        BlockIdentifier           `json:"block_identifier"`
+	Timestamp    int64                     `json:"timestamp"`
+	Transactions []TransactionBalanceTrace `json:"transaction_balance_trace"`
+}
+
+// GetBlockBalanceTrace returns the balance changes made by a block. The node must have
+// historical balance lookup enabled.
+func (c *Client) GetBlockBalanceTrace(block BlockIdentifier) (*BlockBalanceTrace, error) {
+	var trace BlockBalanceTrace
+	if err := c.post("wallet/getblockbalance", &block, &trace); err != nil {
+		return nil, err
+	}
+
+	return &trace, nil
+}
+
+// GetAccountBalance returns the TRX balance of an account as of a block. The node must have
+// historical balance lookup enabled.
+func (c *Client) GetAccountBalance(addr address.Address, block BlockIdentifier) (params.Sun, error) {
+	var request = struct {
+		Account struct {
+			Address string `json:"address"`
+		} `json:"account_identifier"`
+		Block BlockIdentifier `json:"block_identifier"`
+	}{
+		Block: block,
+	}
+	request.Account.Address = c.encodeAddress(addr)
+
+	var response struct {
+		Balance params.Sun `json:"balance"`
+	}
+	if err := c.post("wallet/getaccountbalance", &request, &response); err != nil {
+		return 0, err
+	}
+
+	return response.Balance, nil
+}
+
+// GetBurnTrx returns the total TRX burned by fees since the node began recording burns.
+func (c *Client) GetBurnTrx() (params.Sun, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Amount params.Sun `json:"burnTrxAmount"`
+	}
+	if err := c.post("wallet/getburntrx", &request, &response); err != nil {
+		return 0, err
+	}
+
+	return response.Amount, nil
+}
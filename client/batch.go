@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+)
+
+// tryAggregate is the function of a Multicall2 contract which executes a list of calls and
+// returns the success and return data of each.
+var tryAggregate = abi.Function{
+	Name:       "tryAggregate",
+	Mutability: "nonpayable",
+	Inputs: []abi.Value{
+		{Name: "requireSuccess", Type: "bool"},
+		{Name: "calls", Type: "tuple[]", Components: []abi.Value{
+			{Name: "target", Type: "address"},
+			{Name: "callData", Type: "bytes"},
+		}},
+	},
+	Outputs: []abi.Value{
+		{Name: "returnData", Type: "tuple[]", Components: []abi.Value{
+			{Name: "success", Type: "bool"},
+			{Name: "returnData", Type: "bytes"},
+		}},
+	},
+}
+
+// WithCallBatching coalesces the constant calls made by CallContract within a window into a
+// single call to the tryAggregate function of a Multicall2 contract deployed at multicall. A
+// batch is executed once the window has elapsed since its first call, or as soon as it holds
+// max calls. Callers block until their batch has executed, so batching only reduces requests
+// when calls are made concurrently.
+//
+// The batched calls are made by the multicall contract, functions whose result depends on
+// the caller should not be called on a client with batching. Calls are not batched when a
+// call cache is set.
+func WithCallBatching(multicall address.Address, window time.Duration, max int) Option {
+	return func(c *Client) {
+		c.batcher = newCallBatcher(multicall, window, max)
+	}
+}
+
+// batchedCall is a constant call waiting for its batch to execute.
+type batchedCall struct {
+	contract address.Address
+	data     []byte
+
+	result []byte
+	err    error
+	done   chan struct{}
+}
+
+// callBatcher collects constant calls into batches, it is shared by copies of a client.
+type callBatcher struct {
+	multicall address.Address
+	window    time.Duration
+	max       int
+
+	mu      sync.Mutex
+	pending []*batchedCall
+	timer   *time.Timer
+}
+
+func newCallBatcher(multicall address.Address, window time.Duration, max int) *callBatcher {
+	if max < 1 {
+		max = 1
+	}
+
+	return &callBatcher{
+		multicall: multicall,
+		window:    window,
+		max:       max,
+	}
+}
+
+// call adds a call to the current batch and returns its return data once the batch has
+// executed.
+func (b *callBatcher) call(c *Client, contract address.Address, data []byte) ([]byte, error) {
+	call := &batchedCall{
+		contract: contract,
+		data:     data,
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, call)
+	switch {
+	case len(b.pending) >= b.max:
+		batch := b.take()
+		b.mu.Unlock()
+		b.execute(c, batch)
+	case len(b.pending) == 1:
+		b.timer = time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			batch := b.take()
+			b.mu.Unlock()
+			b.execute(c, batch)
+		})
+		b.mu.Unlock()
+	default:
+		b.mu.Unlock()
+	}
+
+	<-call.done
+	return call.result, call.err
+}
+
+// take removes the pending calls, the mutex must be held.
+func (b *callBatcher) take() []*batchedCall {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+// execute makes the calls of a batch and completes them.
+func (b *callBatcher) execute(c *Client, batch []*batchedCall) {
+	if len(batch) == 0 {
+		return
+	}
+
+	results, err := c.aggregate(context.Background(), b.multicall, batch)
+	for i, call := range batch {
+		switch {
+		case err != nil:
+			call.err = err
+		case !results[i].success:
+			call.err = fmt.Errorf("client: batched call to %s failed", call.contract.ToBase58())
+		default:
+			call.result = results[i].data
+		}
+		close(call.done)
+	}
+}
+
+type aggregateResult struct {
+	success bool
+	data    []byte
+}
+
+// aggregate calls tryAggregate of a multicall contract with the calls of a batch, not requiring
+// success so that a failed call fails only its own caller.
+func (c *Client) aggregate(ctx context.Context, multicall address.Address, batch []*batchedCall) ([]aggregateResult, error) {
+	calls := make([]interface{}, len(batch))
+	for i, call := range batch {
+		calls[i] = []interface{}{call.contract, call.data}
+	}
+
+	args, err := tryAggregate.EncodeArguments(false, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	request := struct {
+		ContractAddress  string `json:"contract_address"`
+		FunctionSelector string `json:"function_selector"`
+		Parameter        string `json:"parameter"`
+		OwnerAddress     string `json:"owner_address"`
+	}{
+		ContractAddress:  c.encodeAddress(multicall),
+		FunctionSelector: tryAggregate.Signature(),
+		Parameter:        hex.EncodeToString(args),
+		OwnerAddress:     c.encodeAddress(multicall),
+	}
+
+	var response struct {
+		Result []string `json:"constant_result"`
+	}
+	if err := c.postContext(ctx, "wallet/triggerconstantcontract", &request, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Result) < 1 {
+		return nil, errors.New("client: multicall returned no result")
+	}
+
+	bs, err := hex.DecodeString(response.Result[0])
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := tryAggregate.Decode(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	returned := values[0].([]interface{})
+	if len(returned) != len(batch) {
+		return nil, fmt.Errorf("client: multicall returned %d results for %d calls", len(returned), len(batch))
+	}
+
+	results := make([]aggregateResult, len(returned))
+	for i, v := range returned {
+		fields := v.([]interface{})
+		results[i] = aggregateResult{
+			success: fields[0].(bool),
+			data:    fields[1].([]byte),
+		}
+	}
+
+	return results, nil
+}
+
+// selector returns the four byte selector of a function signature.
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/params"
+)
+
+// WithCallCache caches the results of constant contract calls. Calls are pinned to the latest
+// solidified block by executing them on the solidity node, and results are cached per
+// contract, function, arguments, caller and block, so repeated calls within one block are
+// served without a request. The latest solidified block is looked up at most once per block
+// interval. At most size results are cached, and results from earlier blocks are discarded
+// as soon as a later block is pinned.
+func WithCallCache(size int) Option {
+	return func(c *Client) {
+		c.calls = newCallCache(size)
+	}
+}
+
+// callKey identifies the result of a constant call.
+type callKey struct {
+	Contract  string
+	Selector  string
+	Parameter string
+	Owner     string
+	Block     uint64
+}
+
+type callEntry struct {
+	key    callKey
+	result []string
+}
+
+// callCache is a least recently used cache of constant call results, it is shared by copies
+// of a client.
+type callCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[callKey]*list.Element
+	order   *list.List
+
+	block    uint64
+	pinnedAt time.Time
+}
+
+func newCallCache(size int) *callCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &callCache{
+		size:    size,
+		entries: make(map[callKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// pinned returns the pinned block, if it was pinned within the last block interval.
+func (cc *callCache) pinned() (uint64, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.block, !cc.pinnedAt.IsZero() && time.Since(cc.pinnedAt) < params.BlockInterval
+}
+
+// pin records the latest solidified block, discarding the results of earlier blocks.
+func (cc *callCache) pin(block uint64) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.pinnedAt = time.Now()
+	if block <= cc.block {
+		return
+	}
+
+	cc.block = block
+	cc.entries = make(map[callKey]*list.Element)
+	cc.order.Init()
+}
+
+func (cc *callCache) get(key callKey) ([]string, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	e, ok := cc.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	cc.order.MoveToFront(e)
+	return e.Value.(*callEntry).result, true
+}
+
+func (cc *callCache) put(key callKey, result []string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	// The block moved on while the call was in flight.
+	if key.Block != cc.block {
+		return
+	}
+
+	if e, ok := cc.entries[key]; ok {
+		e.Value.(*callEntry).result = result
+		cc.order.MoveToFront(e)
+		return
+	}
+
+	cc.entries[key] = cc.order.PushFront(&callEntry{key: key, result: result})
+	if cc.order.Len() > cc.size {
+		oldest := cc.order.Back()
+		cc.order.Remove(oldest)
+		delete(cc.entries, oldest.Value.(*callEntry).key)
+	}
+}
+
+// cachedConstantCall executes a constant call on the solidity node, serving the result from
+// the cache if the call has already been made against the latest solidified block.
+func (c *Client) cachedConstantCall(key callKey, request interface{}) ([]string, error) {
+	block, ok := c.calls.pinned()
+	if !ok {
+		var request = struct{}{}
+
+		var header tron.BlockHeaderOnly
+		if err := c.post("walletsolidity/getnowblock", &request, &header); err != nil {
+			return nil, err
+		}
+
+		block = header.BlockHeader.RawData.Number
+		c.calls.pin(block)
+	}
+
+	key.Block = block
+	if result, ok := c.calls.get(key); ok {
+		return result, nil
+	}
+
+	var response struct {
+		Result []string `json:"constant_result"`
+	}
+	if err := c.post("walletsolidity/triggerconstantcontract", request, &response); err != nil {
+		return nil, err
+	}
+
+	c.calls.put(key, response.Result)
+	return response.Result, nil
+}
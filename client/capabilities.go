@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ProbeEndpoints are the endpoints ProbeCapabilities checks by default.
+var ProbeEndpoints = []string{
+	"wallet/getnodeinfo",
+	"wallet/getchainparameters",
+	"wallet/triggerconstantcontract",
+	"wallet/estimateenergy",
+	"wallet/gettransactioninfobyblocknum",
+	"wallet/freezebalance",
+	"wallet/unfreezebalance",
+	"wallet/freezebalancev2",
+	"wallet/unfreezebalancev2",
+	"wallet/delegateresource",
+	"wallet/undelegateresource",
+	"wallet/getavailableunfreezecount",
+	"walletsolidity/getnowblock",
+	"walletsolidity/gettransactioninfobyid",
+}
+
+// CapabilityOptions controls what ProbeCapabilities checks.
+type CapabilityOptions struct {
+	// Endpoints are the HTTP endpoints checked, nil checks ProbeEndpoints.
+	Endpoints []string
+
+	// JSONRPCURL is the URL of the JSON-RPC service of the node, the default is the full node
+	// host with the path "/jsonrpc". Nodes serve it on a port of its own, 8545 by default, so
+	// the default only finds it behind a gateway which serves both on one host.
+	JSONRPCURL string
+
+	// GRPCAddress is the host and port of the gRPC service of the node. If empty, gRPC is not
+	// checked.
+	GRPCAddress string
+}
+
+// Capabilities are the services and endpoints which a node supports, so that applications
+// can fall back to other behaviour rather than fail when a request is first made.
+type Capabilities struct {
+	// Version is the code version of the full node.
+	Version string
+
+	// HTTP is whether the full node serves the wallet endpoints, Solidity whether the solidity
+	// node serves the walletsolidity endpoints and JSONRPC whether the node serves JSON-RPC.
+	HTTP     bool
+	Solidity bool
+	JSONRPC  bool
+
+	// GRPC is whether a connection could be opened to the gRPC address, which only shows that
+	// the port is reachable rather than that the node answers gRPC requests. It is false if no
+	// gRPC address was given.
+	GRPC bool
+
+	// Stake1 is whether the node serves the Stake 1.0 freeze endpoints. The network may have
+	// disabled Stake 1.0 by proposal even so, in which case freezing fails when broadcast.
+	Stake1 bool
+
+	// Stake2 is whether the node serves the Stake 2.0 endpoints and the network has enabled
+	// them, that is the unfreeze delay is set.
+	Stake2 bool
+
+	// Endpoints is whether each endpoint probed is served.
+	Endpoints map[string]bool
+}
+
+// Supports returns whether an endpoint is served, false if it was not probed.
+func (c *Capabilities) Supports(endpoint string) bool {
+	return c.Endpoints[endpoint]
+}
+
+// Unsupported returns the endpoints probed which are not served, sorted.
+func (c *Capabilities) Unsupported() []string {
+	var endpoints []string
+	for endpoint, ok := range c.Endpoints {
+		if !ok {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// capabilityCache holds the capabilities last probed, shared by copies of the client.
+type capabilityCache struct {
+	mu   sync.Mutex
+	caps *Capabilities
+}
+
+// Capabilities returns the capabilities found by the last call of ProbeCapabilities, nil if
+// the node has not been probed.
+func (c *Client) Capabilities() *Capabilities {
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	return c.capabilities.caps
+}
+
+// ProbeCapabilities checks which services and endpoints the node supports, typically once at
+// startup, and records them for Capabilities. An endpoint is supported unless the node
+// responds that it does not exist; requests are made with an empty body, so endpoints which
+// change state only fail validation. An error is only returned if the context is done or the
+// full node could not be reached at all.
+func (c *Client) ProbeCapabilities(ctx context.Context, opts CapabilityOptions) (*Capabilities, error) {
+	endpoints := opts.Endpoints
+	if endpoints == nil {
+		endpoints = ProbeEndpoints
+	}
+
+	caps := &Capabilities{Endpoints: make(map[string]bool, len(endpoints))}
+
+	info, err := c.getNodeInfo(ctx)
+	if err != nil {
+		// Nodes may refuse node info while serving other endpoints, so the node is only
+		// unreachable if a request could not be made at all.
+		served, err := c.probeEndpoint(ctx, "wallet/getnowblock")
+		if err != nil {
+			return nil, err
+		}
+		caps.HTTP = served
+	} else {
+		caps.HTTP = true
+		caps.Version = info.Config.CodeVersion
+	}
+
+	for _, endpoint := range endpoints {
+		served, err := c.probeEndpoint(ctx, endpoint)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		caps.Endpoints[endpoint] = err == nil && served
+	}
+
+	caps.Solidity, _ = c.probeEndpoint(ctx, "walletsolidity/getnowblock")
+	caps.JSONRPC = c.probeJSONRPC(ctx, opts.JSONRPCURL)
+	if opts.GRPCAddress != "" {
+		caps.GRPC = probeGRPC(ctx, opts.GRPCAddress)
+	}
+
+	caps.Stake1, _ = c.probeEndpoint(ctx, "wallet/freezebalance")
+	if served, _ := c.probeEndpoint(ctx, "wallet/freezebalancev2"); served {
+		if params, err := c.GetChainParameters(); err == nil {
+			caps.Stake2 = params[ParamUnfreezeDelayDays] > 0
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.capabilities.mu.Lock()
+	c.capabilities.caps = caps
+	c.capabilities.mu.Unlock()
+
+	return caps, nil
+}
+
+// probeEndpoint returns whether the node serves an endpoint, that is it does not respond with
+// not found. An error is returned if the request could not be made.
+func (c *Client) probeEndpoint(ctx context.Context, endpoint string) (bool, error) {
+	req, err := http.NewRequest("POST", c.getURL(endpoint), bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIdHeader, c.newRequestId())
+
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return false, &RequestError{Endpoint: endpoint, Err: err}
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return false, nil
+	default:
+		return resp.StatusCode < http.StatusInternalServerError, nil
+	}
+}
+
+// probeJSONRPC returns whether the node answers a JSON-RPC request for the chain id.
+func (c *Client) probeJSONRPC(ctx context.Context, url string) bool {
+	if url == "" {
+		url = c.getFullNodeURL("jsonrpc")
+	}
+
+	body := []byte(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	var response struct {
+		Result string `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return false
+	}
+	return response.Result != ""
+}
+
+// probeGRPC returns whether a connection can be opened to a gRPC address.
+func probeGRPC(ctx context.Context, addr string) bool {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -3,6 +3,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -11,33 +12,120 @@ import (
 	"github.com/go-chain/go-tron/abi"
 	"github.com/go-chain/go-tron/account"
 	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/verify"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type Client struct {
-	// Host is the host of the full node API.
+	// Nodes are the hosts of the full and solidity node APIs and the API key sent to them.
 	// TODO(271): Potentially look at bundling this with a more generic network config.
-	host string
+	nodes *nodes
+
+	// Limiter limits the rate of requests, it is unlimited unless configured.
+	limiter *limiter
 
 	// Throttle is the amount of time to wait between querying the state of a transaction.
 	throttle time.Duration
+
+	// CrossValidate is whether responses are checked against their raw protobuf payloads.
+	crossValidate bool
+
+	// HttpClient makes the requests to the node.
+	httpClient *http.Client
+
+	// Middleware wraps each request made to the node, roundTrip is the resulting chain.
+	middleware []Middleware
+	roundTrip  RoundTripFunc
+
+	// Metrics receives measurements of requests, broadcasts and waits.
+	metrics MetricsCollector
+
+	// CorrelationId prefixes the id of every request made by the client.
+	correlationId string
+
+	// SessionId identifies the session of the client, if it is one.
+	sessionId string
+
+	// Stats counts the outcome of requests for status reporting.
+	stats *stats
+
+	// Visible is whether addresses are sent and received in base 58 rather than base 16.
+	visible bool
+
+	// ShadowHost is the host of a node that transactions are simulated on before they are
+	// broadcast, and shadowMode how they are simulated.
+	shadowHost string
+	shadowMode SimulationMode
+
+	// AbiResolver resolves the ABIs of contracts which have none on chain.
+	abiResolver ABIResolver
+
+	// Calls caches the results of constant calls, if enabled.
+	calls *callCache
+
+	// DryRuns records the transactions which were not broadcast, if dry run is enabled.
+	dryRuns *dryRunLog
+
+	// Heights guards against reads from nodes below a minimum height, if enabled.
+	heights *heightGuard
+
+	// Batcher coalesces constant calls into multicalls, if enabled.
+	batcher *callBatcher
+
+	// Verifier verifies the signatures of blocks, if set.
+	verifier *verify.Verifier
+
+	// Inflight tracks the transactions being broadcast or awaited, for draining on shutdown.
+	inflight *inflight
+
+	// Capabilities are those found by the last probe of the node.
+	capabilities *capabilityCache
 }
 
 // New creates a new client for the provided host.
-func New(host string) *Client {
-	return &Client{
-		host:     host,
-		throttle: 3 * time.Second,
+func New(host string, opts ...Option) *Client {
+	c := &Client{
+		nodes:        &nodes{host: host, solidityHost: host},
+		throttle:     params.BlockInterval,
+		httpClient:   http.DefaultClient,
+		metrics:      nopMetrics{},
+		stats:        new(stats),
+		inflight:     newInflight(),
+		capabilities: new(capabilityCache),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.limiter == nil {
+		WithRateLimit(0, 0)(c)
+	}
+
+	c.buildRoundTrip()
+
+	return c
+}
+
+// buildRoundTrip chains the middleware of the client around its HTTP client.
+func (c *Client) buildRoundTrip() {
+	c.roundTrip = c.httpClient.Do
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		c.roundTrip = c.middleware[i](c.roundTrip)
 	}
 }
 
 type Getaccount struct {
-	Address             string `json:"address"`
-	Balance             int64  `json:"balance"`
-	AssetV2             []V2   `json:"assetV2"`
-	FreeAssetNetUsageV2 []V2   `json:"free_asset_net_usageV2"`
+	Address             string     `json:"address"`
+	Balance             params.Sun `json:"balance"`
+	AssetV2             []V2       `json:"assetV2"`
+	FreeAssetNetUsageV2 []V2       `json:"free_asset_net_usageV2"`
 }
 
 type V2 struct {
@@ -57,7 +145,7 @@ func (c *Client) GetAccount(addr string) (Getaccount, error) {
 	var request = struct {
 		Address string `json:"address"`
 	}{
-		Address: add.ToBase16(),
+		Address: c.encodeAddress(add),
 	}
 
 	var acc Getaccount
@@ -85,6 +173,10 @@ func (c *Client) GetBlockByHeight(n uint64) (*tron.Block, error) {
 		return nil, fmt.Errorf("block num: %d not exist",n)
 	}
 
+	if err := c.validateBlock(&block); err != nil {
+		return nil, err
+	}
+
 	return &block, nil
 }
 
@@ -105,9 +197,77 @@ func (c *Client) GetBlockById(id string) (*tron.Block, error) {
 		return nil, nil
 	}
 
+	if err := c.validateBlock(&block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// GetBlock returns the block for the specified id or height. When detail is false the node
+// omits the transaction bodies, which is considerably cheaper when only the header is needed.
+func (c *Client) GetBlock(idOrNum string, detail bool) (*tron.Block, error) {
+	var request = struct {
+		IdOrNum string `json:"id_or_num"`
+		Detail  bool   `json:"detail"`
+	}{
+		IdOrNum: idOrNum,
+		Detail:  detail,
+	}
+
+	var block tron.Block
+	if err := c.post("wallet/getblock", &request, &block); err != nil {
+		return nil, err
+	}
+
+	if block.Id == "" {
+		return nil, nil
+	}
+
+	if err := c.validateBlock(&block); err != nil {
+		return nil, err
+	}
+
 	return &block, nil
 }
 
+// GetBlockHeader returns the header of the block for the specified id or height. The
+// transactions of the block are never requested from the node.
+func (c *Client) GetBlockHeader(idOrNum string) (*tron.BlockHeaderOnly, error) {
+	var request = struct {
+		IdOrNum string `json:"id_or_num"`
+		Detail  bool   `json:"detail"`
+	}{
+		IdOrNum: idOrNum,
+	}
+
+	var header tron.BlockHeaderOnly
+	if err := c.post("wallet/getblock", &request, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Id == "" {
+		return nil, nil
+	}
+
+	return &header, nil
+}
+
+// GetBlockTransactions loads the transactions of a block for which only the header was
+// fetched.
+func (c *Client) GetBlockTransactions(header tron.BlockHeaderOnly) ([]tron.Transaction, error) {
+	block, err := c.GetBlock(header.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("client: block %s does not exist", header.Id)
+	}
+
+	return block.Transactions, nil
+}
+
 // GetBlockRange returns the blocks within a height range, end exclusive.
 func (c *Client) GetBlockRange(start, end uint64) ([]tron.Block, error) {
 	var request = struct {
@@ -123,6 +283,12 @@ func (c *Client) GetBlockRange(start, end uint64) ([]tron.Block, error) {
 		return nil, err
 	}
 
+	for i := range response.Blocks {
+		if err := c.validateBlock(&response.Blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+
 	return response.Blocks, nil
 }
 
@@ -139,6 +305,12 @@ func (c *Client) GetLatestBlocks(n int) ([]tron.Block, error) {
 		return nil, err
 	}
 
+	for i := range response.Blocks {
+		if err := c.validateBlock(&response.Blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+
 	return response.Blocks, nil
 }
 
@@ -156,6 +328,10 @@ func (c *Client) GetLatestBlock() (tron.Block, error) {
 		return tron.Block{}, errors.New("client: not expecting latest block to be nil")
 	}
 
+	if err := c.validateBlock(&block); err != nil {
+		return tron.Block{}, err
+	}
+
 	return block, nil
 }
 
@@ -164,11 +340,14 @@ type TransactionInfo struct {
 	Id              string             `json:"id"`
 	Fee             uint64             `json:"fee"`
 	BlockNumber     uint64             `json:"blockNumber"`
-	BlockTimestamp  uint64             `json:"blockTimestamp"`
+	BlockTimestamp  tron.Time          `json:"blockTimestamp"`
 	ContractResult  []string           `json:"contractResult"`
 	ContractAddress address.Address    `json:"contract_address"`
 	Receipt         TransactionReceipt `json:"receipt"`
 	Log             *json.RawMessage   `json:"log"`
+	WithdrawAmount  int64              `json:"withdraw_amount"`
+
+	InternalTransactions []InternalTransaction `json:"internal_transactions"`
 }
 
 func (t TransactionInfo) Error() error {
@@ -208,17 +387,27 @@ type TransactionReceipt struct {
 	NetFee           uint64            `json:"net_fee"`
 	NetUsage         uint64            `json:"net_usage"`
 	Result           TransactionResult `json:"result"`
+
+	// EnergyUsage is the energy paid for from the stake of the caller and OriginEnergyUsage
+	// the energy paid for by the origin of the contract. The rest of the total was paid for
+	// by burning the EnergyFee.
+	EnergyUsage       uint64 `json:"energy_usage"`
+	OriginEnergyUsage uint64 `json:"origin_energy_usage"`
 }
 
-// Transfer transfers a balance of Tron from a source account to a destination address.
-func (c *Client) Transfer(src account.Account, dest address.Address, amount uint64) (tron.Transaction, error) {
+// Transfer transfers an amount of TRX, in sun, from a source account to a destination address.
+func (c *Client) Transfer(src account.Account, dest address.Address, amount params.Sun) (tron.Transaction, error) {
+	if amount <= 0 {
+		return tron.Transaction{}, fmt.Errorf("client: transfer amount must be positive (%d sun)", amount)
+	}
+
 	var request = struct {
-		Owner  string `json:"owner_address"`
-		To     string `json:"to_address"`
-		Amount uint64 `json:"amount"`
+		Owner  string     `json:"owner_address"`
+		To     string     `json:"to_address"`
+		Amount params.Sun `json:"amount"`
 	}{
-		Owner:  src.Address().ToBase16(),
-		To:     dest.ToBase16(),
+		Owner:  c.encodeAddress(src.Address()),
+		To:     c.encodeAddress(dest),
 		Amount: amount,
 	}
 
@@ -227,6 +416,10 @@ func (c *Client) Transfer(src account.Account, dest address.Address, amount uint
 		return tron.Transaction{}, err
 	}
 
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
 	if err := src.Sign(&tx); err != nil {
 		return tron.Transaction{}, err
 	}
@@ -248,8 +441,8 @@ func (c *Client) TransferAsset(src account.Account, dest address.Address, assetN
 		Amount uint64 `json:"amount"`
 		Asset  string `json:"asset_name"`
 	}{
-		Owner:  src.Address().ToBase16(),
-		To:     dest.ToBase16(),
+		Owner:  c.encodeAddress(src.Address()),
+		To:     c.encodeAddress(dest),
 		Amount: amount,
 		Asset:  assetName,
 	}
@@ -258,6 +451,10 @@ func (c *Client) TransferAsset(src account.Account, dest address.Address, assetN
 		return tron.Transaction{}, err
 	}
 
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
 	if err := src.Sign(&tx); err != nil {
 		return tron.Transaction{}, err
 	}
@@ -271,10 +468,43 @@ func (c *Client) TransferAsset(src account.Account, dest address.Address, assetN
 
 }
 
+// createTransaction creates a transaction on the node and signs it with the provided account.
+func (c *Client) createTransaction(acc account.Account, endpoint string, request interface{}) (tron.Transaction, error) {
+	var tx tron.Transaction
+	if err := c.post(endpoint, request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := acc.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
 // TransactionInfoById returns the information about a processed transaction. If the transaction
 // does not exist or has not yet been processed then the returned information will be nil even
 // though an error will not be returned.
 func (c *Client) TransactionInfoById(id string) (*TransactionInfo, error) {
+	info, err := c.transactionInfo("wallet/gettransactioninfobyid", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		return nil, errors.New("tx id is null,Unconfirmed transaction")
+	}
+
+	return info, nil
+}
+
+// transactionInfo queries an endpoint for the information about a processed transaction,
+// returning nil information if the transaction has not been processed.
+func (c *Client) transactionInfo(endpoint, id string) (*TransactionInfo, error) {
 	var request = struct {
 		Value string `json:"value"`
 	}{
@@ -282,13 +512,13 @@ func (c *Client) TransactionInfoById(id string) (*TransactionInfo, error) {
 	}
 
 	var info TransactionInfo
-	if err := c.post("wallet/gettransactioninfobyid", &request, &info); err != nil {
+	if err := c.post(endpoint, &request, &info); err != nil {
 		return nil, err
 	}
 
 	// Transactions that exist will always have an identifier returned.
 	if info.Id == "" {
-		return nil, errors.New("tx id is null,Unconfirmed transaction")
+		return nil, nil
 	}
 
 	return &info, nil
@@ -312,6 +542,10 @@ func (c *Client) TransactionById(id string) (*tron.Transaction, error) {
 		return nil, nil
 	}
 
+	if err := c.validateTransaction(&info); err != nil {
+		return nil, err
+	}
+
 	return &info, nil
 }
 
@@ -330,6 +564,11 @@ type DeployContractInput struct {
 // account that this function was called with.
 func (c *Client) DeployContract(acc account.Account, input DeployContractInput) (*TransactionInfo, error) {
 	// TODO(271): ABI encoding.
+	args, err := input.ABI.Constructor.EncodeArguments(input.Arguments...)
+	if err != nil {
+		return nil, err
+	}
+
 	request := struct {
 		ABI               string `json:"abi"`
 		Bytecode          string `json:"bytecode"`
@@ -345,9 +584,9 @@ func (c *Client) DeployContract(acc account.Account, input DeployContractInput)
 		Name:              input.Name,
 		FeeLimit:          input.FeeLimit,
 		CallValue:         input.CallValue,
-		OwnerAddress:      acc.Address().ToBase16(),
+		OwnerAddress:      c.encodeAddress(acc.Address()),
 		OriginEnergyLimit: input.OriginEnergyLimit,
-		Parameter:         hex.EncodeToString(input.ABI.Constructor.Encode(input.Arguments...)),
+		Parameter:         hex.EncodeToString(args),
 	}
 
 	var tx tron.Transaction
@@ -355,6 +594,10 @@ func (c *Client) DeployContract(acc account.Account, input DeployContractInput)
 		return nil, err
 	}
 
+	if err := c.validateTransaction(&tx); err != nil {
+		return nil, err
+	}
+
 	if err := acc.Sign(&tx); err != nil {
 		return nil, err
 	}
@@ -363,7 +606,12 @@ func (c *Client) DeployContract(acc account.Account, input DeployContractInput)
 		return nil, err
 	}
 
-	return c.await(tx.Id)
+	result, err := c.Await(context.Background(), tx.Id, AwaitOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Info, nil
 }
 
 type CallContractInput struct {
@@ -383,6 +631,11 @@ type CallContractInput struct {
 // to CallContractInput.Result. Mutable functions will return transaction info if they are successfully
 // processed.
 func (c *Client) CallContract(acc account.Account, input CallContractInput) (tron.Transaction, error) {
+	args, err := input.Function.EncodeArguments(input.Arguments...)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
 	request := struct {
 		ContractAddress  string `json:"contract_address"`
 		FunctionSelector string `json:"function_selector"`
@@ -391,12 +644,12 @@ func (c *Client) CallContract(acc account.Account, input CallContractInput) (tro
 		CallValue        uint64 `json:"call_value"`
 		OwnerAddress     string `json:"owner_address"`
 	}{
-		ContractAddress:  input.Address.ToBase16(),
+		ContractAddress:  c.encodeAddress(input.Address),
 		FunctionSelector: input.Function.Signature(),
-		Parameter:        hex.EncodeToString(input.Function.Encode(input.Arguments...)),
+		Parameter:        hex.EncodeToString(args),
 		FeeLimit:         input.FeeLimit,
 		CallValue:        input.CallValue,
-		OwnerAddress:     acc.Address().ToBase16(),
+		OwnerAddress:     c.encodeAddress(acc.Address()),
 	}
 
 	var endpoint string
@@ -417,7 +670,31 @@ func (c *Client) CallContract(acc account.Account, input CallContractInput) (tro
 		Result      []string         `json:"constant_result"`
 		Transaction tron.Transaction `json:"transaction"`
 	}{}
-	if err := c.post(endpoint, &request, &response); err != nil {
+	if input.Function.Immutable() && c.calls != nil {
+		key := callKey{
+			Contract:  request.ContractAddress,
+			Selector:  request.FunctionSelector,
+			Parameter: request.Parameter,
+			Owner:     request.OwnerAddress,
+		}
+
+		result, err := c.cachedConstantCall(key, &request)
+		if err != nil {
+			return tron.Transaction{}, err
+		}
+		response.Result = result
+	} else if input.Function.Immutable() && c.batcher != nil && input.CallValue == 0 {
+		data := append(selector(request.FunctionSelector), args...)
+
+		result, err := c.batcher.call(c, input.Address, data)
+		if err != nil {
+			return tron.Transaction{}, err
+		}
+
+		if len(result) > 0 {
+			response.Result = []string{hex.EncodeToString(result)}
+		}
+	} else if err := c.post(endpoint, &request, &response); err != nil {
 		return tron.Transaction{}, err
 	}
 
@@ -442,6 +719,10 @@ func (c *Client) CallContract(acc account.Account, input CallContractInput) (tro
 
 	tx := response.Transaction
 
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
 	if err := acc.Sign(&tx); err != nil {
 		return tron.Transaction{}, err
 	}
@@ -475,6 +756,11 @@ func (c *Client) CallContract(acc account.Account, input CallContractInput) (tro
 }
 
 func (c *Client) TriggerSmartContract(acc account.Account, input CallContractInput) ([]string, error) {
+	args, err := input.Function.EncodeArguments(input.Arguments...)
+	if err != nil {
+		return nil, err
+	}
+
 	request := struct {
 		ContractAddress  string `json:"contract_address"`
 		FunctionSelector string `json:"function_selector"`
@@ -483,12 +769,12 @@ func (c *Client) TriggerSmartContract(acc account.Account, input CallContractInp
 		CallValue        uint64 `json:"call_value"`
 		OwnerAddress     string `json:"owner_address"`
 	}{
-		ContractAddress:  input.Address.ToBase16(),
+		ContractAddress:  c.encodeAddress(input.Address),
 		FunctionSelector: input.Function.Signature(),
-		Parameter:        hex.EncodeToString(input.Function.Encode(input.Arguments...)),
+		Parameter:        hex.EncodeToString(args),
 		FeeLimit:         input.FeeLimit,
 		CallValue:        input.CallValue,
-		OwnerAddress:     input.Address.ToBase16(),
+		OwnerAddress:     c.encodeAddress(input.Address),
 	}
 
 	var endpoint string
@@ -523,17 +809,60 @@ func (c *Client) TriggerSmartContract(acc account.Account, input CallContractInp
 
 // BroadcastTransaction broadcasts a signed transaction to the network.
 func (c *Client) BroadcastTransaction(tx *tron.Transaction) error {
-	// TODO(271): Add in additional pieces for errors.
+	if err := c.inflight.begin(tx.Id); err != nil {
+		return err
+	}
+	defer c.inflight.end(tx.Id)
+
+	if c.shadowHost != "" {
+		if err := c.simulate(tx); err != nil {
+			return err
+		}
+	}
+
+	if c.dryRuns != nil {
+		fee, err := c.EstimateFee(tx)
+		if err != nil {
+			return err
+		}
+		c.dryRuns.record(DryRun{Transaction: *tx, Fee: fee})
+		return nil
+	}
+
+	return c.broadcast(tx)
+}
+
+// broadcast posts a signed transaction to the node.
+func (c *Client) broadcast(tx *tron.Transaction) error {
 	var response = struct {
-		Result bool `json:"result"`
+		Result  bool          `json:"result"`
+		Code    BroadcastCode `json:"code"`
+		Message string        `json:"message"`
+		TxId    string        `json:"txid"`
 	}{}
 
-	if err := c.post("wallet/broadcasttransaction", &tx, &response); err != nil {
+	endpoint, request := "wallet/broadcasttransaction", interface{}(&tx)
+	if tx.RawData == nil && tx.RawDataHex != nil {
+		// Transactions built offline with txbuilder only carry raw_data_hex, which the JSON
+		// endpoint cannot decode, so they are broadcast as a serialized protobuf instead.
+		m, err := pb.FromTransaction(tx)
+		if err != nil {
+			return err
+		}
+
+		endpoint = "wallet/broadcasthex"
+		request = struct {
+			Transaction string `json:"transaction"`
+		}{hex.EncodeToString(m.Marshal())}
+	}
+
+	if err := c.post(endpoint, request, &response); err != nil {
 		return err
 	}
 
 	if !response.Result {
-		return errors.New("client: failed to broadcast transaction")
+		c.metrics.ObserveBroadcastFailure(response.Code)
+		return newBroadcastError(response.Code, response.Message, tx.Id)
 	}
 
 	return nil
@@ -542,62 +871,112 @@ func (c *Client) BroadcastTransaction(tx *tron.Transaction) error {
 // post marshals a request to json and then posts it to an endpoint of the full node server,
 // then once the response is received it unmarshals it into the response.
 func (c *Client) post(endpoint string, request interface{}, response interface{}) error {
+	return c.postContext(context.Background(), endpoint, request, response)
+}
+
+// postContext is post with a context which cancels the request when it is done.
+func (c *Client) postContext(ctx context.Context, endpoint string, request interface{}, response interface{}) error {
+	return c.stream(ctx, endpoint, request, func(body io.Reader) error {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+
+		// Errors are reported with a successful status code and an "Error" field in place of
+		// the expected payload, so they need to be detected before decoding the response.
+		var nodeErr struct {
+			Error string `json:"Error"`
+		}
+		if err := json.Unmarshal(data, &nodeErr); err == nil && nodeErr.Error != "" {
+			return parseNodeError(nodeErr.Error)
+		}
+
+		return json.NewDecoder(bytes.NewReader(data)).Decode(response)
+	})
+}
+
+// stream marshals a request to json and then posts it to an endpoint of the full node server,
+// then passes the body of the response to decode without buffering it.
+func (c *Client) stream(ctx context.Context, endpoint string, request interface{}, decode func(body io.Reader) error) (err error) {
+	if err := c.checkHeight(ctx, endpoint); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.record(err)
+		c.metrics.ObserveRequest(endpoint, time.Since(start), err)
+	}()
+
 	bs, err := json.Marshal(request)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", c.getFullNodeURL(endpoint), bytes.NewReader(bs))
+	if c.visible {
+		if bs, err = setVisible(bs); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest("POST", c.getURL(endpoint), bytes.NewReader(bs))
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(ctx)
 
+	id := c.newRequestId()
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIdHeader, id)
+	if key := c.apiKey(); key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	if c.sessionId != "" {
+		req.Header.Set(SessionHeader, c.sessionId)
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
-		return err
+		return &RequestError{RequestId: id, Endpoint: endpoint, Err: err}
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("client: unexpected status code (%d)", resp.StatusCode)
-	}
-
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+		return &RequestError{
+			RequestId: id,
+			Endpoint:  endpoint,
+			Err:       fmt.Errorf("unexpected status code (%d)", resp.StatusCode),
+		}
 	}
 
-	if err := json.NewDecoder(bytes.NewReader(data)).Decode(response); err != nil {
-		return err
+	err = decode(resp.Body)
+	if nodeErr, ok := err.(*NodeError); ok {
+		nodeErr.RequestId = id
 	}
 
-	return nil
+	return err
 }
 
-// await waits for a transaction to complete processing. The number of requests
-// that are made per unit of time is controlled by the throttle config, and
-// the timeout will dictate how long this function will await before giving up.
-// TODO(271): Allow this to be public?
-func (c *Client) await(id string) (*TransactionInfo, error) {
-	for {
-		info, err := c.TransactionInfoById(id)
-		if err != nil {
-			return nil, err
-		}
-
-		if info == nil {
-			time.Sleep(c.throttle)
-			continue
-		}
-
-		return info, nil
+// getURL returns the URL to a service endpoint. Endpoints of the walletsolidity service are
+// served by the solidity node, all others by the full node.
+func (c *Client) getURL(endpoint string) string {
+	if strings.HasPrefix(endpoint, "walletsolidity/") {
+		return c.getSolidityNodeURL(endpoint)
 	}
+	return c.getFullNodeURL(endpoint)
+}
+
+// getSolidityNodeURL returns the URL to a solidity service endpoint.
+func (c *Client) getSolidityNodeURL(endpoint string) string {
+	c.nodes.mu.RLock()
+	defer c.nodes.mu.RUnlock()
+	return fmt.Sprintf("%s/%s", c.nodes.solidityHost, endpoint)
 }
 
 // getFullNodeURL returns the URL to a service endpoint.
 func (c *Client) getFullNodeURL(endpoint string) string {
-	return fmt.Sprintf("%s/%s", c.host, endpoint)
+	c.nodes.mu.RLock()
+	defer c.nodes.mu.RUnlock()
+	return fmt.Sprintf("%s/%s", c.nodes.host, endpoint)
 }
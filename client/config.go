@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// APIKeyHeader is the header that carries the API key of the client, as TronGrid expects.
+const APIKeyHeader = "TRON-PRO-API-KEY"
+
+// Config is the configuration of a client that can be changed while it is in use, so that
+// services embedding the client can reload it without restarting.
+type Config struct {
+	// Host and SolidityHost are the hosts of the full and solidity node APIs. SolidityHost
+	// defaults to Host.
+	Host         string `json:"host"`
+	SolidityHost string `json:"solidityHost,omitempty"`
+
+	// APIKey is sent with every request, if set.
+	APIKey string `json:"apiKey,omitempty"`
+
+	// RateLimit is the number of requests per second on average and Burst the number of
+	// requests allowed at once, as for WithRateLimit. A rate limit of zero is unlimited.
+	RateLimit float64 `json:"rateLimit,omitempty"`
+	Burst     int     `json:"burst,omitempty"`
+}
+
+// nodes are the hosts of the nodes a client uses and the API key sent to them. Copies of a
+// client share them, so that reconfiguring a client reconfigures its copies, unless the
+// options the copy was made with change them.
+type nodes struct {
+	mu           sync.RWMutex
+	host         string
+	solidityHost string
+	apiKey       string
+}
+
+// clone returns a copy of the nodes which is not shared.
+func (n *nodes) clone() *nodes {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return &nodes{host: n.host, solidityHost: n.solidityHost, apiKey: n.apiKey}
+}
+
+// equal returns whether two sets of nodes have the same hosts and API key.
+func (n *nodes) equal(o *nodes) bool {
+	a, b := n.clone(), o.clone()
+	return a.host == b.host && a.solidityHost == b.solidityHost && a.apiKey == b.apiKey
+}
+
+// WithAPIKey sends an API key with every request, in the APIKeyHeader header.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.nodes.mu.Lock()
+		c.nodes.apiKey = key
+		c.nodes.mu.Unlock()
+	}
+}
+
+// Config returns the current configuration of the client.
+func (c *Client) Config() Config {
+	c.nodes.mu.RLock()
+	cfg := Config{
+		Host:         c.nodes.host,
+		SolidityHost: c.nodes.solidityHost,
+		APIKey:       c.nodes.apiKey,
+	}
+	c.nodes.mu.RUnlock()
+
+	cfg.RateLimit, cfg.Burst = c.limiter.limit()
+	return cfg
+}
+
+// Reconfigure replaces the configuration of the client. Requests already sent complete
+// against the previous nodes and later requests use the new ones, while requests waiting for
+// the rate limit continue to wait under the new limit.
+func (c *Client) Reconfigure(cfg Config) error {
+	if cfg.Host == "" {
+		return errors.New("client: host is required")
+	}
+	if cfg.SolidityHost == "" {
+		cfg.SolidityHost = cfg.Host
+	}
+
+	for _, host := range []string{cfg.Host, cfg.SolidityHost} {
+		if u, err := url.Parse(host); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("client: invalid host %s", host)
+		}
+	}
+
+	if cfg.RateLimit < 0 || cfg.Burst < 0 {
+		return errors.New("client: rate limit and burst cannot be negative")
+	}
+
+	c.nodes.mu.Lock()
+	c.nodes.host = cfg.Host
+	c.nodes.solidityHost = cfg.SolidityHost
+	c.nodes.apiKey = cfg.APIKey
+	c.nodes.mu.Unlock()
+
+	c.limiter.set(cfg.RateLimit, cfg.Burst)
+
+	return nil
+}
+
+// apiKey returns the API key sent with requests.
+func (c *Client) apiKey() string {
+	c.nodes.mu.RLock()
+	defer c.nodes.mu.RUnlock()
+	return c.nodes.apiKey
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+)
+
+// ABIResolver looks up the ABI of a contract from a source other than the node, such as a
+// block explorer with verified contract sources.
+type ABIResolver interface {
+	ResolveABI(contract address.Address) (*abi.ABI, error)
+}
+
+// WithABIResolver sets a resolver that GetContractABI falls back to when a contract has no ABI
+// on chain, as is the case when its owner has cleared it.
+func WithABIResolver(r ABIResolver) Option {
+	return func(c *Client) {
+		c.abiResolver = r
+	}
+}
+
+// GetContractABI returns the ABI of a contract, or nil if the contract does not exist. If the
+// ABI has been cleared from the chain it is resolved with the ABI resolver, if one is set.
+func (c *Client) GetContractABI(contract address.Address) (*abi.ABI, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeAddress(contract),
+	}
+
+	var response struct {
+		Bytecode string `json:"bytecode"`
+		ABI      struct {
+			Entries json.RawMessage `json:"entrys"`
+		} `json:"abi"`
+	}
+	if err := c.post("wallet/getcontract", &request, &response); err != nil {
+		return nil, err
+	}
+
+	entries := response.ABI.Entries
+	if len(entries) == 0 || string(entries) == "[]" || string(entries) == "null" {
+		if response.Bytecode == "" {
+			return nil, nil
+		}
+
+		if c.abiResolver != nil {
+			return c.abiResolver.ResolveABI(contract)
+		}
+
+		return &abi.ABI{Functions: map[string]abi.Function{}, Events: map[string]abi.Event{}}, nil
+	}
+
+	var a abi.ABI
+	if err := json.Unmarshal(entries, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// Contract is a deployed smart contract.
+type Contract struct {
+	Address address.Address
+	Name    string
+
+	// Origin is the address which deployed the contract.
+	Origin address.Address
+
+	// ABI is the ABI of the contract as stored on chain, it has no functions or events if the
+	// ABI has been cleared.
+	ABI      *abi.ABI
+	Bytecode string
+	CodeHash string
+
+	// ConsumeUserResourcePercent is the percentage of the energy of calls paid by the caller,
+	// OriginEnergyLimit is the most energy the origin pays for in a single call.
+	ConsumeUserResourcePercent int64
+	OriginEnergyLimit          int64
+}
+
+// ContractInfo is a deployed smart contract with its runtime state.
+type ContractInfo struct {
+	Contract
+
+	RuntimeCode string
+
+	// EnergyUsage is the energy used by calls to the contract in the current cycle, and
+	// EnergyFactor the resulting increase of the energy cost of calls, in units of 1/10000.
+	EnergyUsage  int64
+	EnergyFactor int64
+	UpdateCycle  int64
+}
+
+type contractJSON struct {
+	Address  address.Address `json:"contract_address"`
+	Origin   address.Address `json:"origin_address"`
+	Name     string          `json:"name"`
+	Bytecode string          `json:"bytecode"`
+	CodeHash string          `json:"code_hash"`
+	ABI      struct {
+		Entries json.RawMessage `json:"entrys"`
+	} `json:"abi"`
+	ConsumeUserResourcePercent int64 `json:"consume_user_resource_percent"`
+	OriginEnergyLimit          int64 `json:"origin_energy_limit"`
+}
+
+func decodeContract(response contractJSON) (*Contract, error) {
+	a := &abi.ABI{Functions: map[string]abi.Function{}, Events: map[string]abi.Event{}}
+	entries := response.ABI.Entries
+	if len(entries) != 0 && string(entries) != "[]" && string(entries) != "null" {
+		if err := json.Unmarshal(entries, a); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Contract{
+		Address:                    response.Address,
+		Name:                       response.Name,
+		Origin:                     response.Origin,
+		ABI:                        a,
+		Bytecode:                   response.Bytecode,
+		CodeHash:                   response.CodeHash,
+		ConsumeUserResourcePercent: response.ConsumeUserResourcePercent,
+		OriginEnergyLimit:          response.OriginEnergyLimit,
+	}, nil
+}
+
+// GetContract returns a deployed contract, or nil if the contract does not exist. Unlike
+// GetContractABI the ABI resolver is not used.
+func (c *Client) GetContract(contract address.Address) (*Contract, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeAddress(contract),
+	}
+
+	var response contractJSON
+	if err := c.post("wallet/getcontract", &request, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Bytecode == "" {
+		return nil, nil
+	}
+
+	return decodeContract(response)
+}
+
+// GetContractInfo returns a deployed contract with its runtime code and energy state, or nil if
+// the contract does not exist.
+func (c *Client) GetContractInfo(contract address.Address) (*ContractInfo, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeAddress(contract),
+	}
+
+	var response struct {
+		RuntimeCode   string       `json:"runtimecode"`
+		SmartContract contractJSON `json:"smart_contract"`
+		ContractState struct {
+			EnergyUsage  int64 `json:"energy_usage"`
+			EnergyFactor int64 `json:"energy_factor"`
+			UpdateCycle  int64 `json:"update_cycle"`
+		} `json:"contract_state"`
+	}
+	if err := c.post("wallet/getcontractinfo", &request, &response); err != nil {
+		return nil, err
+	}
+
+	if response.SmartContract.Bytecode == "" {
+		return nil, nil
+	}
+
+	sc, err := decodeContract(response.SmartContract)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContractInfo{
+		Contract:     *sc,
+		RuntimeCode:  response.RuntimeCode,
+		EnergyUsage:  response.ContractState.EnergyUsage,
+		EnergyFactor: response.ContractState.EnergyFactor,
+		UpdateCycle:  response.ContractState.UpdateCycle,
+	}, nil
+}
+
+// IsContract returns whether a contract is deployed at an address.
+func (c *Client) IsContract(addr address.Address) (bool, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeAddress(addr),
+	}
+
+	var response struct {
+		Bytecode string `json:"bytecode"`
+	}
+	if err := c.post("wallet/getcontract", &request, &response); err != nil {
+		return false, err
+	}
+
+	return response.Bytecode != "", nil
+}
+
+// UpdateSetting creates and signs a transaction which sets the percentage of the energy of calls
+// to a contract that is paid by the caller, the remainder being paid by the owner of the
+// contract. The transaction is not broadcast.
+func (c *Client) UpdateSetting(owner account.Account, contract address.Address, percent int64) (tron.Transaction, error) {
+	if percent < 0 || percent > 100 {
+		return tron.Transaction{}, fmt.Errorf("client: consume user resource percent must be a percentage (%d)", percent)
+	}
+
+	var request = struct {
+		Owner    string `json:"owner_address"`
+		Contract string `json:"contract_address"`
+		Percent  int64  `json:"consume_user_resource_percent"`
+	}{
+		Owner:    c.encodeAddress(owner.Address()),
+		Contract: c.encodeAddress(contract),
+		Percent:  percent,
+	}
+
+	return c.createTransaction(owner, "wallet/updatesetting", &request)
+}
+
+// UpdateEnergyLimit creates and signs a transaction which sets the most energy the owner of a
+// contract pays for in a single call. The transaction is not broadcast.
+func (c *Client) UpdateEnergyLimit(owner account.Account, contract address.Address, limit int64) (tron.Transaction, error) {
+	if limit <= 0 {
+		return tron.Transaction{}, fmt.Errorf("client: origin energy limit must be positive (%d)", limit)
+	}
+
+	var request = struct {
+		Owner    string `json:"owner_address"`
+		Contract string `json:"contract_address"`
+		Limit    int64  `json:"origin_energy_limit"`
+	}{
+		Owner:    c.encodeAddress(owner.Address()),
+		Contract: c.encodeAddress(contract),
+		Limit:    limit,
+	}
+
+	return c.createTransaction(owner, "wallet/updateenergylimit", &request)
+}
+
+// ClearContractABI creates and signs a transaction which removes the ABI of a contract from the
+// chain. The transaction is not broadcast.
+func (c *Client) ClearContractABI(owner account.Account, contract address.Address) (tron.Transaction, error) {
+	var request = struct {
+		Owner    string `json:"owner_address"`
+		Contract string `json:"contract_address"`
+	}{
+		Owner:    c.encodeAddress(owner.Address()),
+		Contract: c.encodeAddress(contract),
+	}
+
+	return c.createTransaction(owner, "wallet/clearabi", &request)
+}
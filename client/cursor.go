@@ -0,0 +1,152 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+)
+
+// Cursor is an opaque position in a paginated listing, which can be persisted and passed back
+// to resume the listing from where it left off, including in another process. The empty
+// cursor is the start of a listing. Cursors are tied to the listing that returned them.
+type Cursor string
+
+// Kinds of listings, which cursors record so that they are not used with another listing.
+const (
+	cursorAssets    = "assets"
+	cursorProposals = "proposals"
+	cursorBlocks    = "blocks"
+)
+
+// cursorVersion prefixes encoded cursors, so that their encoding can change without misreading
+// cursors persisted by earlier versions.
+const cursorVersion = "1"
+
+func newCursor(kind string, pos uint64) Cursor {
+	str := cursorVersion + "/" + kind + "/" + strconv.FormatUint(pos, 10)
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(str)))
+}
+
+// BlockCursor returns a cursor for GetBlockPage which starts at a height.
+func BlockCursor(height uint64) Cursor {
+	return newCursor(cursorBlocks, height)
+}
+
+// position decodes the position of a cursor of a kind of listing.
+func (c Cursor) position(kind string) (uint64, error) {
+	if c == "" {
+		return 0, nil
+	}
+
+	bs, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return 0, fmt.Errorf("client: invalid cursor (%s)", c)
+	}
+
+	parts := strings.Split(string(bs), "/")
+	if len(parts) != 3 || parts[0] != cursorVersion {
+		return 0, fmt.Errorf("client: invalid cursor (%s)", c)
+	}
+
+	if parts[1] != kind {
+		return 0, fmt.Errorf("client: cursor is for %s, not %s", parts[1], kind)
+	}
+
+	pos, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("client: invalid cursor (%s)", c)
+	}
+
+	return pos, nil
+}
+
+// GetAssetIssuePage returns up to limit assets from a cursor, and the cursor of the next page,
+// which is empty once every asset has been listed.
+func (c *Client) GetAssetIssuePage(cursor Cursor, limit int) ([]AssetIssue, Cursor, error) {
+	offset, err := cursor.position(cursorAssets)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var request = struct {
+		Offset uint64 `json:"offset"`
+		Limit  int    `json:"limit"`
+	}{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	var response struct {
+		Assets []assetIssueJSON `json:"assetIssue"`
+	}
+	if err := c.post("wallet/getpaginatedassetissuelist", &request, &response); err != nil {
+		return nil, "", err
+	}
+
+	assets, err := c.assetIssues(response.Assets)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return assets, nextOffset(cursorAssets, offset, len(assets), limit), nil
+}
+
+// ListProposalsPage returns up to limit proposals from a cursor, in the order the node stores
+// them, and the cursor of the next page, which is empty once every proposal has been listed.
+func (c *Client) ListProposalsPage(cursor Cursor, limit int) ([]Proposal, Cursor, error) {
+	offset, err := cursor.position(cursorProposals)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var request = struct {
+		Offset uint64 `json:"offset"`
+		Limit  int    `json:"limit"`
+	}{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	var response struct {
+		Proposals []Proposal `json:"proposals"`
+	}
+	if err := c.post("wallet/getpaginatedproposallist", &request, &response); err != nil {
+		return nil, "", err
+	}
+
+	return response.Proposals, nextOffset(cursorProposals, offset, len(response.Proposals), limit), nil
+}
+
+// GetBlockPage returns up to limit consecutive blocks from a cursor, see BlockCursor, and the
+// cursor of the block after the last returned. Fewer blocks are returned once the latest
+// block is reached, and the cursor can be used again later to continue with new blocks.
+func (c *Client) GetBlockPage(cursor Cursor, limit int) ([]tron.Block, Cursor, error) {
+	start, err := cursor.position(cursorBlocks)
+	if err != nil {
+		return nil, "", err
+	}
+
+	blocks, err := c.GetBlockRange(start, start+uint64(limit))
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := start
+	if len(blocks) > 0 {
+		next = blocks[len(blocks)-1].BlockHeader.RawData.Number + 1
+	}
+
+	return blocks, BlockCursor(next), nil
+}
+
+// nextOffset returns the cursor after a page of an offset based listing, or the empty cursor
+// if the page was the last.
+func nextOffset(kind string, offset uint64, n, limit int) Cursor {
+	if n == 0 || n < limit {
+		return ""
+	}
+	return newCursor(kind, offset+uint64(n))
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// WithDryRun stops the client from broadcasting transactions. Methods create and sign
+// transactions as usual, but BroadcastTransaction, and every method which broadcasts, records
+// the transaction with an estimate of its fee instead of sending it, and Await returns the
+// estimate at once. The recorded transactions are returned by DryRuns. Transactions are still
+// simulated if a shadow node is set.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.dryRuns = &dryRunLog{byId: make(map[string]int)}
+	}
+}
+
+// DryRun is a transaction which was not broadcast because dry run is enabled.
+type DryRun struct {
+	Transaction tron.Transaction
+	Fee         *FeeEstimate
+}
+
+// dryRunLog records the dry runs of a client, shared between its copies.
+type dryRunLog struct {
+	mu   sync.Mutex
+	runs []DryRun
+	byId map[string]int
+}
+
+func (l *dryRunLog) record(run DryRun) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.byId[run.Transaction.Id] = len(l.runs)
+	l.runs = append(l.runs, run)
+}
+
+func (l *dryRunLog) get(id string) (DryRun, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i, ok := l.byId[id]
+	if !ok {
+		return DryRun{}, false
+	}
+	return l.runs[i], true
+}
+
+// DryRuns returns the transactions which were not broadcast because dry run is enabled, in
+// the order they would have been broadcast.
+func (c *Client) DryRuns() []DryRun {
+	if c.dryRuns == nil {
+		return nil
+	}
+
+	c.dryRuns.mu.Lock()
+	defer c.dryRuns.mu.Unlock()
+
+	return append([]DryRun(nil), c.dryRuns.runs...)
+}
+
+// dryRunResult returns the outcome Await reports for a dry run, with the estimated fee and
+// resources in place of those of a processed transaction.
+func dryRunResult(run DryRun) *AwaitResult {
+	return &AwaitResult{
+		Info: &TransactionInfo{
+			Id:  run.Transaction.Id,
+			Fee: uint64(run.Fee.Sun),
+			Receipt: TransactionReceipt{
+				NetUsage:         uint64(run.Fee.Bandwidth),
+				EnergyUsageTotal: uint64(run.Fee.Energy),
+			},
+		},
+		DryRun: &run,
+	}
+}
+
+// FeeEstimate is the resources a transaction consumes, and the sun burned if the owner has
+// neither bandwidth nor energy available to cover them.
+type FeeEstimate struct {
+	Bandwidth int64
+	Energy    int64
+	Sun       params.Sun
+}
+
+// EstimateFee estimates the resources a signed transaction consumes. The energy of smart
+// contract calls is measured by executing them as constant calls at the current state.
+func (c *Client) EstimateFee(tx *tron.Transaction) (*FeeEstimate, error) {
+	signatures := len(tx.Signatures)
+	if signatures == 0 {
+		signatures = 1
+	}
+
+	bandwidth, err := pb.Bandwidth(tx, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := m.RawData.UnpackContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	var energy int64
+	for _, msg := range msgs {
+		trigger, ok := msg.(*pb.TriggerSmartContract)
+		if !ok {
+			continue
+		}
+
+		used, err := c.estimateEnergy(trigger)
+		if err != nil {
+			return nil, err
+		}
+		energy += used
+	}
+
+	chain, err := c.GetChainParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeeEstimate{
+		Bandwidth: bandwidth,
+		Energy:    energy,
+		Sun:       params.Sun(bandwidth*chain.TransactionFee() + energy*chain.EnergyFee()),
+	}, nil
+}
+
+// estimateEnergy returns the energy used by a smart contract call executed as a constant call.
+func (c *Client) estimateEnergy(trigger *pb.TriggerSmartContract) (int64, error) {
+	var response struct {
+		EnergyUsed int64 `json:"energy_used"`
+	}
+	if err := c.constantTrigger(context.Background(), "wallet/triggerconstantcontract", trigger, &response); err != nil {
+		return 0, err
+	}
+
+	return response.EnergyUsed, nil
+}
+
+// constantTrigger executes the smart contract call of a transaction as a constant call on the
+// endpoint and decodes the node's response.
+func (c *Client) constantTrigger(ctx context.Context, endpoint string, trigger *pb.TriggerSmartContract, response interface{}) error {
+	var owner, contract address.Address
+	copy(owner[:], trigger.OwnerAddress)
+	copy(contract[:], trigger.ContractAddress)
+
+	var request = struct {
+		Owner     string `json:"owner_address"`
+		Contract  string `json:"contract_address"`
+		Data      string `json:"data"`
+		CallValue int64  `json:"call_value"`
+		TokenId   int64  `json:"token_id,omitempty"`
+		TokenVal  int64  `json:"call_token_value,omitempty"`
+	}{
+		Owner:     c.encodeAddress(owner),
+		Contract:  c.encodeAddress(contract),
+		Data:      hex.EncodeToString(trigger.Data),
+		CallValue: trigger.CallValue,
+		TokenId:   trigger.TokenId,
+		TokenVal:  trigger.CallTokenValue,
+	}
+
+	return c.postContext(ctx, endpoint, &request, response)
+}
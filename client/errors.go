@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NodeError is an error that was reported by the node in the "Error" field of a response.
+type NodeError struct {
+	// Category is the name of the exception class that the node raised, such as
+	// ContractValidateException. It is empty if the node did not report one.
+	Category string
+
+	// Message is the human readable message that accompanied the error.
+	Message string
+
+	// Raw is the error exactly as it was returned by the node.
+	Raw string
+
+	// RequestId is the id of the request that the node responded to with the error.
+	RequestId string
+}
+
+func (e *NodeError) Error() string {
+	msg := "client: node error: " + e.Message
+	if e.Category != "" {
+		msg = fmt.Sprintf("client: node error (%s): %s", e.Category, e.Message)
+	}
+
+	if e.RequestId == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s [request %s]", msg, e.RequestId)
+}
+
+// RequestError is returned when a request could not be made or the node responded with an
+// unexpected status code.
+type RequestError struct {
+	RequestId string
+	Endpoint  string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("client: request %s to %s failed: %v", e.RequestId, e.Endpoint, e.Err)
+}
+
+// Unwrap returns the error that caused the request to fail.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// parseNodeError parses the "Error" field of a node response. Some endpoints hex encode the
+// message, in which case it is decoded first. The message is then split into the exception
+// class and the message, e.g. "class org.tron.core.exception.ContractValidateException : msg".
+func parseNodeError(raw string) *NodeError {
+	msg := raw
+	if bs, err := hex.DecodeString(raw); err == nil {
+		msg = string(bs)
+	}
+
+	e := &NodeError{Message: msg, Raw: raw}
+
+	if !strings.HasPrefix(msg, "class ") {
+		return e
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(msg, "class "), " : ", 2)
+	if len(parts) != 2 {
+		return e
+	}
+
+	class := parts[0]
+	if i := strings.LastIndexByte(class, '.'); i >= 0 {
+		class = class[i+1:]
+	}
+
+	e.Category = class
+	e.Message = parts[1]
+
+	return e
+}
+
+// BroadcastCode is the reason reported by the node for the outcome of a broadcast.
+type BroadcastCode string
+
+const (
+	BroadcastSuccess                      BroadcastCode = "SUCCESS"
+	BroadcastSigError                     BroadcastCode = "SIGERROR"
+	BroadcastContractValidateError        BroadcastCode = "CONTRACT_VALIDATE_ERROR"
+	BroadcastContractExeError             BroadcastCode = "CONTRACT_EXE_ERROR"
+	BroadcastBandwidthError               BroadcastCode = "BANDWITH_ERROR"
+	BroadcastDupTransactionError          BroadcastCode = "DUP_TRANSACTION_ERROR"
+	BroadcastTaposError                   BroadcastCode = "TAPOS_ERROR"
+	BroadcastTooBigTransactionError       BroadcastCode = "TOO_BIG_TRANSACTION_ERROR"
+	BroadcastTransactionExpirationError   BroadcastCode = "TRANSACTION_EXPIRATION_ERROR"
+	BroadcastServerBusy                   BroadcastCode = "SERVER_BUSY"
+	BroadcastNoConnection                 BroadcastCode = "NO_CONNECTION"
+	BroadcastNotEnoughEffectiveConnection BroadcastCode = "NOT_ENOUGH_EFFECTIVE_CONNECTION"
+	BroadcastBlockUnsolidified            BroadcastCode = "BLOCK_UNSOLIDIFIED"
+	BroadcastOtherError                   BroadcastCode = "OTHER_ERROR"
+)
+
+// Retryable returns if a broadcast that failed with this code may succeed if it is
+// attempted again without modifying the transaction.
+func (c BroadcastCode) Retryable() bool {
+	switch c {
+	case BroadcastServerBusy, BroadcastNoConnection, BroadcastNotEnoughEffectiveConnection, BroadcastBlockUnsolidified:
+		return true
+	default:
+		return false
+	}
+}
+
+// BroadcastError is returned when the node refuses to broadcast a transaction.
+type BroadcastError struct {
+	Code    BroadcastCode
+	Message string
+	TxId    string
+}
+
+func (e *BroadcastError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("client: failed to broadcast transaction %s (%s)", e.TxId, e.Code)
+	}
+	return fmt.Sprintf("client: failed to broadcast transaction %s (%s): %s", e.TxId, e.Code, e.Message)
+}
+
+// newBroadcastError creates a broadcast error from the fields of a broadcast response. The
+// message is hex encoded by the node.
+func newBroadcastError(code BroadcastCode, message, txId string) *BroadcastError {
+	if bs, err := hex.DecodeString(message); err == nil {
+		message = string(bs)
+	}
+
+	return &BroadcastError{
+		Code:    code,
+		Message: message,
+		TxId:    txId,
+	}
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives debug logs from the client as a message and alternating keys and values.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+}
+
+// redacted replaces the values of secrets in logs.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the json fields and headers, lowercased, whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"signature":        true,
+	"private_key":      true,
+	"privatekey":       true,
+	"password":         true,
+	"api_key":          true,
+	"apikey":           true,
+	"tron-pro-api-key": true,
+	"authorization":    true,
+
+	// Shielded spending keys and the keys expanded from them.
+	"sk":  true,
+	"ask": true,
+	"nsk": true,
+	"ovk": true,
+	"ak":  true,
+	"nk":  true,
+	"ivk": true,
+}
+
+// secretValueEndpoints are the shielded endpoints whose "value" field, in the request or the
+// response, holds a key rather than an amount or id.
+var secretValueEndpoints = []string{
+	"wallet/getspendingkey",
+	"wallet/getexpandedspendingkey",
+	"wallet/getakfromask",
+	"wallet/getnkfromnsk",
+}
+
+// secretValue returns whether the "value" field of requests to a path holds a key.
+func secretValue(path string) bool {
+	for _, endpoint := range secretValueEndpoints {
+		if strings.HasSuffix(path, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLogger logs every request and response at debug level. Signatures, private keys, API keys
+// and shielded keys are redacted from both the headers and the json bodies.
+func WithLogger(l Logger) Option {
+	return WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var body []byte
+			if req.Body != nil {
+				var err error
+				if body, err = ioutil.ReadAll(req.Body); err != nil {
+					return nil, err
+				}
+				req.Body.Close()
+				req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			}
+
+			id := req.Header.Get(RequestIdHeader)
+			secret := secretValue(req.URL.Path)
+
+			l.Debug("client: request",
+				"request_id", id,
+				"method", req.Method,
+				"url", req.URL.String(),
+				"headers", redactHeaders(req.Header),
+				"body", redactJSON(body, secret))
+
+			start := time.Now()
+			resp, err := next(req)
+			if err != nil {
+				l.Debug("client: request failed", "request_id", id, "url", req.URL.String(), "error", err)
+				return nil, err
+			}
+
+			data, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+			l.Debug("client: response",
+				"request_id", id,
+				"url", req.URL.String(),
+				"status", resp.StatusCode,
+				"duration", time.Since(start),
+				"body", redactJSON(data, secret))
+
+			return resp, nil
+		}
+	})
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if sensitiveKeys[strings.ToLower(k)] {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ",")
+	}
+	return out
+}
+
+// redactJSON returns a json body with the values of sensitive fields replaced, including the
+// "value" fields if secret is true. Bodies which are not json are returned as is.
+func redactJSON(data []byte, secret bool) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+
+	bs, err := json.Marshal(redactValue(v, secret))
+	if err != nil {
+		return string(data)
+	}
+
+	return string(bs)
+}
+
+func redactValue(v interface{}, secret bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			key := strings.ToLower(k)
+			if sensitiveKeys[key] || (secret && key == "value") {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(val, secret)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValue(val, secret)
+		}
+		return v
+	default:
+		return v
+	}
+}
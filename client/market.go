@@ -0,0 +1,181 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+)
+
+// MarketTRX is the token id of TRX in market orders, other tokens are identified by their
+// TRC10 asset id.
+const MarketTRX = "_"
+
+// MarketOrderState is the stage of a market order.
+type MarketOrderState string
+
+const (
+	MarketOrderActive   MarketOrderState = "ACTIVE"
+	MarketOrderInactive MarketOrderState = "INACTIVE"
+	MarketOrderCanceled MarketOrderState = "CANCELED"
+)
+
+// MarketPair is a pair of tokens traded on the market.
+type MarketPair struct {
+	SellTokenId string
+	BuyTokenId  string
+}
+
+// MarketOrder is an order to sell a quantity of one token for a quantity of another, at the
+// price given by the ratio of the quantities.
+type MarketOrder struct {
+	// Id is the hex encoded id of the order.
+	Id    string
+	Owner address.Address
+	State MarketOrderState
+
+	SellTokenId       string
+	SellTokenQuantity int64
+	BuyTokenId        string
+	BuyTokenQuantity  int64
+
+	// SellTokenQuantityRemain is the quantity not yet sold, SellTokenQuantityReturn the
+	// quantity returned to the owner when the order was canceled or became too small to fill.
+	SellTokenQuantityRemain int64
+	SellTokenQuantityReturn int64
+
+	// CreateTime is in milliseconds since the epoch.
+	CreateTime int64
+}
+
+// MarketSellAsset creates and signs a transaction which places an order to sell a quantity of
+// a token for a quantity of another. The transaction is not broadcast.
+func (c *Client) MarketSellAsset(owner account.Account, sellTokenId string, sellQuantity int64, buyTokenId string, buyQuantity int64) (tron.Transaction, error) {
+	switch {
+	case sellTokenId == "" || buyTokenId == "":
+		return tron.Transaction{}, errors.New("client: market order token id is empty")
+	case sellTokenId == buyTokenId:
+		return tron.Transaction{}, fmt.Errorf("client: market order sells and buys the same token (%s)", sellTokenId)
+	case sellQuantity <= 0 || buyQuantity <= 0:
+		return tron.Transaction{}, fmt.Errorf("client: market order quantities must be positive (%d, %d)", sellQuantity, buyQuantity)
+	}
+
+	var request = struct {
+		Owner        string `json:"owner_address"`
+		SellTokenId  string `json:"sell_token_id"`
+		SellQuantity int64  `json:"sell_token_quantity"`
+		BuyTokenId   string `json:"buy_token_id"`
+		BuyQuantity  int64  `json:"buy_token_quantity"`
+	}{
+		Owner:        c.encodeAddress(owner.Address()),
+		SellTokenId:  c.encodeString(sellTokenId),
+		SellQuantity: sellQuantity,
+		BuyTokenId:   c.encodeString(buyTokenId),
+		BuyQuantity:  buyQuantity,
+	}
+
+	return c.createTransaction(owner, "wallet/marketsellasset", &request)
+}
+
+// MarketCancelOrder creates and signs a transaction which cancels an active order, returning
+// the quantity not yet sold. The transaction is not broadcast.
+func (c *Client) MarketCancelOrder(owner account.Account, orderId string) (tron.Transaction, error) {
+	var request = struct {
+		Owner   string `json:"owner_address"`
+		OrderId string `json:"order_id"`
+	}{
+		Owner:   c.encodeAddress(owner.Address()),
+		OrderId: orderId,
+	}
+
+	return c.createTransaction(owner, "wallet/marketcancelorder", &request)
+}
+
+// GetMarketOrderByAccount returns the active orders of an account.
+func (c *Client) GetMarketOrderByAccount(addr address.Address) ([]MarketOrder, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: c.encodeAddress(addr),
+	}
+
+	var response struct {
+		Orders []struct {
+			Id                      string           `json:"order_id"`
+			Owner                   address.Address  `json:"owner_address"`
+			State                   MarketOrderState `json:"state"`
+			SellTokenId             string           `json:"sell_token_id"`
+			SellTokenQuantity       int64            `json:"sell_token_quantity"`
+			BuyTokenId              string           `json:"buy_token_id"`
+			BuyTokenQuantity        int64            `json:"buy_token_quantity"`
+			SellTokenQuantityRemain int64            `json:"sell_token_quantity_remain"`
+			SellTokenQuantityReturn int64            `json:"sell_token_quantity_return"`
+			CreateTime              int64            `json:"create_time"`
+		} `json:"orders"`
+	}
+	if err := c.post("wallet/getmarketorderbyaccount", &request, &response); err != nil {
+		return nil, err
+	}
+
+	orders := make([]MarketOrder, 0, len(response.Orders))
+	for _, o := range response.Orders {
+		sell, err := c.decodeString(o.SellTokenId)
+		if err != nil {
+			return nil, err
+		}
+
+		buy, err := c.decodeString(o.BuyTokenId)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, MarketOrder{
+			Id:                      o.Id,
+			Owner:                   o.Owner,
+			State:                   o.State,
+			SellTokenId:             sell,
+			SellTokenQuantity:       o.SellTokenQuantity,
+			BuyTokenId:              buy,
+			BuyTokenQuantity:        o.BuyTokenQuantity,
+			SellTokenQuantityRemain: o.SellTokenQuantityRemain,
+			SellTokenQuantityReturn: o.SellTokenQuantityReturn,
+			CreateTime:              o.CreateTime,
+		})
+	}
+
+	return orders, nil
+}
+
+// GetMarketPairList returns the pairs of tokens which have active orders.
+func (c *Client) GetMarketPairList() ([]MarketPair, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Pairs []struct {
+			SellTokenId string `json:"sell_token_id"`
+			BuyTokenId  string `json:"buy_token_id"`
+		} `json:"orderPair"`
+	}
+	if err := c.post("wallet/getmarketpairlist", &request, &response); err != nil {
+		return nil, err
+	}
+
+	pairs := make([]MarketPair, 0, len(response.Pairs))
+	for _, p := range response.Pairs {
+		sell, err := c.decodeString(p.SellTokenId)
+		if err != nil {
+			return nil, err
+		}
+
+		buy, err := c.decodeString(p.BuyTokenId)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, MarketPair{SellTokenId: sell, BuyTokenId: buy})
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import "time"
+
+// MetricsCollector receives measurements of the client's behaviour. It is deliberately small
+// so that it can be backed by Prometheus, StatsD or anything else without the client
+// depending on them. Implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called after every request to the node with the endpoint, how long
+	// the request took and the error it failed with, if any.
+	ObserveRequest(endpoint string, duration time.Duration, err error)
+
+	// ObserveBroadcastFailure is called when the node refuses to broadcast a transaction.
+	ObserveBroadcastFailure(code BroadcastCode)
+
+	// ObserveAwait is called when a call to Await finishes.
+	ObserveAwait(duration time.Duration, err error)
+}
+
+// WithMetrics reports the client's behaviour to a metrics collector.
+func WithMetrics(m MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// ErrorCode returns a short, low cardinality label describing an error returned by the
+// client, which is suitable for use as a metric label.
+func ErrorCode(err error) string {
+	switch err := err.(type) {
+	case nil:
+		return "ok"
+	case *NodeError:
+		if err.Category != "" {
+			return err.Category
+		}
+		return "node"
+	case *BroadcastError:
+		return string(err.Code)
+	case *ValidationError:
+		return "validation"
+	case *RequestError:
+		return "request"
+	default:
+		return "other"
+	}
+}
+
+// nopMetrics discards all measurements.
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, time.Duration, error) {}
+func (nopMetrics) ObserveBroadcastFailure(BroadcastCode)       {}
+func (nopMetrics) ObserveAwait(time.Duration, error)           {}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+)
+
+const (
+	// DefaultMulticallBatchSize is the most calls Multicall makes in one call of a multicall
+	// contract, which keeps the energy of each call well within the limit of a constant call.
+	DefaultMulticallBatchSize = 100
+
+	// DefaultMulticallConcurrency is the most requests Multicall has in flight at once.
+	DefaultMulticallConcurrency = 8
+)
+
+// Call is a constant call made by Multicall.
+type Call struct {
+	Address   address.Address
+	Function  abi.Function
+	Arguments []interface{}
+
+	// Result, if set, is what the return data of the call is unmarshaled into.
+	Result interface{}
+}
+
+// CallResult is the outcome of a call made by Multicall, its return data or why it failed.
+type CallResult struct {
+	Data []byte
+	Err  error
+}
+
+// MulticallOptions controls how Multicall makes its calls.
+type MulticallOptions struct {
+	// Contract is the address of a Multicall2 contract. If set, the calls are made in batches
+	// through its tryAggregate function, otherwise each call is a request of its own.
+	Contract address.Address
+
+	// BatchSize is the most calls in a batch, zero uses DefaultMulticallBatchSize.
+	BatchSize int
+
+	// Concurrency is the most requests in flight at once, zero uses
+	// DefaultMulticallConcurrency.
+	Concurrency int
+}
+
+// Multicall makes many constant calls, either through a multicall contract or as concurrent
+// requests, and returns their results in the order of the calls. A call failing, such as a
+// revert, fails only its own result; the error is only returned if no calls could be made,
+// such as when the context is done.
+//
+// Calls made through a multicall contract are made by that contract, functions whose result
+// depends on the caller should be called without one.
+func (c *Client) Multicall(ctx context.Context, calls []Call, opts MulticallOptions) ([]CallResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultMulticallBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultMulticallConcurrency
+	}
+
+	// Each task makes the calls from start to end, either as one batch or one request.
+	size := 1
+	if opts.Contract != address.Zero {
+		size = opts.BatchSize
+	}
+
+	results := make([]CallResult, len(calls))
+	sem := make(chan struct{}, opts.Concurrency)
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(calls); start += size {
+		end := start + size
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if opts.Contract != address.Zero {
+				c.multicallBatch(ctx, opts.Contract, calls[start:end], results[start:end])
+			} else {
+				results[start] = c.multicallOne(ctx, calls[start])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, call := range calls {
+		if results[i].Err == nil && call.Result != nil {
+			results[i].Err = abi.Unmarshal(results[i].Data, call.Function, call.Result)
+		}
+	}
+
+	return results, nil
+}
+
+// multicallBatch makes a batch of calls through tryAggregate of a multicall contract.
+func (c *Client) multicallBatch(ctx context.Context, multicall address.Address, calls []Call, results []CallResult) {
+	// Calls whose arguments cannot be encoded fail on their own and are left out of the batch.
+	var batch []*batchedCall
+	var batched []int
+	for i, call := range calls {
+		args, err := call.Function.EncodeArguments(call.Arguments...)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		sel := call.Function.Selector()
+		batch = append(batch, &batchedCall{
+			contract: call.Address,
+			data:     append(sel[:], args...),
+		})
+		batched = append(batched, i)
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	aggregated, err := c.aggregate(ctx, multicall, batch)
+	for j, i := range batched {
+		call := calls[i]
+		switch {
+		case err != nil:
+			results[i].Err = err
+		case !aggregated[j].success:
+			results[i].Err = fmt.Errorf("client: call of %s on %s failed", call.Function.Name, call.Address.ToBase58())
+		default:
+			results[i].Data = aggregated[j].data
+		}
+	}
+}
+
+// multicallOne makes a call as a request of its own.
+func (c *Client) multicallOne(ctx context.Context, call Call) CallResult {
+	args, err := call.Function.EncodeArguments(call.Arguments...)
+	if err != nil {
+		return CallResult{Err: err}
+	}
+
+	request := struct {
+		ContractAddress  string `json:"contract_address"`
+		FunctionSelector string `json:"function_selector"`
+		Parameter        string `json:"parameter"`
+		OwnerAddress     string `json:"owner_address"`
+	}{
+		ContractAddress:  c.encodeAddress(call.Address),
+		FunctionSelector: call.Function.Signature(),
+		Parameter:        hex.EncodeToString(args),
+		OwnerAddress:     c.encodeAddress(call.Address),
+	}
+
+	var response struct {
+		Result      []string `json:"constant_result"`
+		Transaction struct {
+			Ret []struct {
+				Result string `json:"contractRet"`
+			} `json:"ret"`
+		} `json:"transaction"`
+	}
+	if err := c.postContext(ctx, "wallet/triggerconstantcontract", &request, &response); err != nil {
+		return CallResult{Err: err}
+	}
+
+	for _, ret := range response.Transaction.Ret {
+		if ret.Result != "" && ret.Result != string(TxResultSuccess) {
+			return CallResult{Err: fmt.Errorf("client: call of %s on %s failed (%s)", call.Function.Name, call.Address.ToBase58(), ret.Result)}
+		}
+	}
+
+	if len(response.Result) < 1 {
+		return CallResult{Err: fmt.Errorf("client: call of %s on %s returned no result", call.Function.Name, call.Address.ToBase58())}
+	}
+
+	data, err := hex.DecodeString(response.Result[0])
+	return CallResult{Data: data, Err: err}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BlockRef identifies a block by its height and id.
+type BlockRef struct {
+	Num uint64
+	Id  string
+}
+
+// UnmarshalJSON decodes a block reference from the "Num:<height>,ID:<id>" form used by the node.
+func (b *BlockRef) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("client: invalid block reference %q", s)
+		}
+
+		switch kv[0] {
+		case "Num":
+			n, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("client: invalid block reference %q: %v", s, err)
+			}
+			b.Num = n
+		case "ID":
+			b.Id = kv[1]
+		}
+	}
+
+	return nil
+}
+
+// PeerInfo describes a peer that the node is connected to.
+type PeerInfo struct {
+	Host                string   `json:"host"`
+	Port                int      `json:"port"`
+	NodeId              string   `json:"nodeId"`
+	Active              bool     `json:"isActive"`
+	SyncFlag            bool     `json:"syncFlag"`
+	HeadBlockWeBothHave BlockRef `json:"headBlockWeBothHave"`
+	LastSyncBlock       string   `json:"lastSyncBlock"`
+	RemainNum           uint64   `json:"remainNum"`
+	AvgLatency          float64  `json:"avgLatency"`
+}
+
+// NodeConfig is the configuration and version of the node.
+type NodeConfig struct {
+	CodeVersion          string `json:"codeVersion"`
+	P2PVersion           string `json:"p2pVersion"`
+	VersionNum           int64  `json:"versionNum"`
+	ListenPort           int    `json:"listenPort"`
+	DiscoverEnable       bool   `json:"discoverEnable"`
+	ActiveNodeSize       int    `json:"activeNodeSize"`
+	MinParticipationRate int    `json:"minParticipationRate"`
+}
+
+// NodeInfo is the sync status, peers and version of a node.
+type NodeInfo struct {
+	BeginSyncNum        uint64     `json:"beginSyncNum"`
+	Block               BlockRef   `json:"block"`
+	SolidityBlock       BlockRef   `json:"solidityBlock"`
+	CurrentConnectCount int        `json:"currentConnectCount"`
+	ActiveConnectCount  int        `json:"activeConnectCount"`
+	PassiveConnectCount int        `json:"passiveConnectCount"`
+	Peers               []PeerInfo `json:"peerInfoList"`
+	Config              NodeConfig `json:"configNodeInfo"`
+}
+
+// NetworkHead returns the height of the network tip as far as the node knows it, which is its
+// own head plus the most blocks that any peer has reported it is missing.
+func (n *NodeInfo) NetworkHead() uint64 {
+	var remain uint64
+	for _, p := range n.Peers {
+		if p.RemainNum > remain {
+			remain = p.RemainNum
+		}
+	}
+	return n.Block.Num + remain
+}
+
+// Lag returns the number of blocks that the node is behind the network tip.
+func (n *NodeInfo) Lag() uint64 {
+	return n.NetworkHead() - n.Block.Num
+}
+
+// GetNodeInfo returns the sync status, peers and version of the full node.
+func (c *Client) GetNodeInfo() (*NodeInfo, error) {
+	return c.getNodeInfo(context.Background())
+}
+
+func (c *Client) getNodeInfo(ctx context.Context) (*NodeInfo, error) {
+	var request = struct{}{}
+
+	var info NodeInfo
+	if err := c.postContext(ctx, "wallet/getnodeinfo", &request, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// Healthy reports whether the full node has peers and is within maxLag blocks of the network
+// tip. An error is returned if the node could not be queried.
+func (c *Client) Healthy(ctx context.Context, maxLag uint64) (bool, error) {
+	info, err := c.getNodeInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if info.CurrentConnectCount == 0 {
+		return false, nil
+	}
+
+	return info.Lag() <= maxLag, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import "net/http"
+
+// Option configures optional behaviour of a client.
+type Option func(*Client)
+
+// WithCrossValidation enables cross validation of the blocks and transactions returned by
+// the node. Ids are recomputed from the raw protobuf payloads and the JSON fields are
+// compared against the decoded raw data, any discrepancy fails the call with a
+// *ValidationError. This is useful when the node is operated by an untrusted third party.
+func WithCrossValidation() Option {
+	return func(c *Client) {
+		c.crossValidate = true
+	}
+}
+
+// With returns a copy of the client with additional options applied. Middleware added to the
+// copy runs inside the middleware of the original client. The copy shares its stats and
+// in-flight transactions with the original, so that copies made for different callers of
+// the same node are reported and shut down together. The copy also shares the nodes of the
+// original, and so follows Reconfigure, unless the options change its hosts or API key.
+func (c *Client) With(opts ...Option) *Client {
+	cp := *c
+	cp.middleware = append([]Middleware(nil), c.middleware...)
+
+	// Options are applied to a copy of the nodes, so that they cannot change the nodes of
+	// the original and its other copies.
+	before := c.nodes.clone()
+	cp.nodes = before.clone()
+
+	for _, opt := range opts {
+		opt(&cp)
+	}
+
+	if cp.nodes.equal(before) {
+		cp.nodes = c.nodes
+	}
+
+	cp.buildRoundTrip()
+	return &cp
+}
+
+// RoundTripFunc performs a single HTTP request against the node.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc, for example to log requests, add authentication headers
+// or serve responses from a cache.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware to the client. The first middleware is the outermost, it
+// sees each request first and each response last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithSolidityHost sets the host of the solidity node API. By default the solidity endpoints
+// are requested from the full node host, as is the case for TronGrid.
+func WithSolidityHost(host string) Option {
+	return func(c *Client) {
+		c.nodes.mu.Lock()
+		c.nodes.solidityHost = host
+		c.nodes.mu.Unlock()
+	}
+}
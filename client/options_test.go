@@ -0,0 +1,26 @@
+package client
+
+import "testing"
+
+func TestWithNodes(t *testing.T) {
+	base := New("http://base.example", WithAPIKey("base"))
+
+	tenant := base.With(WithAPIKey("tenant"), WithSolidityHost("http://solidity.example"))
+	if cfg := base.Config(); cfg.APIKey != "base" || cfg.SolidityHost != "http://base.example" {
+		t.Fatalf("options of a copy changed the original to %+v", cfg)
+	}
+	if cfg := tenant.Config(); cfg.APIKey != "tenant" || cfg.SolidityHost != "http://solidity.example" {
+		t.Fatalf("copy has config %+v", cfg)
+	}
+
+	shared := base.With(WithCrossValidation())
+	if err := base.Reconfigure(Config{Host: "http://other.example", APIKey: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := shared.Config(); cfg.Host != "http://other.example" || cfg.APIKey != "other" {
+		t.Fatalf("copy did not follow reconfigure, has config %+v", cfg)
+	}
+	if cfg := tenant.Config(); cfg.APIKey != "tenant" {
+		t.Fatalf("reconfigure changed the API key of a copy with its own to %s", cfg.APIKey)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+// ChainParameter is the name of a dynamic chain parameter as returned by the node.
+type ChainParameter string
+
+const (
+	ParamMaintenanceTimeInterval             ChainParameter = "getMaintenanceTimeInterval"
+	ParamAccountUpgradeCost                  ChainParameter = "getAccountUpgradeCost"
+	ParamCreateAccountFee                    ChainParameter = "getCreateAccountFee"
+	ParamTransactionFee                      ChainParameter = "getTransactionFee"
+	ParamAssetIssueFee                       ChainParameter = "getAssetIssueFee"
+	ParamWitnessPayPerBlock                  ChainParameter = "getWitnessPayPerBlock"
+	ParamWitnessStandbyAllowance             ChainParameter = "getWitnessStandbyAllowance"
+	ParamCreateNewAccountFeeInSystemContract ChainParameter = "getCreateNewAccountFeeInSystemContract"
+	ParamCreateNewAccountBandwidthRate       ChainParameter = "getCreateNewAccountBandwidthRate"
+	ParamEnergyFee                           ChainParameter = "getEnergyFee"
+	ParamExchangeCreateFee                   ChainParameter = "getExchangeCreateFee"
+	ParamMaxCpuTimeOfOneTx                   ChainParameter = "getMaxCpuTimeOfOneTx"
+	ParamTotalEnergyLimit                    ChainParameter = "getTotalEnergyLimit"
+	ParamTotalEnergyCurrentLimit             ChainParameter = "getTotalEnergyCurrentLimit"
+	ParamMemoFee                             ChainParameter = "getMemoFee"
+	ParamMultiSignFee                        ChainParameter = "getMultiSignFee"
+	ParamUpdateAccountPermissionFee          ChainParameter = "getUpdateAccountPermissionFee"
+	ParamMaxFeeLimit                         ChainParameter = "getMaxFeeLimit"
+	ParamUnfreezeDelayDays                   ChainParameter = "getUnfreezeDelayDays"
+	ParamAllowTvmConstantinople              ChainParameter = "getAllowTvmConstantinople"
+	ParamAllowNewResourceModel               ChainParameter = "getAllowNewResourceModel"
+	ParamAllowDelegateResource               ChainParameter = "getAllowDelegateResource"
+)
+
+// ChainParameters are the dynamic chain parameters, which are changed by proposals. Parameters
+// which the node reports without a value are present with a value of zero.
+type ChainParameters map[ChainParameter]int64
+
+// EnergyFee returns the sun burned per unit of energy.
+func (p ChainParameters) EnergyFee() int64 {
+	return p[ParamEnergyFee]
+}
+
+// TransactionFee returns the sun burned per byte of bandwidth.
+func (p ChainParameters) TransactionFee() int64 {
+	return p[ParamTransactionFee]
+}
+
+// CreateAccountFee returns the sun burned when a transfer activates a new account.
+func (p ChainParameters) CreateAccountFee() int64 {
+	return p[ParamCreateAccountFee]
+}
+
+// CreateNewAccountFeeInSystemContract returns the additional sun burned when a system contract
+// activates a new account.
+func (p ChainParameters) CreateNewAccountFeeInSystemContract() int64 {
+	return p[ParamCreateNewAccountFeeInSystemContract]
+}
+
+// MemoFee returns the sun burned for transactions that carry a memo.
+func (p ChainParameters) MemoFee() int64 {
+	return p[ParamMemoFee]
+}
+
+// MultiSignFee returns the sun burned for transactions signed by more than one key.
+func (p ChainParameters) MultiSignFee() int64 {
+	return p[ParamMultiSignFee]
+}
+
+// MaxFeeLimit returns the largest fee limit that a transaction may set.
+func (p ChainParameters) MaxFeeLimit() int64 {
+	return p[ParamMaxFeeLimit]
+}
+
+// GetChainParameters returns the current dynamic chain parameters.
+func (c *Client) GetChainParameters() (ChainParameters, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Parameters []struct {
+			Key   ChainParameter `json:"key"`
+			Value int64          `json:"value"`
+		} `json:"chainParameter"`
+	}
+	if err := c.post("wallet/getchainparameters", &request, &response); err != nil {
+		return nil, err
+	}
+
+	params := make(ChainParameters, len(response.Parameters))
+	for _, p := range response.Parameters {
+		params[p.Key] = p.Value
+	}
+
+	return params, nil
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// Permission types, as reported in Permission.Type.
+const (
+	PermissionOwner   = "Owner"
+	PermissionWitness = "Witness"
+	PermissionActive  = "Active"
+)
+
+// maxActivePermissions is the most active permissions that an account may have.
+const maxActivePermissions = 8
+
+// Operations returns the operations bitmap of a permission which may sign the provided
+// contract types, as the hex string used by Permission.Operations.
+func Operations(types ...pb.ContractType) string {
+	var bitmap [32]byte
+	for _, t := range types {
+		bitmap[t/8] |= 1 << uint(t%8)
+	}
+	return hex.EncodeToString(bitmap[:])
+}
+
+// OperationsFromNames returns the operations bitmap of a permission which may sign the contract
+// types with the provided names, such as "TransferContract" or "TriggerSmartContract".
+func OperationsFromNames(names ...string) (string, error) {
+	types := make([]pb.ContractType, 0, len(names))
+	for _, name := range names {
+		t, err := pb.ParseContractType(name)
+		if err != nil {
+			return "", err
+		}
+		types = append(types, t)
+	}
+	return Operations(types...), nil
+}
+
+// ParseOperations returns the contract types allowed by an operations bitmap.
+func ParseOperations(operations string) ([]pb.ContractType, error) {
+	bitmap, err := hex.DecodeString(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bitmap) != 32 {
+		return nil, fmt.Errorf("client: operations must be 32 bytes (%d)", len(bitmap))
+	}
+
+	var types []pb.ContractType
+	for i, b := range bitmap {
+		for bit := uint(0); bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				types = append(types, pb.ContractType(i*8+int(bit)))
+			}
+		}
+	}
+
+	return types, nil
+}
+
+// PermissionUpdate is the complete set of permissions of an account, which replaces its
+// existing permissions. Witness may only be set for witness accounts, and there must be at
+// least one and at most eight active permissions. The type and id of each permission are set
+// from its position.
+type PermissionUpdate struct {
+	Owner   Permission
+	Witness *Permission
+	Actives []Permission
+}
+
+// UpdateAccountPermissions creates and signs a transaction which replaces the permissions of an
+// account. The transaction must be signed by the current owner permission of the account and
+// is not broadcast.
+func (c *Client) UpdateAccountPermissions(owner account.Account, update PermissionUpdate) (tron.Transaction, error) {
+	if len(update.Actives) == 0 || len(update.Actives) > maxActivePermissions {
+		return tron.Transaction{}, fmt.Errorf("client: account must have between 1 and %d active permissions (%d)", maxActivePermissions, len(update.Actives))
+	}
+
+	type key struct {
+		Address string `json:"address"`
+		Weight  int64  `json:"weight"`
+	}
+
+	type permission struct {
+		Type       string `json:"type"`
+		Id         int32  `json:"id"`
+		Name       string `json:"permission_name"`
+		Threshold  int64  `json:"threshold"`
+		Operations string `json:"operations,omitempty"`
+		Keys       []key  `json:"keys"`
+	}
+
+	encode := func(p Permission, typ string, id int32) (*permission, error) {
+		if err := checkPermission(p, typ); err != nil {
+			return nil, err
+		}
+
+		encoded := &permission{
+			Type:       typ,
+			Id:         id,
+			Name:       p.Name,
+			Threshold:  p.Threshold,
+			Operations: p.Operations,
+		}
+		for _, k := range p.Keys {
+			encoded.Keys = append(encoded.Keys, key{Address: c.encodeAddress(k.Address), Weight: k.Weight})
+		}
+
+		return encoded, nil
+	}
+
+	var request = struct {
+		Owner           string        `json:"owner_address"`
+		OwnerPermission *permission   `json:"owner"`
+		Witness         *permission   `json:"witness,omitempty"`
+		Actives         []*permission `json:"actives"`
+	}{
+		Owner: c.encodeAddress(owner.Address()),
+	}
+
+	var err error
+	if request.OwnerPermission, err = encode(update.Owner, PermissionOwner, 0); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if update.Witness != nil {
+		if request.Witness, err = encode(*update.Witness, PermissionWitness, 1); err != nil {
+			return tron.Transaction{}, err
+		}
+	}
+
+	// Active permissions are numbered from 2.
+	for i, p := range update.Actives {
+		active, err := encode(p, PermissionActive, int32(i+2))
+		if err != nil {
+			return tron.Transaction{}, err
+		}
+		request.Actives = append(request.Actives, active)
+	}
+
+	var tx tron.Transaction
+	if err := c.post("wallet/accountpermissionupdate", &request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := owner.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
+// checkPermission checks that a permission can be satisfied and that only active permissions
+// set operations, as the node requires.
+func checkPermission(p Permission, typ string) error {
+	if p.Threshold <= 0 {
+		return fmt.Errorf("client: %s permission threshold must be positive (%d)", typ, p.Threshold)
+	}
+
+	if len(p.Keys) == 0 {
+		return fmt.Errorf("client: %s permission has no keys", typ)
+	}
+
+	switch {
+	case typ == PermissionActive && p.Operations == "":
+		return errors.New("client: active permission has no operations")
+	case typ != PermissionActive && p.Operations != "":
+		return fmt.Errorf("client: %s permission cannot have operations", typ)
+	}
+
+	if p.Operations != "" {
+		if _, err := ParseOperations(p.Operations); err != nil {
+			return err
+		}
+	}
+
+	var total int64
+	seen := make(map[string]bool, len(p.Keys))
+	for _, k := range p.Keys {
+		addr := k.Address.ToBase58()
+		if k.Weight <= 0 {
+			return fmt.Errorf("client: %s permission key %s weight must be positive (%d)", typ, addr, k.Weight)
+		}
+
+		if seen[addr] {
+			return fmt.Errorf("client: %s permission key %s is duplicated", typ, addr)
+		}
+		seen[addr] = true
+
+		total += k.Weight
+	}
+
+	if total < p.Threshold {
+		return fmt.Errorf("client: %s permission threshold cannot be reached (%d < %d)", typ, total, p.Threshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+)
+
+// ProposalState is the stage of a proposal.
+type ProposalState string
+
+const (
+	ProposalPending     ProposalState = "PENDING"
+	ProposalApproved    ProposalState = "APPROVED"
+	ProposalDisapproved ProposalState = "DISAPPROVED"
+	ProposalCanceled    ProposalState = "CANCELED"
+)
+
+// ProposalParameter is a change to a network parameter, identified by its index in the
+// proposal system contract.
+type ProposalParameter struct {
+	Key   int64 `json:"key"`
+	Value int64 `json:"value"`
+}
+
+// Proposal is a proposal by a witness to change network parameters.
+type Proposal struct {
+	Id         int64               `json:"proposal_id"`
+	Proposer   address.Address     `json:"proposer_address"`
+	Parameters []ProposalParameter `json:"parameters"`
+	Approvals  []address.Address   `json:"approvals"`
+	State      ProposalState       `json:"state"`
+
+	// ExpirationTime and CreateTime are in milliseconds since the epoch.
+	ExpirationTime int64 `json:"expiration_time"`
+	CreateTime     int64 `json:"create_time"`
+}
+
+// ListProposals returns every proposal, most recent first.
+func (c *Client) ListProposals() ([]Proposal, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Proposals []Proposal `json:"proposals"`
+	}
+	if err := c.post("wallet/listproposals", &request, &response); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(response.Proposals, func(i, j int) bool {
+		return response.Proposals[i].Id > response.Proposals[j].Id
+	})
+
+	return response.Proposals, nil
+}
+
+// GetProposalById returns a proposal, or nil if it does not exist.
+func (c *Client) GetProposalById(id int64) (*Proposal, error) {
+	var request = struct {
+		Id int64 `json:"id"`
+	}{
+		Id: id,
+	}
+
+	var proposal Proposal
+	if err := c.post("wallet/getproposalbyid", &request, &proposal); err != nil {
+		return nil, err
+	}
+
+	// The node returns an empty object for proposals that do not exist, and ids start at 1.
+	if proposal.Id == 0 {
+		return nil, nil
+	}
+
+	return &proposal, nil
+}
+
+// ProposalCreate creates and signs a transaction which proposes changes to network parameters.
+// Only witnesses may create proposals. The transaction is not broadcast.
+func (c *Client) ProposalCreate(witness account.Account, parameters []ProposalParameter) (tron.Transaction, error) {
+	if len(parameters) == 0 {
+		return tron.Transaction{}, errors.New("client: proposal has no parameters")
+	}
+
+	var request = struct {
+		Owner      string              `json:"owner_address"`
+		Parameters []ProposalParameter `json:"parameters"`
+	}{
+		Owner:      c.encodeAddress(witness.Address()),
+		Parameters: parameters,
+	}
+
+	return c.createTransaction(witness, "wallet/proposalcreate", &request)
+}
+
+// ProposalApprove creates and signs a transaction which adds or, if approve is false, removes
+// the approval of a witness for a proposal. The transaction is not broadcast.
+func (c *Client) ProposalApprove(witness account.Account, id int64, approve bool) (tron.Transaction, error) {
+	var request = struct {
+		Owner   string `json:"owner_address"`
+		Id      int64  `json:"proposal_id"`
+		Approve bool   `json:"is_add_approval"`
+	}{
+		Owner:   c.encodeAddress(witness.Address()),
+		Id:      id,
+		Approve: approve,
+	}
+
+	return c.createTransaction(witness, "wallet/proposalapprove", &request)
+}
+
+// ProposalDelete creates and signs a transaction which cancels a pending proposal. Only the
+// proposer may cancel a proposal. The transaction is not broadcast.
+func (c *Client) ProposalDelete(proposer account.Account, id int64) (tron.Transaction, error) {
+	var request = struct {
+		Owner string `json:"owner_address"`
+		Id    int64  `json:"proposal_id"`
+	}{
+		Owner: c.encodeAddress(proposer.Address()),
+		Id:    id,
+	}
+
+	return c.createTransaction(proposer, "wallet/proposaldelete", &request)
+}
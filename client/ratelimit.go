@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the client to rps requests per second on average, while allowing
+// bursts of up to burst requests. Requests wait for capacity rather than failing, unless
+// their context is done first. A rate of zero is unlimited. The limit can be changed later
+// by Reconfigure.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		l := newLimiter(rps, burst)
+		c.limiter = l
+		c.middleware = append(c.middleware, func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if err := l.wait(req.Context()); err != nil {
+					return nil, err
+				}
+				return next(req)
+			}
+		})
+	}
+}
+
+// limiter is a token bucket, which is unlimited when its rate is zero.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rps float64, burst int) *limiter {
+	l := &limiter{last: time.Now()}
+	l.set(rps, burst)
+	l.tokens = l.burst
+	return l
+}
+
+// set changes the rate and burst of the limiter, keeping the tokens it has up to the new
+// burst.
+func (l *limiter) set(rps float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = rps
+	l.burst = float64(burst)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// limit returns the rate and burst of the limiter.
+func (l *limiter) limit() (float64, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate, int(l.burst)
+}
+
+// wait blocks until a token is available and takes it.
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		delay, ok := l.take()
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// take takes a token if one is available, otherwise it returns how long until one will be.
+func (l *limiter) take() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0, true
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+}
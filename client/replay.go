@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-chain/go-tron/pb"
+)
+
+// ReplayOutcome is the outcome of executing a contract call.
+type ReplayOutcome struct {
+	Result TransactionResult
+	Output []byte
+	Energy uint64
+}
+
+// Replay compares the outcome of a contract call on chain to the outcome of executing it again
+// as a constant call.
+type Replay struct {
+	TxId string
+
+	// Solidified is whether the call was executed again against the solidified state rather
+	// than the latest state of the full node.
+	Solidified bool
+
+	Original ReplayOutcome
+	Replayed ReplayOutcome
+
+	// Divergences describe how the outcomes differ, empty if they do not.
+	Divergences []string
+}
+
+// Diverged returns whether the outcome of the replay differs from the original.
+func (r *Replay) Diverged() bool {
+	return len(r.Divergences) > 0
+}
+
+// ReplayTransaction executes a contract call made by a transaction again as a constant call,
+// with the same caller, data and value, and compares the result, return data and energy to
+// its receipt. If solidified is set, the call is executed against the solidified state, which
+// is pinned to a block, rather than the latest state, which may change between calls.
+//
+// Nodes can only execute calls against their current state, not the state before the block
+// of the transaction, which includes the effects of the transaction itself and of everything
+// since. Any divergence is therefore due to state the call depends on, such as balances,
+// allowances, storage slots written for the first time or the block time, which is what
+// makes replays useful for debugging calls that fail intermittently.
+func (c *Client) ReplayTransaction(ctx context.Context, id string, solidified bool) (*Replay, error) {
+	tx, err := c.TransactionById(id)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("client: transaction %s not found", id)
+	}
+
+	info, err := c.TransactionInfoById(id)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("client: transaction %s has not been processed", id)
+	}
+
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := m.RawData.UnpackContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	var trigger *pb.TriggerSmartContract
+	for _, msg := range msgs {
+		if t, ok := msg.(*pb.TriggerSmartContract); ok {
+			trigger = t
+			break
+		}
+	}
+	if trigger == nil {
+		return nil, fmt.Errorf("client: transaction %s is not a contract call", id)
+	}
+
+	r := &Replay{
+		TxId:       id,
+		Solidified: solidified,
+		Original: ReplayOutcome{
+			Result: info.Receipt.Result,
+			Energy: info.Receipt.EnergyUsageTotal,
+		},
+	}
+	if len(info.ContractResult) > 0 {
+		if r.Original.Output, err = hex.DecodeString(info.ContractResult[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Replayed, err = c.replayTrigger(ctx, trigger, solidified); err != nil {
+		return nil, err
+	}
+
+	r.compare()
+
+	return r, nil
+}
+
+// replayTrigger executes a contract call as a constant call.
+func (c *Client) replayTrigger(ctx context.Context, trigger *pb.TriggerSmartContract, solidified bool) (ReplayOutcome, error) {
+	var response struct {
+		Result struct {
+			Result  bool   `json:"result"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"result"`
+		EnergyUsed     uint64   `json:"energy_used"`
+		ConstantResult []string `json:"constant_result"`
+		Transaction    struct {
+			Ret []struct {
+				ContractRet TransactionResult `json:"contractRet"`
+			} `json:"ret"`
+		} `json:"transaction"`
+	}
+
+	endpoint := "wallet/triggerconstantcontract"
+	if solidified {
+		endpoint = "walletsolidity/triggerconstantcontract"
+	}
+	if err := c.constantTrigger(ctx, endpoint, trigger, &response); err != nil {
+		return ReplayOutcome{}, err
+	}
+
+	if !response.Result.Result {
+		message := response.Result.Message
+		if bs, err := hex.DecodeString(message); err == nil {
+			message = string(bs)
+		}
+		return ReplayOutcome{}, fmt.Errorf("client: replay failed: %s: %s", response.Result.Code, message)
+	}
+
+	out := ReplayOutcome{Result: TxResultSuccess, Energy: response.EnergyUsed}
+	for _, ret := range response.Transaction.Ret {
+		if ret.ContractRet != "" {
+			out.Result = ret.ContractRet
+		}
+	}
+
+	if len(response.ConstantResult) > 0 {
+		data, err := hex.DecodeString(response.ConstantResult[0])
+		if err != nil {
+			return ReplayOutcome{}, err
+		}
+		out.Output = data
+	}
+
+	return out, nil
+}
+
+// compare records how the replayed outcome differs from the original.
+func (r *Replay) compare() {
+	original, replayed := r.Original, r.Replayed
+
+	// Receipts of successful calls may omit the result.
+	if original.Result == "" {
+		original.Result = TxResultSuccess
+	}
+
+	if original.Result != replayed.Result {
+		r.Divergences = append(r.Divergences, fmt.Sprintf("result was %s on chain but %s replayed", original.Result, replayed.Result))
+	}
+
+	if !bytes.Equal(original.Output, replayed.Output) {
+		r.Divergences = append(r.Divergences, fmt.Sprintf("output was 0x%x on chain but 0x%x replayed", original.Output, replayed.Output))
+	}
+
+	if original.Energy != replayed.Energy {
+		diff := int64(replayed.Energy) - int64(original.Energy)
+		r.Divergences = append(r.Divergences, fmt.Sprintf("energy was %d on chain but %d replayed (%+d)", original.Energy, replayed.Energy, diff))
+	}
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RequestIdHeader is the header that carries the id of each request made to the node, so
+// that requests can be correlated with node, proxy and application logs.
+const RequestIdHeader = "X-Request-Id"
+
+// WithCorrelationId returns a copy of the client whose request ids are prefixed with the
+// provided id. Components that use a client on behalf of a larger operation, such as a scan
+// or a payout, can use this to tie every request they make back to that operation.
+func (c *Client) WithCorrelationId(id string) *Client {
+	cp := *c
+	cp.correlationId = id
+	return &cp
+}
+
+// newRequestId generates a random id for a request.
+func (c *Client) newRequestId() string {
+	var bs [8]byte
+	if _, err := rand.Read(bs[:]); err != nil {
+		panic("client: unexpected error encountered while generating request id")
+	}
+
+	id := hex.EncodeToString(bs[:])
+	if c.correlationId != "" {
+		return c.correlationId + "/" + id
+	}
+
+	return id
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+)
+
+// AccountResource describes the bandwidth, energy and TronPower available to an account, along
+// with the balances it has frozen to obtain them.
+type AccountResource struct {
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+
+	EnergyUsed  int64 `json:"EnergyUsed"`
+	EnergyLimit int64 `json:"EnergyLimit"`
+
+	TronPowerUsed  int64 `json:"tronPowerUsed"`
+	TronPowerLimit int64 `json:"tronPowerLimit"`
+
+	TotalNetLimit        int64 `json:"TotalNetLimit"`
+	TotalNetWeight       int64 `json:"TotalNetWeight"`
+	TotalEnergyLimit     int64 `json:"TotalEnergyLimit"`
+	TotalEnergyWeight    int64 `json:"TotalEnergyWeight"`
+	TotalTronPowerWeight int64 `json:"TotalTronPowerWeight"`
+
+	// Frozen are the balances frozen by the account, both under Stake 1.0 and Stake 2.0.
+	Frozen []FrozenBalance `json:"-"`
+}
+
+// FreeNetRemaining returns the free bandwidth remaining today.
+func (r AccountResource) FreeNetRemaining() int64 {
+	return r.FreeNetLimit - r.FreeNetUsed
+}
+
+// NetRemaining returns the staked bandwidth remaining.
+func (r AccountResource) NetRemaining() int64 {
+	return r.NetLimit - r.NetUsed
+}
+
+// EnergyRemaining returns the staked energy remaining.
+func (r AccountResource) EnergyRemaining() int64 {
+	return r.EnergyLimit - r.EnergyUsed
+}
+
+// Resource is a resource that balance can be frozen for.
+type Resource string
+
+const (
+	ResourceBandwidth Resource = "BANDWIDTH"
+	ResourceEnergy    Resource = "ENERGY"
+	ResourceTronPower Resource = "TRON_POWER"
+)
+
+// FrozenBalance is an amount of sun frozen for a resource.
+type FrozenBalance struct {
+	Resource Resource
+	Amount   int64
+
+	// V2 is whether the balance was frozen under Stake 2.0.
+	V2 bool
+
+	// ExpireTime is when a Stake 1.0 balance can be unfrozen.
+	ExpireTime tron.Time
+}
+
+// GetAccountResource returns the resources available to an account.
+func (c *Client) GetAccountResource(addr address.Address) (*AccountResource, error) {
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(addr),
+	}
+
+	var resource AccountResource
+	if err := c.post("wallet/getaccountresource", &request, &resource); err != nil {
+		return nil, err
+	}
+
+	account, err := c.getAccountJSON(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != nil {
+		resource.Frozen = account.frozen()
+	}
+
+	return &resource, nil
+}
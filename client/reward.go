@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// GetReward returns the voting rewards, in sun, that an account has earned but not yet
+// withdrawn.
+func (c *Client) GetReward(addr address.Address) (int64, error) {
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(addr),
+	}
+
+	var response struct {
+		Reward int64 `json:"reward"`
+	}
+	if err := c.post("wallet/getReward", &request, &response); err != nil {
+		return 0, err
+	}
+
+	return response.Reward, nil
+}
+
+// GetBrokerage returns the percentage of voting rewards that a witness keeps as commission,
+// the remainder being shared among its voters.
+func (c *Client) GetBrokerage(witness address.Address) (int, error) {
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(witness),
+	}
+
+	var response struct {
+		Brokerage int `json:"brokerage"`
+	}
+	if err := c.post("wallet/getBrokerage", &request, &response); err != nil {
+		return 0, err
+	}
+
+	return response.Brokerage, nil
+}
+
+// UpdateBrokerage creates and signs a transaction which sets the commission of a witness to
+// the provided percentage. The transaction is not broadcast.
+func (c *Client) UpdateBrokerage(witness account.Account, brokerage int) (tron.Transaction, error) {
+	if brokerage < 0 || brokerage > 100 {
+		return tron.Transaction{}, fmt.Errorf("client: brokerage must be a percentage (%d)", brokerage)
+	}
+
+	var request = struct {
+		Owner     string `json:"owner_address"`
+		Brokerage int    `json:"brokerage"`
+	}{
+		Owner:     c.encodeAddress(witness.Address()),
+		Brokerage: brokerage,
+	}
+
+	var tx tron.Transaction
+	if err := c.post("wallet/updateBrokerage", &request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := witness.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
+// WithdrawResult is the outcome of withdrawing the rewards of an account.
+type WithdrawResult struct {
+	Transaction tron.Transaction
+
+	// Amount is the reward that was withdrawn to the balance of the account.
+	Amount params.Sun
+
+	// Await is the outcome of waiting for the transaction to be processed.
+	Await *AwaitResult
+}
+
+// WithdrawBalance creates, signs and broadcasts a transaction which withdraws the voting and
+// block production rewards of an account to its balance, then waits for it to be processed
+// according to the options. Rewards can be withdrawn at most once every 24 hours.
+func (c *Client) WithdrawBalance(ctx context.Context, acc account.Account, opts AwaitOptions) (*WithdrawResult, error) {
+	var request = struct {
+		Owner string `json:"owner_address"`
+	}{
+		Owner: c.encodeAddress(acc.Address()),
+	}
+
+	var tx tron.Transaction
+	if err := c.postContext(ctx, "wallet/withdrawbalance", &request, &tx); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return nil, err
+	}
+
+	if err := acc.Sign(&tx); err != nil {
+		return nil, err
+	}
+
+	if err := c.BroadcastTransaction(&tx); err != nil {
+		return nil, err
+	}
+
+	result, err := c.Await(ctx, tx.Id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WithdrawResult{
+		Transaction: tx,
+		Amount:      params.Sun(result.Info.WithdrawAmount),
+		Await:       result,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SessionHeader is the header that carries the id of the session a request was made in, so
+// that middleware which spreads requests across nodes, such as HostPool, can route every
+// request of a session to the same node.
+const SessionHeader = "X-Session-Id"
+
+// Session returns a copy of the client whose requests are pinned to a single node by a pool
+// of hosts, and which reads monotonically: if the node falls behind the highest block seen in
+// the session, requests fail with a *StaleReadError. A sequence of reads made in a session
+// therefore observes a single, advancing view of the chain. The minimum height of the client,
+// if any, carries over to the session.
+func (c *Client) Session() *Client {
+	var bs [8]byte
+	if _, err := rand.Read(bs[:]); err != nil {
+		panic("client: unexpected error encountered while generating session id")
+	}
+
+	guard := &heightGuard{monotonic: true}
+	if c.heights != nil {
+		_, guard.min, _ = c.heights.cached()
+	}
+
+	cp := *c
+	cp.sessionId = hex.EncodeToString(bs[:])
+	cp.heights = guard
+	return &cp
+}
+
+// poolBackoff is how long a host is skipped for after a request to it fails.
+const poolBackoff = 30 * time.Second
+
+// HostPool returns middleware which spreads requests across several nodes, replacing the
+// scheme and host of each request with those of the next healthy node in turn. A node is
+// skipped for a while after a request to it fails. Requests made in a session are all sent
+// to the node the first request of the session was sent to, even if it fails, since moving
+// to another node would break the consistency of the session.
+func HostPool(hosts ...string) Middleware {
+	pool := &hostPool{
+		failed:   make(map[int]time.Time),
+		sessions: make(map[string]sessionHost),
+	}
+	for _, host := range hosts {
+		u, err := url.Parse(host)
+		if err != nil {
+			panic("client: invalid pool host " + host)
+		}
+		pool.hosts = append(pool.hosts, u)
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if len(pool.hosts) == 0 {
+				return next(req)
+			}
+
+			i := pool.pick(req.Header.Get(SessionHeader))
+			req.URL.Scheme = pool.hosts[i].Scheme
+			req.URL.Host = pool.hosts[i].Host
+			req.Host = pool.hosts[i].Host
+
+			resp, err := next(req)
+			if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+				pool.fail(i)
+			}
+			return resp, err
+		}
+	}
+}
+
+type sessionHost struct {
+	host int
+	used time.Time
+}
+
+type hostPool struct {
+	hosts []*url.URL
+
+	mu       sync.Mutex
+	next     int
+	failed   map[int]time.Time
+	sessions map[string]sessionHost
+}
+
+// pick returns the host for a request, the host of its session if it has one.
+func (p *hostPool) pick(session string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if s, ok := p.sessions[session]; ok && session != "" {
+		s.used = now
+		p.sessions[session] = s
+		return s.host
+	}
+
+	i := p.next
+	for n := 0; n < len(p.hosts); n++ {
+		candidate := (p.next + n) % len(p.hosts)
+		if now.Sub(p.failed[candidate]) > poolBackoff {
+			i = candidate
+			break
+		}
+	}
+	p.next = (i + 1) % len(p.hosts)
+
+	if session != "" {
+		// Sessions are forgotten once idle, as the pool cannot tell when they end.
+		for id, s := range p.sessions {
+			if now.Sub(s.used) > poolBackoff {
+				delete(p.sessions, id)
+			}
+		}
+		p.sessions[session] = sessionHost{host: i, used: now}
+	}
+
+	return i
+}
+
+func (p *hostPool) fail(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failed[i] = time.Now()
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// SimulationMode is how transactions are simulated on a shadow node.
+type SimulationMode int
+
+const (
+	// SimulateTrigger executes smart contract calls on the shadow node as constant calls,
+	// which changes no state. Other transactions are not simulated, since the node validates
+	// them fully before broadcasting and so they cannot burn fees by failing.
+	SimulateTrigger SimulationMode = iota
+
+	// SimulateBroadcast broadcasts every transaction to the shadow node, which must be a
+	// private fork or staging network that accepts the same transactions as the target.
+	SimulateBroadcast
+)
+
+// WithShadowNode simulates each transaction on the node at host before it is broadcast, and
+// only broadcasts it if the simulation succeeds. A failed simulation fails BroadcastTransaction
+// with a *SimulationError, avoiding the fees burned by transactions that revert.
+func WithShadowNode(host string, mode SimulationMode) Option {
+	return func(c *Client) {
+		c.shadowHost = host
+		c.shadowMode = mode
+	}
+}
+
+// SimulationError is returned when a transaction fails simulation on the shadow node.
+type SimulationError struct {
+	TxId    string
+	Message string
+
+	// Err is the error returned by the shadow node, if it refused the transaction.
+	Err error
+}
+
+func (e *SimulationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("client: transaction %s failed simulation: %v", e.TxId, e.Err)
+	}
+	return fmt.Sprintf("client: transaction %s failed simulation: %s", e.TxId, e.Message)
+}
+
+// shadowClient returns a copy of the client that makes requests to the shadow node.
+func (c *Client) shadowClient() *Client {
+	cp := *c
+	cp.nodes = &nodes{host: c.shadowHost, solidityHost: c.shadowHost}
+	cp.shadowHost = ""
+	return &cp
+}
+
+// simulate runs a transaction on the shadow node.
+func (c *Client) simulate(tx *tron.Transaction) error {
+	shadow := c.shadowClient()
+
+	if c.shadowMode == SimulateBroadcast {
+		if err := shadow.broadcast(tx); err != nil {
+			return &SimulationError{TxId: tx.Id, Err: err}
+		}
+		return nil
+	}
+
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := m.RawData.UnpackContracts()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		trigger, ok := msg.(*pb.TriggerSmartContract)
+		if !ok {
+			continue
+		}
+
+		if err := shadow.simulateTrigger(tx.Id, trigger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// simulateTrigger executes a smart contract call as a constant call.
+func (c *Client) simulateTrigger(id string, trigger *pb.TriggerSmartContract) error {
+	var response struct {
+		Result struct {
+			Result  bool   `json:"result"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"result"`
+		Transaction struct {
+			Ret []struct {
+				Ret         string            `json:"ret"`
+				ContractRet TransactionResult `json:"contractRet"`
+			} `json:"ret"`
+		} `json:"transaction"`
+	}
+	if err := c.constantTrigger(context.Background(), "wallet/triggerconstantcontract", trigger, &response); err != nil {
+		return &SimulationError{TxId: id, Err: err}
+	}
+
+	if !response.Result.Result {
+		message := response.Result.Message
+		if bs, err := hex.DecodeString(message); err == nil {
+			message = string(bs)
+		}
+		return &SimulationError{TxId: id, Message: fmt.Sprintf("%s: %s", response.Result.Code, message)}
+	}
+
+	// Calls that revert are still executed successfully, the outcome of the call is in the
+	// result of the transaction.
+	for _, ret := range response.Transaction.Ret {
+		if ret.Ret == "FAILED" || (ret.ContractRet != "" && ret.ContractRet != TxResultSuccess) {
+			return &SimulationError{TxId: id, Message: string(ret.ContractRet)}
+		}
+	}
+
+	return nil
+}
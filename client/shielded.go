@@ -0,0 +1,418 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+)
+
+// Functions of shielded TRC20 contracts, which are called with parameters built by the node.
+const (
+	shieldedMintSelector     = "mint(uint256,bytes32[9],bytes32[2],bytes32[21])"
+	shieldedTransferSelector = "transfer(bytes32[10][],bytes32[2][],bytes32[9][],bytes32[2],bytes32[21][])"
+	shieldedBurnSelector     = "burn(bytes32[10],bytes32[2],uint256,bytes32[2],address,bytes32[3],bytes32[9][],bytes32[21][])"
+	shieldedPathSelector     = "getPath(uint256)"
+)
+
+// ShieldedKeys are the keys expanded from a spending key. Ask authorizes spends, Nsk derives
+// the nullifiers of notes and Ovk lets the sender decrypt the notes they send.
+type ShieldedKeys struct {
+	Ask string `json:"ask"`
+	Nsk string `json:"nsk"`
+	Ovk string `json:"ovk"`
+}
+
+// ShieldedAddress is a shielded payment address with all of its keys, in hex.
+type ShieldedAddress struct {
+	ShieldedKeys
+
+	SpendingKey    string `json:"sk"`
+	Ak             string `json:"ak"`
+	Nk             string `json:"nk"`
+	Ivk            string `json:"ivk"`
+	Diversifier    string `json:"d"`
+	PkD            string `json:"pkD"`
+	PaymentAddress string `json:"payment_address"`
+}
+
+// ShieldedNote is an amount held by a shielded payment address. Value is in units of the
+// scaling factor of the shielded contract, Rcm is the hex encoded randomness of the note
+// commitment and Memo is hex encoded.
+type ShieldedNote struct {
+	Value          int64  `json:"value"`
+	PaymentAddress string `json:"payment_address"`
+	Rcm            string `json:"rcm"`
+	Memo           string `json:"memo,omitempty"`
+}
+
+// ShieldedNoteTx is a note found by scanning a shielded contract.
+type ShieldedNoteTx struct {
+	Note     ShieldedNote `json:"note"`
+	Position int64        `json:"position"`
+	IsSpent  bool         `json:"is_spent"`
+	TxId     string       `json:"txid"`
+	Index    int          `json:"index"`
+}
+
+// ShieldedSpend is a note to spend, with the hex encoded randomness of the spend and the
+// Merkle root and path of the note as returned by GetShieldedPath.
+type ShieldedSpend struct {
+	Note     ShieldedNote `json:"note"`
+	Alpha    string       `json:"alpha"`
+	Root     string       `json:"root"`
+	Path     string       `json:"path"`
+	Position int64        `json:"pos"`
+}
+
+type shieldedReceive struct {
+	Note ShieldedNote `json:"note"`
+}
+
+// GetSpendingKey returns a new random spending key.
+func (c *Client) GetSpendingKey() (string, error) {
+	return c.shieldedValue("wallet/getspendingkey", &struct{}{})
+}
+
+// GetExpandedSpendingKey returns the keys expanded from a spending key.
+func (c *Client) GetExpandedSpendingKey(sk string) (*ShieldedKeys, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: sk,
+	}
+
+	var keys ShieldedKeys
+	if err := c.post("wallet/getexpandedspendingkey", &request, &keys); err != nil {
+		return nil, err
+	}
+
+	return &keys, nil
+}
+
+// GetAkFromAsk returns the public key of a spend authorizing key.
+func (c *Client) GetAkFromAsk(ask string) (string, error) {
+	return c.shieldedValue("wallet/getakfromask", &struct {
+		Value string `json:"value"`
+	}{ask})
+}
+
+// GetNkFromNsk returns the public key of a nullifier deriving key.
+func (c *Client) GetNkFromNsk(nsk string) (string, error) {
+	return c.shieldedValue("wallet/getnkfromnsk", &struct {
+		Value string `json:"value"`
+	}{nsk})
+}
+
+// GetIncomingViewingKey returns the incoming viewing key of ak and nk, which can find the
+// notes received by an address but not spend them.
+func (c *Client) GetIncomingViewingKey(ak, nk string) (string, error) {
+	var request = struct {
+		Ak string `json:"ak"`
+		Nk string `json:"nk"`
+	}{
+		Ak: ak,
+		Nk: nk,
+	}
+
+	var response struct {
+		Ivk string `json:"ivk"`
+	}
+	if err := c.post("wallet/getincomingviewingkey", &request, &response); err != nil {
+		return "", err
+	}
+
+	return response.Ivk, nil
+}
+
+// GetDiversifier returns a new random diversifier.
+func (c *Client) GetDiversifier() (string, error) {
+	var request = struct{}{}
+
+	var response struct {
+		D string `json:"d"`
+	}
+	if err := c.post("wallet/getdiversifier", &request, &response); err != nil {
+		return "", err
+	}
+
+	return response.D, nil
+}
+
+// GetZenPaymentAddress returns the payment address of an incoming viewing key and a
+// diversifier. One key has many unlinkable payment addresses, one per diversifier.
+func (c *Client) GetZenPaymentAddress(ivk, d string) (string, error) {
+	var request = struct {
+		Ivk string `json:"ivk"`
+		D   string `json:"d"`
+	}{
+		Ivk: ivk,
+		D:   d,
+	}
+
+	var response struct {
+		PaymentAddress string `json:"payment_address"`
+	}
+	if err := c.post("wallet/getzenpaymentaddress", &request, &response); err != nil {
+		return "", err
+	}
+
+	return response.PaymentAddress, nil
+}
+
+// GetNewShieldedAddress returns a new random payment address with all of its keys. The keys
+// are generated by the node, which must be trusted with them.
+func (c *Client) GetNewShieldedAddress() (*ShieldedAddress, error) {
+	var request = struct{}{}
+
+	var addr ShieldedAddress
+	if err := c.post("wallet/getnewshieldedaddress", &request, &addr); err != nil {
+		return nil, err
+	}
+
+	if addr.PaymentAddress == "" {
+		return nil, errors.New("client: node returned no shielded address")
+	}
+
+	return &addr, nil
+}
+
+// GetRcm returns new randomness for a note commitment or a spend.
+func (c *Client) GetRcm() (string, error) {
+	return c.shieldedValue("wallet/getrcm", &struct{}{})
+}
+
+// ScanShieldedTRC20NotesByIvk returns the notes received by the incoming viewing key of ak and
+// nk in a shielded contract within a range of block heights, end exclusive. The node scans at
+// most 1000 blocks per request.
+func (c *Client) ScanShieldedTRC20NotesByIvk(contract address.Address, ivk, ak, nk string, start, end uint64) ([]ShieldedNoteTx, error) {
+	var request = struct {
+		Start    uint64 `json:"start_block_index"`
+		End      uint64 `json:"end_block_index"`
+		Contract string `json:"shielded_TRC20_contract_address"`
+		Ivk      string `json:"ivk"`
+		Ak       string `json:"ak"`
+		Nk       string `json:"nk"`
+	}{
+		Start:    start,
+		End:      end,
+		Contract: c.encodeAddress(contract),
+		Ivk:      ivk,
+		Ak:       ak,
+		Nk:       nk,
+	}
+
+	var response struct {
+		Notes []ShieldedNoteTx `json:"noteTxs"`
+	}
+	if err := c.post("wallet/scanshieldedtrc20notesbyivk", &request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Notes, nil
+}
+
+// IsShieldedTRC20NoteSpent returns whether a note of a shielded contract has been spent.
+func (c *Client) IsShieldedTRC20NoteSpent(contract address.Address, note ShieldedNote, ak, nk string, position int64) (bool, error) {
+	var request = struct {
+		Note     ShieldedNote `json:"note"`
+		Ak       string       `json:"ak"`
+		Nk       string       `json:"nk"`
+		Position int64        `json:"position"`
+		Contract string       `json:"shielded_TRC20_contract_address"`
+	}{
+		Note:     note,
+		Ak:       ak,
+		Nk:       nk,
+		Position: position,
+		Contract: c.encodeAddress(contract),
+	}
+
+	var response struct {
+		IsSpent bool `json:"is_spent"`
+	}
+	if err := c.post("wallet/isshieldedtrc20contractnotespent", &request, &response); err != nil {
+		return false, err
+	}
+
+	return response.IsSpent, nil
+}
+
+// GetShieldedPath returns the Merkle root of the notes of a shielded contract and the path of
+// the note at a position, hex encoded as required by ShieldedSpend.
+func (c *Client) GetShieldedPath(contract address.Address, position int64) (root, path string, err error) {
+	var request = struct {
+		ContractAddress  string `json:"contract_address"`
+		FunctionSelector string `json:"function_selector"`
+		Parameter        string `json:"parameter"`
+		OwnerAddress     string `json:"owner_address"`
+	}{
+		ContractAddress:  c.encodeAddress(contract),
+		FunctionSelector: shieldedPathSelector,
+		Parameter:        fmt.Sprintf("%064x", position),
+		OwnerAddress:     c.encodeAddress(contract),
+	}
+
+	var response struct {
+		Result []string `json:"constant_result"`
+	}
+	if err := c.post("wallet/triggerconstantcontract", &request, &response); err != nil {
+		return "", "", err
+	}
+
+	// The result is the root followed by the 32 words of the path.
+	if len(response.Result) < 1 || len(response.Result[0]) != 33*64 {
+		return "", "", errors.New("client: malformed shielded path")
+	}
+
+	return response.Result[0][:64], response.Result[0][64:], nil
+}
+
+// ShieldedTRC20Mint creates and signs a transaction which moves an amount of the TRC20 token
+// of a shielded contract from the owner into a note. The note value is the amount divided by
+// the scaling factor of the contract, and the contract must be approved to transfer the
+// amount. The transaction is not broadcast.
+func (c *Client) ShieldedTRC20Mint(owner account.Account, contract address.Address, amount int64, ovk string, receive ShieldedNote, feeLimit uint64) (tron.Transaction, error) {
+	if amount <= 0 {
+		return tron.Transaction{}, fmt.Errorf("client: mint amount must be positive (%d)", amount)
+	}
+
+	var request = struct {
+		Ovk        string            `json:"ovk"`
+		FromAmount string            `json:"from_amount"`
+		Receives   []shieldedReceive `json:"shielded_receives"`
+		Contract   string            `json:"shielded_TRC20_contract_address"`
+	}{
+		Ovk:        ovk,
+		FromAmount: strconv.FormatInt(amount, 10),
+		Receives:   []shieldedReceive{{Note: receive}},
+		Contract:   c.encodeAddress(contract),
+	}
+
+	return c.shieldedTRC20(owner, contract, shieldedMintSelector, &request, feeLimit)
+}
+
+// ShieldedTRC20Transfer creates and signs a transaction which spends one or two notes into one
+// or two new notes of equal total value. The transaction is not broadcast.
+func (c *Client) ShieldedTRC20Transfer(owner account.Account, contract address.Address, keys ShieldedKeys, spends []ShieldedSpend, receives []ShieldedNote, feeLimit uint64) (tron.Transaction, error) {
+	if len(spends) < 1 || len(spends) > 2 || len(receives) < 1 || len(receives) > 2 {
+		return tron.Transaction{}, fmt.Errorf("client: shielded transfer must spend and receive one or two notes (%d, %d)", len(spends), len(receives))
+	}
+
+	var request = struct {
+		ShieldedKeys
+		Spends   []ShieldedSpend   `json:"shielded_spends"`
+		Receives []shieldedReceive `json:"shielded_receives"`
+		Contract string            `json:"shielded_TRC20_contract_address"`
+	}{
+		ShieldedKeys: keys,
+		Spends:       spends,
+		Receives:     toReceives(receives),
+		Contract:     c.encodeAddress(contract),
+	}
+
+	return c.shieldedTRC20(owner, contract, shieldedTransferSelector, &request, feeLimit)
+}
+
+// ShieldedTRC20Burn creates and signs a transaction which spends a note to transfer an amount
+// of the TRC20 token of a shielded contract to a transparent address, returning any change to
+// a new note. The transaction is not broadcast.
+func (c *Client) ShieldedTRC20Burn(owner account.Account, contract address.Address, keys ShieldedKeys, spend ShieldedSpend, to address.Address, amount int64, change *ShieldedNote, feeLimit uint64) (tron.Transaction, error) {
+	if amount <= 0 {
+		return tron.Transaction{}, fmt.Errorf("client: burn amount must be positive (%d)", amount)
+	}
+
+	var receives []ShieldedNote
+	if change != nil {
+		receives = append(receives, *change)
+	}
+
+	var request = struct {
+		ShieldedKeys
+		Spends   []ShieldedSpend   `json:"shielded_spends"`
+		Receives []shieldedReceive `json:"shielded_receives,omitempty"`
+		To       string            `json:"transparent_to_address"`
+		ToAmount string            `json:"to_amount"`
+		Contract string            `json:"shielded_TRC20_contract_address"`
+	}{
+		ShieldedKeys: keys,
+		Spends:       []ShieldedSpend{spend},
+		Receives:     toReceives(receives),
+		To:           c.encodeAddress(to),
+		ToAmount:     strconv.FormatInt(amount, 10),
+		Contract:     c.encodeAddress(contract),
+	}
+
+	return c.shieldedTRC20(owner, contract, shieldedBurnSelector, &request, feeLimit)
+}
+
+// shieldedTRC20 has the node build the parameters of a shielded contract call, including its
+// proofs, and creates and signs a transaction calling the contract with them.
+func (c *Client) shieldedTRC20(owner account.Account, contract address.Address, selector string, request interface{}, feeLimit uint64) (tron.Transaction, error) {
+	var parameters struct {
+		Input string `json:"trigger_contract_input"`
+	}
+	if err := c.post("wallet/createshieldedcontractparameters", request, &parameters); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if _, err := hex.DecodeString(parameters.Input); err != nil || parameters.Input == "" {
+		return tron.Transaction{}, errors.New("client: node returned no shielded contract parameters")
+	}
+
+	var trigger = struct {
+		ContractAddress  string `json:"contract_address"`
+		FunctionSelector string `json:"function_selector"`
+		Parameter        string `json:"parameter"`
+		FeeLimit         uint64 `json:"fee_limit"`
+		OwnerAddress     string `json:"owner_address"`
+	}{
+		ContractAddress:  c.encodeAddress(contract),
+		FunctionSelector: selector,
+		Parameter:        parameters.Input,
+		FeeLimit:         feeLimit,
+		OwnerAddress:     c.encodeAddress(owner.Address()),
+	}
+
+	var response struct {
+		Transaction tron.Transaction `json:"transaction"`
+	}
+	if err := c.post("wallet/triggersmartcontract", &trigger, &response); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	tx := response.Transaction
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := owner.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
+// shieldedValue posts a request to an endpoint which responds with a single value.
+func (c *Client) shieldedValue(endpoint string, request interface{}) (string, error) {
+	var response struct {
+		Value string `json:"value"`
+	}
+	if err := c.post(endpoint, request, &response); err != nil {
+		return "", err
+	}
+
+	return response.Value, nil
+}
+
+func toReceives(notes []ShieldedNote) []shieldedReceive {
+	receives := make([]shieldedReceive, len(notes))
+	for i, note := range notes {
+		receives[i] = shieldedReceive{Note: note}
+	}
+	return receives
+}
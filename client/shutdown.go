@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrShutdown is returned when a transaction is sent, or awaited, after the client has been
+// shut down.
+var ErrShutdown = errors.New("client: shut down")
+
+// inflight tracks the transactions that are being broadcast or awaited so that they can be
+// drained on shutdown, it is shared by copies of a client.
+type inflight struct {
+	mu      sync.Mutex
+	closed  bool
+	pending map[string]int
+	drained chan struct{}
+	abort   chan struct{}
+}
+
+func newInflight() *inflight {
+	return &inflight{
+		pending: make(map[string]int),
+		abort:   make(chan struct{}),
+	}
+}
+
+// begin registers a transaction as in flight, or returns ErrShutdown if the client is no
+// longer accepting sends.
+func (f *inflight) begin(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return ErrShutdown
+	}
+
+	f.pending[id]++
+	return nil
+}
+
+// end marks a transaction that was registered with begin as resolved.
+func (f *inflight) end(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.pending[id]--; f.pending[id] <= 0 {
+		delete(f.pending, id)
+	}
+
+	if f.closed && len(f.pending) == 0 && f.drained != nil {
+		close(f.drained)
+		f.drained = nil
+	}
+}
+
+// ids returns the ids of the transactions still in flight.
+func (f *inflight) ids() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, 0, len(f.pending))
+	for id := range f.pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Shutdown stops the client from accepting new broadcasts and awaits, then waits for those in
+// flight to finish. If the context is done first the remaining awaits are aborted with
+// ErrShutdown, and the ids of the transactions which were unresolved are returned along with
+// the context's error so that the caller can persist them and resume awaiting them later.
+//
+// Shutdown applies to the client and every copy made of it with WithCorrelationId. Queries
+// are unaffected.
+func (c *Client) Shutdown(ctx context.Context) ([]string, error) {
+	f := c.inflight
+
+	f.mu.Lock()
+	f.closed = true
+	if len(f.pending) == 0 {
+		f.mu.Unlock()
+		return nil, nil
+	}
+	drained := f.drained
+	if drained == nil {
+		drained = make(chan struct{})
+		f.drained = drained
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil, nil
+	case <-ctx.Done():
+		ids := f.ids()
+		f.mu.Lock()
+		select {
+		case <-f.abort:
+		default:
+			close(f.abort)
+		}
+		f.mu.Unlock()
+		return ids, ctx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+)
+
+// The methods in this file query the solidity node, which only serves state from blocks that
+// have been solidified and so can no longer be reverted.
+
+// GetAccountSolid returns the confirmed state of an account.
+func (c *Client) GetAccountSolid(addr string) (Getaccount, error) {
+	add, err := address.FromBase58(addr)
+	if err != nil {
+		return Getaccount{}, err
+	}
+
+	var request = struct {
+		Address string `json:"address"`
+	}{
+		Address: c.encodeAddress(add),
+	}
+
+	var acc Getaccount
+	if err := c.post("walletsolidity/getaccount", &request, &acc); err != nil {
+		return Getaccount{}, err
+	}
+
+	return acc, nil
+}
+
+// GetNowBlockSolid returns the latest solidified block.
+func (c *Client) GetNowBlockSolid() (tron.Block, error) {
+	var request = struct{}{}
+
+	var block tron.Block
+	if err := c.post("walletsolidity/getnowblock", &request, &block); err != nil {
+		return tron.Block{}, err
+	}
+
+	if block.Id == "" {
+		return tron.Block{}, errors.New("client: not expecting latest solid block to be nil")
+	}
+
+	if err := c.validateBlock(&block); err != nil {
+		return tron.Block{}, err
+	}
+
+	return block, nil
+}
+
+// GetBlockByHeightSolid returns the solidified block at the specified height, or nil if the
+// block has not been solidified.
+func (c *Client) GetBlockByHeightSolid(n uint64) (*tron.Block, error) {
+	var request = struct {
+		Num uint64 `json:"num"`
+	}{
+		Num: n,
+	}
+
+	var block tron.Block
+	if err := c.post("walletsolidity/getblockbynum", &request, &block); err != nil {
+		return nil, err
+	}
+
+	if block.Id == "" {
+		return nil, nil
+	}
+
+	if err := c.validateBlock(&block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// TransactionInfoByIdSolid returns the information about a processed transaction in a
+// solidified block, or nil if the transaction has not been solidified.
+func (c *Client) TransactionInfoByIdSolid(id string) (*TransactionInfo, error) {
+	return c.transactionInfo("walletsolidity/gettransactioninfobyid", id)
+}
+
+// TransactionByIdSolid returns the transaction for the provided id, or nil if the transaction
+// has not been solidified.
+func (c *Client) TransactionByIdSolid(id string) (*tron.Transaction, error) {
+	var request = struct {
+		Value string `json:"value"`
+	}{
+		Value: id,
+	}
+
+	var tx tron.Transaction
+	if err := c.post("walletsolidity/gettransactionbyid", &request, &tx); err != nil {
+		return nil, err
+	}
+
+	if tx.Id == "" {
+		return nil, nil
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
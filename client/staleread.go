@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/params"
+)
+
+// WithMinHeight fails requests to the full node with a *StaleReadError if the latest block of
+// the node is below a height, such as the height of a block the caller has already processed.
+// The latest block of the node is looked up at most once per block interval, and once more
+// before failing a request, in case the node has caught up since.
+func WithMinHeight(height uint64) Option {
+	return func(c *Client) {
+		if c.heights == nil {
+			c.heights = new(heightGuard)
+		}
+		c.heights.raise(height)
+	}
+}
+
+// WithMonotonicReads is WithMinHeight with the minimum height raised to the highest latest
+// block seen by the client, so that after failing over to a node that is behind, requests
+// fail rather than return state older than has already been read. The heights are shared by
+// copies of the client.
+func WithMonotonicReads() Option {
+	return func(c *Client) {
+		if c.heights == nil {
+			c.heights = new(heightGuard)
+		}
+		c.heights.monotonic = true
+	}
+}
+
+// StaleReadError is returned when the latest block of the node is below the minimum height.
+type StaleReadError struct {
+	Endpoint  string
+	Height    uint64
+	MinHeight uint64
+}
+
+func (e *StaleReadError) Error() string {
+	return fmt.Sprintf("client: node is at height %d, below the minimum height %d for %s",
+		e.Height, e.MinHeight, e.Endpoint)
+}
+
+// heightGuard tracks the minimum height the node must be at, and the height it was last seen
+// at.
+type heightGuard struct {
+	monotonic bool
+
+	mu        sync.Mutex
+	min       uint64
+	height    uint64
+	checkedAt time.Time
+}
+
+// raise raises the minimum height.
+func (g *heightGuard) raise(height uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if height > g.min {
+		g.min = height
+	}
+}
+
+// cached returns the height the node was last seen at and the minimum height, and whether the
+// node was seen within the last block interval.
+func (g *heightGuard) cached() (height, min uint64, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.height, g.min, !g.checkedAt.IsZero() && time.Since(g.checkedAt) < params.BlockInterval
+}
+
+// observe records the height the node was seen at, raising the minimum height to it if reads
+// are monotonic, and returns the minimum height.
+func (g *heightGuard) observe(height uint64) uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.height = height
+	g.checkedAt = time.Now()
+	if g.monotonic && height > g.min {
+		g.min = height
+	}
+	return g.min
+}
+
+// heightCheckKey marks the context of the requests made to check the height of the node, which
+// are not themselves checked.
+type heightCheckKey struct{}
+
+// checkHeight returns a *StaleReadError if the node is below the minimum height.
+func (c *Client) checkHeight(ctx context.Context, endpoint string) error {
+	if c.heights == nil || ctx.Value(heightCheckKey{}) != nil || !strings.HasPrefix(endpoint, "wallet/") {
+		return nil
+	}
+
+	height, min, ok := c.heights.cached()
+	if ok && height >= min {
+		return nil
+	}
+
+	var header tron.BlockHeaderOnly
+	if err := c.postContext(context.WithValue(ctx, heightCheckKey{}, true), "wallet/getnowblock", &struct{}{}, &header); err != nil {
+		return err
+	}
+
+	height = header.BlockHeader.RawData.Number
+	if min = c.heights.observe(height); height < min {
+		return &StaleReadError{Endpoint: endpoint, Height: height, MinHeight: min}
+	}
+
+	return nil
+}
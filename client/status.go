@@ -0,0 +1,63 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron/health"
+)
+
+// Stats are counters describing the requests a client has made. LastError only tracks
+// failures to reach the node, errors which the node reported are counted but do not make the
+// client unhealthy.
+type Stats struct {
+	Requests      uint64    `json:"requests"`
+	Errors        uint64    `json:"errors"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at"`
+	LastSuccessAt time.Time `json:"last_success_at"`
+}
+
+// stats records the outcome of requests, it is shared by copies of a client.
+type stats struct {
+	mu sync.Mutex
+	Stats
+}
+
+func (s *stats) record(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Requests++
+	if err != nil {
+		s.Errors++
+	}
+
+	if _, ok := err.(*RequestError); ok {
+		s.LastError = err.Error()
+		s.LastErrorAt = time.Now()
+		return
+	}
+	s.LastSuccessAt = time.Now()
+}
+
+// Stats returns the request counters of the client.
+func (c *Client) Stats() Stats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	return c.stats.Stats
+}
+
+// Status reports the client as healthy if its most recent request reached the node, so that
+// it can be passed to health.Handler.
+func (c *Client) Status() health.Status {
+	s := c.Stats()
+
+	status := health.Status{Healthy: true, Details: s}
+	if s.LastErrorAt.After(s.LastSuccessAt) {
+		status.Healthy = false
+		status.Message = s.LastError
+	}
+
+	return status
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-chain/go-tron"
+)
+
+// StreamBlockRange calls fn with each block within a height range, end exclusive, as it is
+// decoded from the response. Unlike GetBlockRange the blocks are never all held in memory at
+// once, which matters for ranges of busy blocks. If fn returns an error the stream stops and
+// the error is returned.
+func (c *Client) StreamBlockRange(start, end uint64, fn func(block tron.Block) error) error {
+	return c.streamBlockRange(context.Background(), start, end, fn)
+}
+
+func (c *Client) streamBlockRange(ctx context.Context, start, end uint64, fn func(block tron.Block) error) error {
+	var request = struct {
+		Start uint64 `json:"startNum"`
+		End   uint64 `json:"endNum"`
+	}{
+		Start: start,
+		End:   end,
+	}
+
+	return c.stream(ctx, "wallet/getblockbylimitnext", &request, func(body io.Reader) error {
+		return decodeBlockStream(json.NewDecoder(body), func(block *tron.Block) error {
+			if err := c.validateBlock(block); err != nil {
+				return err
+			}
+			return fn(*block)
+		})
+	})
+}
+
+// decodeBlockStream decodes a response of the form {"block": [...]}, calling fn with each block
+// as it is decoded.
+func decodeBlockStream(dec *json.Decoder, fn func(block *tron.Block) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "block":
+			if err := expectDelim(dec, '['); err != nil {
+				return err
+			}
+
+			for dec.More() {
+				var block tron.Block
+				if err := dec.Decode(&block); err != nil {
+					return err
+				}
+
+				if err := fn(&block); err != nil {
+					return err
+				}
+			}
+
+			if err := expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		case "Error":
+			var msg string
+			if err := dec.Decode(&msg); err != nil {
+				return err
+			}
+			return parseNodeError(msg)
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("client: unexpected token in response (%v)", tok)
+	}
+
+	return nil
+}
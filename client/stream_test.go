@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/txbuilder"
+)
+
+// txsPerBlock and blocksPerStream size the fixtures to resemble busy mainnet blocks.
+const (
+	txsPerBlock     = 200
+	blocksPerStream = 20
+)
+
+// fixtureBlock builds a block of signed transfers, as the node would return it.
+func fixtureBlock(b *testing.B, number uint64) tron.Block {
+	acc, err := account.FromPrivateKeyHex("000000000000000000000000000000000000000000000000000000000000010f")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var ref tron.BlockHeaderOnly
+	ref.Id = strings.Repeat("0", 16) + strings.Repeat("ab", 24)
+	ref.BlockHeader.RawData.Number = number
+	ref.BlockHeader.RawData.Timestamp = 1600000000000
+
+	builder, err := txbuilder.New(ref)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	block := tron.Block{Id: ref.Id, BlockHeader: ref.BlockHeader}
+	for i := 0; i < txsPerBlock; i++ {
+		tx, err := builder.Transfer(acc.Address(), acc.Address(), params.Sun(i+1))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := acc.Sign(&tx); err != nil {
+			b.Fatal(err)
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block
+}
+
+func BenchmarkBlockUnmarshal(b *testing.B) {
+	data, err := json.Marshal(fixtureBlock(b, 1))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var block tron.Block
+		if err := json.Unmarshal(data, &block); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStreamBlockRange measures the throughput of decoding a range of blocks from a
+// node, which bounds how fast a chain can be scanned.
+func BenchmarkStreamBlockRange(b *testing.B) {
+	var response struct {
+		Block []tron.Block `json:"block"`
+	}
+	for i := 0; i < blocksPerStream; i++ {
+		response.Block = append(response.Block, fixtureBlock(b, uint64(i+1)))
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	cli := New(srv.URL)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := cli.StreamBlockRange(1, blocksPerStream+1, func(block tron.Block) error {
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/params"
+)
+
+// maxBlockRange is the most blocks that wallet/getblockbylimitnext returns per request.
+const maxBlockRange = 100
+
+// reorgWindow is the number of delivered blocks remembered for detecting reorgs. Blocks are
+// solidified long before this depth, so no reorg can be deeper.
+const reorgWindow = 64
+
+// Reorg describes a change of the chain's history. Blocks after the common ancestor which
+// were already delivered are no longer part of the chain and should be rolled back, the
+// blocks of the new history are delivered after the reorg.
+type Reorg struct {
+	// OldHead is the last block delivered before the reorg.
+	OldHead BlockRef
+
+	// NewHead is the head of the chain when the reorg was detected.
+	NewHead BlockRef
+
+	// CommonAncestor is the last block which is part of both histories.
+	CommonAncestor BlockRef
+}
+
+// ChainEvent is either the next block of the chain or a reorg, exactly one field is set.
+type ChainEvent struct {
+	Block *tron.Block
+	Reorg *Reorg
+}
+
+// SubscribeBlocks delivers blocks over a channel in order of height, starting with the
+// current head of the chain. It is SubscribeChain without reorg notifications, so after a
+// reorg the blocks following the common ancestor are delivered again.
+func (c *Client) SubscribeBlocks(ctx context.Context) (<-chan tron.Block, error) {
+	events, err := c.SubscribeChain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(chan tron.Block)
+	go func() {
+		defer close(blocks)
+
+		for event := range events {
+			if event.Block == nil {
+				continue
+			}
+
+			select {
+			case blocks <- *event.Block:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return blocks, nil
+}
+
+// SubscribeChain delivers the blocks of the chain over a channel in order of height, starting
+// with the current head of the chain. The node is polled once per block interval, missed
+// blocks are fetched in ranges, and blocks are only fetched as fast as they are received,
+// so a slow receiver falls behind the chain rather than accumulating blocks in memory.
+//
+// Each block is checked against the parent hash of the block before it. On a mismatch a
+// reorg event is delivered, followed by the blocks of the new history from the common
+// ancestor onwards.
+//
+// Errors after the subscription has started are retried on the next poll. The channel is
+// closed once the context is done.
+func (c *Client) SubscribeChain(ctx context.Context) (<-chan ChainEvent, error) {
+	head, err := c.header(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ChainEvent)
+	go func() {
+		defer close(events)
+
+		s := &subscription{
+			c:      c,
+			events: events,
+			next:   head.BlockHeader.RawData.Number,
+			ids:    make(map[uint64]string),
+		}
+
+		for {
+			s.deliver(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(params.BlockInterval):
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// subscription is the state of a chain subscription.
+type subscription struct {
+	c      *Client
+	events chan<- ChainEvent
+
+	// next is the height of the next block to deliver, and ids the ids of the blocks most
+	// recently delivered.
+	next uint64
+	ids  map[uint64]string
+}
+
+var (
+	errBlockGap = errors.New("client: node returned a gap in the block range")
+	errReorg    = errors.New("client: chain reorganized")
+)
+
+// deliver sends every block up to the current head. Delivery stops at the first missing
+// block so that no height is skipped.
+func (s *subscription) deliver(ctx context.Context) error {
+	head, err := s.c.header(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for end := head.BlockHeader.RawData.Number + 1; s.next < end; {
+		limit := s.next + maxBlockRange
+		if limit > end {
+			limit = end
+		}
+
+		err := s.c.streamBlockRange(ctx, s.next, limit, func(block tron.Block) error {
+			return s.deliverBlock(ctx, &block)
+		})
+		if err == errReorg {
+			if err := s.reorg(ctx, head); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// The node returned fewer blocks than requested, try again on the next poll.
+		if s.next < limit {
+			return errBlockGap
+		}
+	}
+
+	return nil
+}
+
+func (s *subscription) deliverBlock(ctx context.Context, block *tron.Block) error {
+	n := block.BlockHeader.RawData.Number
+	if n < s.next {
+		return nil
+	}
+	if n > s.next {
+		return errBlockGap
+	}
+
+	if parent, ok := s.ids[n-1]; ok && parent != block.BlockHeader.RawData.ParentHash {
+		return errReorg
+	}
+
+	if err := s.send(ctx, ChainEvent{Block: block}); err != nil {
+		return err
+	}
+
+	s.ids[n] = block.Id
+	delete(s.ids, n-reorgWindow)
+	s.next++
+	return nil
+}
+
+// reorg finds the common ancestor of the delivered blocks and the node's chain, delivers a
+// reorg event and rewinds the subscription to the block after the ancestor.
+func (s *subscription) reorg(ctx context.Context, head *tron.BlockHeaderOnly) error {
+	last := s.next - 1
+	r := &Reorg{
+		OldHead: BlockRef{Num: last, Id: s.ids[last]},
+		NewHead: BlockRef{Num: head.BlockHeader.RawData.Number, Id: head.Id},
+	}
+
+	n := last
+	for ; ; n-- {
+		id, ok := s.ids[n]
+		if !ok {
+			// The reorg is deeper than the window, which cannot happen to solidified blocks.
+			n++
+			break
+		}
+
+		header, err := s.c.header(ctx, strconv.FormatUint(n, 10))
+		if err != nil {
+			return err
+		}
+
+		if header.Id == id {
+			break
+		}
+	}
+	r.CommonAncestor = BlockRef{Num: n, Id: s.ids[n]}
+
+	if err := s.send(ctx, ChainEvent{Reorg: r}); err != nil {
+		return err
+	}
+
+	for h := n + 1; h <= last; h++ {
+		delete(s.ids, h)
+	}
+	s.next = n + 1
+	return nil
+}
+
+func (s *subscription) send(ctx context.Context, event ChainEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// header returns the header of the block with the provided id or height, or of the head
+// block if idOrNum is empty.
+func (c *Client) header(ctx context.Context, idOrNum string) (*tron.BlockHeaderOnly, error) {
+	var request = struct {
+		IdOrNum string `json:"id_or_num,omitempty"`
+		Detail  bool   `json:"detail"`
+	}{
+		IdOrNum: idOrNum,
+	}
+
+	var header tron.BlockHeaderOnly
+	if err := c.postContext(ctx, "wallet/getblock", &request, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Id == "" && idOrNum == "" {
+		return nil, errors.New("client: node did not return the latest block")
+	}
+	if header.Id == "" {
+		return nil, fmt.Errorf("client: node did not return block %s", idOrNum)
+	}
+
+	return &header, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DialContextFunc opens a connection to an address, as net.Dialer.DialContext does.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// WithHTTPClient sets the HTTP client requests to the node are made with, the default is
+// http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithDialer makes the client open connections to the node with dial, for example to connect
+// through a SOCKS proxy. Other settings of the transport are those of http.DefaultTransport.
+func WithDialer(dial DialContextFunc) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: newTransport(dial)}
+	}
+}
+
+// WithResolver makes the client look up the hosts of nodes with r rather than the resolver of
+// the operating system, for example to resolve them with DNS over HTTPS or a split horizon DNS
+// server.
+func WithResolver(r *net.Resolver) Option {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  r,
+	}
+	return WithDialer(dialer.DialContext)
+}
+
+// newTransport returns a transport with the settings of http.DefaultTransport and a custom
+// dialer.
+func newTransport(dial DialContextFunc) *http.Transport {
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dial,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// Log is an event emitted by a contract while executing a transaction. The address is the
+// 20 byte EVM form of the emitting contract, without the network prefix.
+type Log struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// CallValue is an amount transferred by an internal transaction. TokenId is empty for TRX.
+type CallValue struct {
+	CallValue int64  `json:"callValue"`
+	TokenId   string `json:"tokenId"`
+}
+
+// InternalTransaction is a call or transfer made by a contract while executing a transaction.
+type InternalTransaction struct {
+	Hash          string          `json:"hash"`
+	CallerAddress address.Address `json:"caller_address"`
+	ToAddress     address.Address `json:"transferTo_address"`
+	CallValues    []CallValue     `json:"callValueInfo"`
+	Note          string          `json:"note"`
+	Rejected      bool            `json:"rejected"`
+}
+
+// Logs decodes the events emitted while executing the transaction.
+func (t TransactionInfo) Logs() ([]Log, error) {
+	if t.Log == nil {
+		return nil, nil
+	}
+
+	var logs []Log
+	if err := json.Unmarshal(*t.Log, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// GetTransactionInfoByBlockNum returns the information about every transaction processed in
+// the block at the provided height, including logs and internal transactions.
+func (c *Client) GetTransactionInfoByBlockNum(n uint64) ([]TransactionInfo, error) {
+	var request = struct {
+		Num uint64 `json:"num"`
+	}{
+		Num: n,
+	}
+
+	var infos []TransactionInfo
+	if err := c.post("wallet/gettransactioninfobyblocknum", &request, &infos); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/verify"
+)
+
+// WithSignatureVerification verifies the signatures of every block returned by the node, and of
+// their transactions, with the provided verifier. Blocks are verified one at a time as they are
+// received, with the signatures of each verified in parallel.
+func WithSignatureVerification(v *verify.Verifier) Option {
+	return func(c *Client) {
+		c.verifier = v
+	}
+}
+
+// ValidationError is returned when cross validation is enabled and a block or transaction
+// returned by the node is inconsistent with its raw protobuf payload.
+type ValidationError struct {
+	// Object is either "block" or "transaction".
+	Object string
+
+	// Id is the id of the object as reported by the node.
+	Id string
+
+	// Discrepancies describes each inconsistency that was found.
+	Discrepancies []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("client: %s %s failed cross validation: %s",
+		e.Object, e.Id, strings.Join(e.Discrepancies, "; "))
+}
+
+// validateBlock verifies the signatures of a block if a verifier is set, and cross validates
+// it if cross validation is enabled.
+func (c *Client) validateBlock(block *tron.Block) error {
+	if c.verifier != nil {
+		if err := c.verifier.VerifyBlock(context.Background(), block); err != nil {
+			return err
+		}
+	}
+
+	if !c.crossValidate {
+		return nil
+	}
+	return checkBlock(block)
+}
+
+// validateTransaction cross validates a transaction if cross validation is enabled.
+func (c *Client) validateTransaction(tx *tron.Transaction) error {
+	if !c.crossValidate {
+		return nil
+	}
+	return checkTransaction(tx)
+}
+
+func checkBlock(block *tron.Block) error {
+	id, err := pb.BlockId(block)
+	if err != nil {
+		return &ValidationError{Object: "block", Id: block.Id, Discrepancies: []string{err.Error()}}
+	}
+
+	if id != block.Id {
+		return &ValidationError{
+			Object:        "block",
+			Id:            block.Id,
+			Discrepancies: []string{fmt.Sprintf("id recomputed from header is %s", id)},
+		}
+	}
+
+	for i := range block.Transactions {
+		if err := checkTransaction(&block.Transactions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkTransaction(tx *tron.Transaction) error {
+	var discrepancies []string
+	fail := func(format string, args ...interface{}) {
+		discrepancies = append(discrepancies, fmt.Sprintf(format, args...))
+	}
+
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return &ValidationError{Object: "transaction", Id: tx.Id, Discrepancies: []string{err.Error()}}
+	}
+
+	if id, err := tx.ComputeId(); err != nil || id != tx.Id {
+		fail("id recomputed from raw_data_hex is %s", id)
+	}
+
+	if tx.RawData == nil {
+		fail("raw_data is missing")
+		return &ValidationError{Object: "transaction", Id: tx.Id, Discrepancies: discrepancies}
+	}
+
+	var raw struct {
+		RefBlockBytes string `json:"ref_block_bytes"`
+		RefBlockHash  string `json:"ref_block_hash"`
+		Expiration    int64  `json:"expiration"`
+		Timestamp     int64  `json:"timestamp"`
+		FeeLimit      int64  `json:"fee_limit"`
+		Contracts     []struct {
+			Type      string `json:"type"`
+			Parameter struct {
+				TypeUrl string `json:"type_url"`
+			} `json:"parameter"`
+		} `json:"contract"`
+	}
+	if err := json.Unmarshal(*tx.RawData, &raw); err != nil {
+		fail("raw_data could not be decoded: %v", err)
+		return &ValidationError{Object: "transaction", Id: tx.Id, Discrepancies: discrepancies}
+	}
+
+	if raw.RefBlockBytes != hex.EncodeToString(m.RawData.RefBlockBytes) {
+		fail("ref_block_bytes is %s but raw data has %x", raw.RefBlockBytes, m.RawData.RefBlockBytes)
+	}
+	if raw.RefBlockHash != hex.EncodeToString(m.RawData.RefBlockHash) {
+		fail("ref_block_hash is %s but raw data has %x", raw.RefBlockHash, m.RawData.RefBlockHash)
+	}
+	if raw.Expiration != m.RawData.Expiration {
+		fail("expiration is %d but raw data has %d", raw.Expiration, m.RawData.Expiration)
+	}
+	if raw.Timestamp != m.RawData.Timestamp {
+		fail("timestamp is %d but raw data has %d", raw.Timestamp, m.RawData.Timestamp)
+	}
+	if raw.FeeLimit != m.RawData.FeeLimit {
+		fail("fee_limit is %d but raw data has %d", raw.FeeLimit, m.RawData.FeeLimit)
+	}
+
+	if len(raw.Contracts) != len(m.RawData.Contracts) {
+		fail("has %d contracts but raw data has %d", len(raw.Contracts), len(m.RawData.Contracts))
+	} else {
+		for i, contract := range m.RawData.Contracts {
+			if raw.Contracts[i].Type != contract.Type.String() {
+				fail("contract %d is %s but raw data has %s", i, raw.Contracts[i].Type, contract.Type)
+			}
+			if contract.Parameter != nil && raw.Contracts[i].Parameter.TypeUrl != contract.Parameter.TypeUrl {
+				fail("contract %d has type url %s but raw data has %s", i, raw.Contracts[i].Parameter.TypeUrl, contract.Parameter.TypeUrl)
+			}
+		}
+	}
+
+	if len(discrepancies) > 0 {
+		return &ValidationError{Object: "transaction", Id: tx.Id, Discrepancies: discrepancies}
+	}
+
+	return nil
+}
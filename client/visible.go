@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// WithVisibleAddresses makes the client send addresses in base 58 and request that the node
+// respond with base 58 addresses, by setting the "visible" flag on every request. Fields of
+// responses typed as address.Address decode either encoding, string fields such as
+// Getaccount.Address are left in base 58.
+//
+// Transactions created by the node are broadcast with their own visible flag, and those built
+// offline with txbuilder are broadcast as serialized protobuf through wallet/broadcasthex, so
+// the mode does not affect broadcasting.
+func WithVisibleAddresses() Option {
+	return func(c *Client) {
+		c.visible = true
+	}
+}
+
+// encodeAddress encodes an address for a request, in base 58 if the client uses visible
+// addresses and base 16 otherwise.
+func (c *Client) encodeAddress(addr address.Address) string {
+	if c.visible {
+		return addr.ToBase58()
+	}
+	return addr.ToBase16()
+}
+
+// encodeString encodes a bytes field such as a name for a request, which the node reads as
+// UTF-8 if the client uses visible addresses and as hex otherwise.
+func (c *Client) encodeString(str string) string {
+	if c.visible {
+		return str
+	}
+	return hex.EncodeToString([]byte(str))
+}
+
+// decodeString decodes a bytes field such as a name from a response, which the node writes as
+// UTF-8 if the client uses visible addresses and as hex otherwise.
+func (c *Client) decodeString(str string) (string, error) {
+	if c.visible {
+		return str, nil
+	}
+
+	bs, err := hex.DecodeString(str)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// setVisible adds "visible": true to a JSON object, unless the object already has a visible
+// field.
+func setVisible(bs []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &fields); err != nil {
+		return nil, err
+	}
+
+	if _, ok := fields["visible"]; ok {
+		return bs, nil
+	}
+
+	bs = bytes.TrimSpace(bs)
+	if len(fields) == 0 {
+		return []byte(`{"visible":true}`), nil
+	}
+
+	return append([]byte(`{"visible":true,`), bs[1:]...), nil
+}
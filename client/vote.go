@@ -0,0 +1,85 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/params"
+)
+
+// VoteWitnessAccount creates and signs a transaction which casts the account's votes for
+// witnesses, replacing any votes it previously cast. Each vote is a number of TronPower, the
+// votes must be for distinct witnesses and must not total more than the TronPower of the
+// account. The transaction is broadcast if requested.
+func (c *Client) VoteWitnessAccount(acc account.Account, votes []Vote, broadcast bool) (tron.Transaction, error) {
+	if len(votes) == 0 {
+		return tron.Transaction{}, errors.New("client: no votes provided")
+	}
+
+	if len(votes) > params.MaxVoteWitnesses {
+		return tron.Transaction{}, fmt.Errorf("client: cannot vote for more than %d witnesses (%d)", params.MaxVoteWitnesses, len(votes))
+	}
+
+	type vote struct {
+		Address string `json:"vote_address"`
+		Count   int64  `json:"vote_count"`
+	}
+
+	var total int64
+	seen := make(map[string]bool, len(votes))
+	encoded := make([]vote, 0, len(votes))
+	for _, v := range votes {
+		witness := v.Address.ToBase58()
+		if v.Count <= 0 {
+			return tron.Transaction{}, fmt.Errorf("client: vote count for %s must be positive (%d)", witness, v.Count)
+		}
+
+		if seen[witness] {
+			return tron.Transaction{}, fmt.Errorf("client: witness %s voted for more than once", witness)
+		}
+		seen[witness] = true
+
+		total += v.Count
+		encoded = append(encoded, vote{Address: c.encodeAddress(v.Address), Count: v.Count})
+	}
+
+	resource, err := c.GetAccountResource(acc.Address())
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if total > resource.TronPowerLimit {
+		return tron.Transaction{}, fmt.Errorf("client: votes exceed TronPower of account (%d > %d)", total, resource.TronPowerLimit)
+	}
+
+	var request = struct {
+		Owner string `json:"owner_address"`
+		Votes []vote `json:"votes"`
+	}{
+		Owner: c.encodeAddress(acc.Address()),
+		Votes: encoded,
+	}
+
+	var tx tron.Transaction
+	if err := c.post("wallet/votewitnessaccount", &request, &tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := c.validateTransaction(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := acc.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if broadcast {
+		if err := c.BroadcastTransaction(&tx); err != nil {
+			return tron.Transaction{}, err
+		}
+	}
+
+	return tx, nil
+}
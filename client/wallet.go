@@ -0,0 +1,19 @@
+package client
+
+import (
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Wallet is the set of high level operations that are available over both the HTTP API and
+// the gRPC API, so that either backend can be used interchangeably.
+type Wallet interface {
+	GetBlock(idOrNum string, detail bool) (*tron.Block, error)
+	Transfer(src account.Account, dest address.Address, amount params.Sun) (tron.Transaction, error)
+	TriggerSmartContract(acc account.Account, input CallContractInput) ([]string, error)
+	BroadcastTransaction(tx *tron.Transaction) error
+}
+
+var _ Wallet = (*Client)(nil)
@@ -0,0 +1,56 @@
+package client
+
+import "github.com/go-chain/go-tron/address"
+
+// Witness is a super representative candidate.
+type Witness struct {
+	Address        address.Address `json:"address"`
+	VoteCount      int64           `json:"voteCount"`
+	Url            string          `json:"url"`
+	TotalProduced  int64           `json:"totalProduced"`
+	TotalMissed    int64           `json:"totalMissed"`
+	LatestBlockNum int64           `json:"latestBlockNum"`
+	LatestSlotNum  int64           `json:"latestSlotNum"`
+	IsJobs         bool            `json:"isJobs"`
+}
+
+// Productivity returns the fraction of its scheduled blocks that the witness has produced, or
+// zero if it has never been scheduled.
+func (w Witness) Productivity() float64 {
+	total := w.TotalProduced + w.TotalMissed
+	if total == 0 {
+		return 0
+	}
+	return float64(w.TotalProduced) / float64(total)
+}
+
+// ListWitnesses returns every witness known to the node.
+func (c *Client) ListWitnesses() ([]Witness, error) {
+	var request = struct{}{}
+
+	var response struct {
+		Witnesses []Witness `json:"witnesses"`
+	}
+	if err := c.post("wallet/listwitnesses", &request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Witnesses, nil
+}
+
+// GetWitnessByAddress returns the witness with the provided address, or nil if the address is
+// not a witness. The node has no endpoint for a single witness, so the full list is fetched.
+func (c *Client) GetWitnessByAddress(addr address.Address) (*Witness, error) {
+	witnesses, err := c.ListWitnesses()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range witnesses {
+		if witnesses[i].Address == addr {
+			return &witnesses[i], nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,109 @@
+// Command flow runs a flow defined in YAML, see the flows package. Parameters are given after
+// the flow as name=value, and accounts as -key name=path where the file at path contains the
+// hexadecimal private key of the account. A run which fails or is interrupted is resumed by
+// running it again with the same parameters.
+//
+//	go run ./cmd/flow -node https://api.shasta.trongrid.io -key hot=hot.key sweep.yaml amount=12.5
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/flows"
+)
+
+// keys are the -key flags, each naming an account and the file of its private key.
+type keys map[string]string
+
+func (k keys) String() string {
+	return fmt.Sprint(map[string]string(k))
+}
+
+func (k keys) Set(str string) error {
+	i := strings.IndexByte(str, '=')
+	if i < 0 {
+		return fmt.Errorf("expected name=path, got %s", str)
+	}
+	k[str[:i]] = str[i+1:]
+	return nil
+}
+
+func main() {
+	home, _ := os.UserHomeDir()
+	node := flag.String("node", "http://127.0.0.1:16667", "URL of the node")
+	state := flag.String("state", filepath.Join(home, ".tron", "flows"), "directory of the state of incomplete runs")
+	accountKeys := make(keys)
+	flag.Var(accountKeys, "key", "account to sign with as name=path of its private key, may be repeated")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		log.Fatal("Usage: flow [flags] <flow.yaml> [name=value...]")
+	}
+
+	f, err := flows.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal("Failed to read flow - ", err)
+	}
+
+	params := make(map[string]string)
+	for _, arg := range flag.Args()[1:] {
+		i := strings.IndexByte(arg, '=')
+		if i < 0 {
+			log.Fatalf("Expected parameter as name=value, got %s", arg)
+		}
+		params[arg[:i]] = arg[i+1:]
+	}
+
+	accounts := make(map[string]account.Account, len(accountKeys))
+	for name, path := range accountKeys {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Fatal("Failed to read private key - ", err)
+		}
+
+		acc, err := account.FromPrivateKeyHex(strings.TrimSpace(string(data)))
+		if err != nil {
+			log.Fatal("Failed to parse private key hex - ", err)
+		}
+		accounts[name] = acc
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	confirm := func(step flows.Step, tx *tron.Transaction) (bool, error) {
+		fmt.Printf("Broadcast %s transaction %s of step %s? [y/N] ", step.Action, tx.Id, step.Name)
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return false, err
+		}
+		return strings.EqualFold(strings.TrimSpace(line), "y"), nil
+	}
+
+	runner := flows.New(client.New(*node),
+		flows.WithAccounts(accounts),
+		flows.WithStore(flows.FileStore(*state)),
+		flows.WithConfirm(confirm),
+	)
+
+	result, err := runner.Run(context.Background(), f, params)
+	if result != nil {
+		for _, step := range f.Steps {
+			if st := result.Steps[step.Name]; st != nil {
+				fmt.Printf("%-16s %-10s %v\n", step.Name, st.Status, st.Outputs)
+			}
+		}
+	}
+	if err != nil {
+		log.Fatal("Failed to run flow - ", err)
+	}
+}
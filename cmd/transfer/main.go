@@ -1,9 +1,14 @@
 package main
 
 import (
+	"flag"
+	"log"
+
 	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
 	"github.com/go-chain/go-tron/client"
-	"log"
+	"github.com/go-chain/go-tron/names"
+	"github.com/go-chain/go-tron/params"
 )
 
 const (
@@ -12,6 +17,10 @@ const (
 )
 
 func main() {
+	to := flag.String("to", "", "address or TNS name to transfer to, instead of the destination key")
+	registry := flag.String("tns", "", "address of the TNS registry, to transfer to names")
+	flag.Parse()
+
 	src, err := account.FromPrivateKeyHex(srcPrivKey)
 	if err != nil {
 		log.Fatal("Failed to parse private key hex - ", err)
@@ -24,7 +33,26 @@ func main() {
 
 	cli := client.New("http://127.0.0.1:16667")
 
-	tx, err := cli.Transfer(src, dest.Address(), 100000000000)
+	destAddr := dest.Address()
+	switch {
+	case *to != "" && names.IsName(*to):
+		reg, err := address.Parse(*registry)
+		if err != nil {
+			log.Fatal("Failed to parse TNS registry address - ", err)
+		}
+
+		destAddr, err = names.New(cli, reg).Resolve(*to)
+		if err != nil {
+			log.Fatal("Failed to resolve name - ", err)
+		}
+	case *to != "":
+		destAddr, err = address.Parse(*to)
+		if err != nil {
+			log.Fatal("Failed to parse address - ", err)
+		}
+	}
+
+	tx, err := cli.Transfer(src, destAddr, 100000*params.SunPerTRX)
 	if err != nil {
 		log.Fatal("Failed to transfer tron balance - ", err)
 	}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// feeLimit is the fee limit of contract calls sent from the shell.
+const feeLimit = 100 * params.SunPerTRX
+
+type command struct {
+	usage string
+	run   func(s *session, out io.Writer, args []string) error
+}
+
+var commands map[string]command
+
+// The commands are registered in init, as help refers to them.
+func init() {
+	commands = map[string]command{
+		"help":      {"help", help},
+		"network":   {"network [mainnet|shasta|nile|url]", network},
+		"account":   {"account new <name> | account import <name> <key>", accountCommand},
+		"accounts":  {"accounts", accounts},
+		"bind":      {"bind <name> <address> [abi file]", bind},
+		"contracts": {"contracts", contracts},
+		"methods":   {"methods <contract>", methods},
+		"balance":   {"balance <account|address>", balance},
+		"block":     {"block [height]", block},
+		"transfer":  {"transfer <from> <to> <trx>", transfer},
+		"call":      {"call <contract.method> [args...]", call},
+		"send":      {"send <from> <contract.method> [args...]", send},
+		"history":   {"history", history},
+	}
+}
+
+// execute runs a command line.
+func (s *session) execute(out io.Writer, line string) error {
+	fields := strings.Fields(line)
+
+	cmd, ok := commands[fields[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %s, see help", fields[0])
+	}
+
+	return cmd.run(s, out, fields[1:])
+}
+
+func help(s *session, out io.Writer, args []string) error {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(out, "  exit")
+	return nil
+}
+
+func network(s *session, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(out, s.Network)
+		return nil
+	}
+
+	s.Network = args[0]
+	s.connect()
+	return s.save()
+}
+
+func accountCommand(s *session, out io.Writer, args []string) error {
+	switch {
+	case len(args) == 2 && args[0] == "new":
+		acc := account.NewLocalAccount()
+		s.unlocked[args[1]] = acc
+		s.Accounts[args[1]] = acc.Address().ToBase58()
+		fmt.Fprintf(out, "%s %s\nprivate key %s\n", args[1], acc.Address().ToBase58(), acc.PrivateKey())
+	case len(args) == 3 && args[0] == "import":
+		acc, err := account.FromPrivateKeyHex(args[2])
+		if err != nil {
+			return err
+		}
+		s.unlocked[args[1]] = acc
+		s.Accounts[args[1]] = acc.Address().ToBase58()
+		fmt.Fprintf(out, "%s %s\n", args[1], acc.Address().ToBase58())
+	default:
+		return usage("account")
+	}
+
+	return s.save()
+}
+
+func accounts(s *session, out io.Writer, args []string) error {
+	for _, name := range sortedKeys(s.Accounts) {
+		status := "locked"
+		if _, ok := s.unlocked[name]; ok {
+			status = "unlocked"
+		}
+		fmt.Fprintf(out, "  %-16s %s %s\n", name, s.Accounts[name], status)
+	}
+	return nil
+}
+
+func bind(s *session, out io.Writer, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return usage("bind")
+	}
+
+	addr, err := address.Parse(args[1])
+	if err != nil {
+		return err
+	}
+
+	b := &binding{Address: addr.ToBase58()}
+	if len(args) == 3 {
+		b.ABIFile = args[2]
+	}
+	s.Contracts[args[0]] = b
+
+	a, err := s.contractABI(args[0])
+	if err != nil {
+		delete(s.Contracts, args[0])
+		return err
+	}
+
+	fmt.Fprintf(out, "%s %s, %d methods\n", args[0], b.Address, len(a.Functions))
+	return s.save()
+}
+
+func contracts(s *session, out io.Writer, args []string) error {
+	names := make([]string, 0, len(s.Contracts))
+	for name := range s.Contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-16s %s\n", name, s.Contracts[name].Address)
+	}
+	return nil
+}
+
+func methods(s *session, out io.Writer, args []string) error {
+	if len(args) != 1 {
+		return usage("methods")
+	}
+
+	a, err := s.contractABI(args[0])
+	if err != nil {
+		return err
+	}
+
+	sigs := make([]string, 0, len(a.Functions))
+	for _, fn := range a.Functions {
+		sigs = append(sigs, fmt.Sprintf("%s %s", fn.Signature(), fn.Mutability))
+	}
+	sort.Strings(sigs)
+
+	for _, sig := range sigs {
+		fmt.Fprintf(out, "  %s\n", sig)
+	}
+	return nil
+}
+
+func balance(s *session, out io.Writer, args []string) error {
+	if len(args) != 1 {
+		return usage("balance")
+	}
+
+	addr, err := s.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	state, err := s.client.GetAccountState(addr)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		fmt.Fprintln(out, "account not activated")
+		return nil
+	}
+
+	fmt.Fprintln(out, state.Balance)
+	for id, amount := range state.Assets {
+		fmt.Fprintf(out, "%d of asset %s\n", amount, id)
+	}
+	return nil
+}
+
+func block(s *session, out io.Writer, args []string) error {
+	var header struct {
+		id        string
+		number    uint64
+		timestamp tron.Time
+		txs       int
+	}
+
+	switch len(args) {
+	case 0:
+		b, err := s.client.GetLatestBlock()
+		if err != nil {
+			return err
+		}
+		header.id, header.number, header.timestamp, header.txs = b.Id, b.BlockHeader.RawData.Number, b.BlockHeader.RawData.Timestamp, len(b.Transactions)
+	case 1:
+		n, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		b, err := s.client.GetBlockByHeight(n)
+		if err != nil {
+			return err
+		}
+		header.id, header.number, header.timestamp, header.txs = b.Id, b.BlockHeader.RawData.Number, b.BlockHeader.RawData.Timestamp, len(b.Transactions)
+	default:
+		return usage("block")
+	}
+
+	fmt.Fprintf(out, "block %d %s\ntimestamp %s, %d transactions\n", header.number, header.id, header.timestamp, header.txs)
+	return nil
+}
+
+func transfer(s *session, out io.Writer, args []string) error {
+	if len(args) != 3 {
+		return usage("transfer")
+	}
+
+	from, err := s.account(args[0])
+	if err != nil {
+		return err
+	}
+
+	to, err := s.resolve(args[1])
+	if err != nil {
+		return err
+	}
+
+	amount, err := params.ParseTRX(args[2])
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.client.Transfer(from, to, amount)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.BroadcastTransaction(&tx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "broadcast %s\n", tx.Id)
+	return nil
+}
+
+func call(s *session, out io.Writer, args []string) error {
+	if len(args) < 1 {
+		return usage("call")
+	}
+
+	contract, fn, err := s.function(args[0])
+	if err != nil {
+		return err
+	}
+
+	arguments, err := s.arguments(fn, args[1:])
+	if err != nil {
+		return err
+	}
+
+	results, err := s.client.TriggerSmartContract(nil, client.CallContractInput{
+		Address:   contract,
+		Function:  fn,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return err
+	}
+
+	bs, err := hex.DecodeString(results[0])
+	if err != nil {
+		return err
+	}
+
+	values, err := fn.Decode(bs)
+	if err != nil || len(values) != len(fn.Outputs) {
+		// Only some output types can be decoded, the others are shown raw.
+		fmt.Fprintln(out, results[0])
+		return nil
+	}
+
+	for i, v := range values {
+		fmt.Fprintf(out, "%s %s = %v\n", fn.Outputs[i].Type, fn.Outputs[i].Name, v)
+	}
+	return nil
+}
+
+func send(s *session, out io.Writer, args []string) error {
+	if len(args) < 2 {
+		return usage("send")
+	}
+
+	from, err := s.account(args[0])
+	if err != nil {
+		return err
+	}
+
+	contract, fn, err := s.function(args[1])
+	if err != nil {
+		return err
+	}
+
+	if fn.Immutable() {
+		return fmt.Errorf("%s does not change state, use call", fn.Name)
+	}
+
+	arguments, err := s.arguments(fn, args[2:])
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.client.CallContract(from, client.CallContractInput{
+		Address:   contract,
+		Function:  fn,
+		Arguments: arguments,
+		FeeLimit:  uint64(feeLimit),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.BroadcastTransaction(&tx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "broadcast %s\n", tx.Id)
+	return nil
+}
+
+func history(s *session, out io.Writer, args []string) error {
+	f, err := os.Open(s.historyPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		fmt.Fprintf(out, "%5d  %s\n", n, sc.Text())
+	}
+	return sc.Err()
+}
+
+// arguments parses the arguments of a function call. Addresses may be given as the names of
+// accounts or contracts, integers in decimal or with a 0x prefix in hex and bytes in hex.
+// Arrays cannot be given.
+func (s *session) arguments(fn abi.Function, args []string) ([]interface{}, error) {
+	if len(args) != len(fn.Inputs) {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", fn.Signature(), len(fn.Inputs), len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, in := range fn.Inputs {
+		t := string(in.Type)
+		switch {
+		case strings.HasSuffix(t, "]"):
+			return nil, fmt.Errorf("arguments of type %s are not supported", t)
+		case t == "address":
+			addr, err := s.resolve(args[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = addr
+		case strings.HasPrefix(t, "uint") || strings.HasPrefix(t, "int"):
+			n, ok := new(big.Int).SetString(args[i], 0)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s %s", t, args[i])
+			}
+			values[i] = n
+		case t == "string":
+			values[i] = args[i]
+		case t == "bool":
+			b, err := strconv.ParseBool(args[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool %s", args[i])
+			}
+			values[i] = b
+		case strings.HasPrefix(t, "bytes"):
+			b, err := hex.DecodeString(strings.TrimPrefix(args[i], "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %s", t, args[i])
+			}
+			values[i] = b
+		default:
+			return nil, fmt.Errorf("arguments of type %s are not supported", t)
+		}
+	}
+
+	return values, nil
+}
+
+func usage(name string) error {
+	return fmt.Errorf("usage: %s", commands[name].usage)
+}
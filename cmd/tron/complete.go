@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// complete completes the word before the cursor with the name of a command, as the first word,
+// or otherwise with the name of an account or contract or a method of a bound contract. If
+// several names match, the word is completed to their longest common prefix.
+func (s *session) complete(line string, pos int) (string, int, bool) {
+	start := strings.LastIndexByte(line[:pos], ' ') + 1
+	word := line[start:pos]
+
+	var candidates []string
+	if strings.TrimSpace(line[:start]) == "" {
+		for name := range commands {
+			candidates = append(candidates, name)
+		}
+		candidates = append(candidates, "exit")
+	} else {
+		candidates = s.names()
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+	sort.Strings(matches)
+
+	completion := matches[0]
+	for _, m := range matches[1:] {
+		completion = commonPrefix(completion, m)
+	}
+	if len(matches) == 1 {
+		completion += " "
+	}
+
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+// names returns the names that can be completed after the first word.
+func (s *session) names() []string {
+	var names []string
+	for name := range s.Accounts {
+		names = append(names, name)
+	}
+
+	for name := range s.Contracts {
+		names = append(names, name)
+
+		// ABIs are only completed once loaded, completion does not make requests.
+		if b := s.Contracts[name]; b.abi != nil {
+			// Overloaded methods are completed by signature, others by name.
+			count := make(map[string]int)
+			for _, fn := range b.abi.Functions {
+				count[fn.Name]++
+			}
+			for sig, fn := range b.abi.Functions {
+				if count[fn.Name] > 1 {
+					names = append(names, name+"."+sig)
+				} else {
+					names = append(names, name+"."+fn.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Keys handled by the line editor.
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = 9
+	keyEnter     = 13
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// editor reads lines from a terminal in raw mode, with cursor movement, history recalled with
+// the up and down arrows, and completion with tab.
+type editor struct {
+	in     io.Reader
+	out    io.Writer
+	prompt string
+
+	history  []string
+	complete func(line string, pos int) (string, int, bool)
+}
+
+// readLine reads a line. Ctrl-C discards the line, Ctrl-D on an empty line ends the input with
+// io.EOF.
+func (e *editor) readLine() (string, error) {
+	var line []byte
+	pos := 0
+	recall := len(e.history)
+
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.prompt, line)
+		if back := len(line) - pos; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	redraw()
+
+	var b [1]byte
+	read := func() (byte, error) {
+		_, err := io.ReadFull(e.in, b[:])
+		return b[0], err
+	}
+
+	for {
+		key, err := read()
+		if err != nil {
+			return "", err
+		}
+
+		switch key {
+		case keyEnter, '\n':
+			fmt.Fprint(e.out, "\n")
+			if len(line) > 0 {
+				e.history = append(e.history, string(line))
+			}
+			return string(line), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\n")
+			return "", nil
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+			}
+		case keyTab:
+			if e.complete != nil {
+				if completed, p, ok := e.complete(string(line), pos); ok {
+					line, pos = []byte(completed), p
+				}
+			}
+		case keyEscape:
+			// Arrow keys are sent as escape sequences, ESC [ A to D.
+			if next, err := read(); err != nil || next != '[' {
+				continue
+			}
+			arrow, err := read()
+			if err != nil {
+				return "", err
+			}
+
+			switch arrow {
+			case 'A':
+				if recall > 0 {
+					recall--
+					line = []byte(e.history[recall])
+					pos = len(line)
+				}
+			case 'B':
+				if recall < len(e.history) {
+					recall++
+					line = line[:0]
+					if recall < len(e.history) {
+						line = []byte(e.history[recall])
+					}
+					pos = len(line)
+				}
+			case 'C':
+				if pos < len(line) {
+					pos++
+				}
+			case 'D':
+				if pos > 0 {
+					pos--
+				}
+			}
+		default:
+			if key < ' ' {
+				continue
+			}
+			line = append(line, 0)
+			copy(line[pos+1:], line[pos:])
+			line[pos] = key
+			pos++
+		}
+
+		redraw()
+	}
+}
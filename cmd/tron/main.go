@@ -0,0 +1,132 @@
+// Command tron is an interactive shell for exploring a network, typically a testnet. The
+// selected network, the names of accounts and the contracts bound to names are kept in a
+// session file and restored on the next run, as is the history of commands. Private keys are
+// never written to disk, accounts must be unlocked again in each run.
+//
+//	go run ./cmd/tron
+//	tron> network shasta
+//	tron> account new alice
+//	tron> bind usdt TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs
+//	tron> call usdt.balanceOf alice
+//
+// Methods of bound contracts, accounts and commands are completed with tab.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	home, _ := os.UserHomeDir()
+	dir := flag.String("dir", filepath.Join(home, ".tron"), "directory of the session and history files")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		log.Fatal("Failed to create session directory - ", err)
+	}
+
+	s, err := loadSession(filepath.Join(*dir, "session.json"))
+	if err != nil {
+		log.Fatal("Failed to load session - ", err)
+	}
+
+	history, err := os.OpenFile(filepath.Join(*dir, "history"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		log.Fatal("Failed to open history - ", err)
+	}
+	defer history.Close()
+	s.historyPath = history.Name()
+
+	fd := int(os.Stdin.Fd())
+	if !isTerminal(fd) {
+		// Commands piped from a file or another program are run without line editing.
+		run(s, lineReader(os.Stdin), os.Stdout, history)
+		return
+	}
+
+	e := &editor{
+		in:       os.Stdin,
+		out:      os.Stdout,
+		prompt:   "tron> ",
+		history:  readHistory(history.Name()),
+		complete: s.complete,
+	}
+
+	// The terminal is only in raw mode while a line is read, so that commands write to it
+	// as usual.
+	readLine := func() (string, error) {
+		mode, err := makeRaw(fd)
+		if err != nil {
+			return "", err
+		}
+		defer restore(fd, mode)
+
+		return e.readLine()
+	}
+
+	run(s, readLine, os.Stdout, history)
+}
+
+// run reads and executes commands until the input ends or the shell is exited.
+func run(s *session, readLine func() (string, error), out io.Writer, history io.Writer) {
+	for {
+		line, err := readLine()
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Private keys are never written to disk, so imports are left out of the history.
+		if !strings.HasPrefix(line, "account import") {
+			fmt.Fprintln(history, line)
+		}
+
+		if line == "exit" || line == "quit" {
+			return
+		}
+
+		if err := s.execute(out, line); err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+		}
+	}
+}
+
+// lineReader reads lines from a reader which is not a terminal.
+func lineReader(r io.Reader) func() (string, error) {
+	sc := bufio.NewScanner(r)
+	return func() (string, error) {
+		if sc.Scan() {
+			return sc.Text(), nil
+		}
+		if err := sc.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+}
+
+// readHistory reads the commands of previous runs.
+func readHistory(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var lines []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+// networks are the hosts of the public networks, selected by name.
+var networks = map[string]string{
+	"mainnet": "https://api.trongrid.io",
+	"shasta":  "https://api.shasta.trongrid.io",
+	"nile":    "https://nile.trongrid.io",
+}
+
+// binding is a contract bound to a name. The ABI is read from a file if one was given, and
+// from the node otherwise.
+type binding struct {
+	Address string `json:"address"`
+	ABIFile string `json:"abi_file,omitempty"`
+
+	abi *abi.ABI
+}
+
+// state is the part of a session that is persisted.
+type state struct {
+	Network   string              `json:"network"`
+	Accounts  map[string]string   `json:"accounts"`
+	Contracts map[string]*binding `json:"contracts"`
+}
+
+// session is the state of the shell.
+type session struct {
+	state
+	path        string
+	historyPath string
+
+	client   *client.Client
+	unlocked map[string]*account.LocalAccount
+}
+
+func loadSession(path string) (*session, error) {
+	s := &session{
+		state: state{
+			Network:   "shasta",
+			Accounts:  make(map[string]string),
+			Contracts: make(map[string]*binding),
+		},
+		path:     path,
+		unlocked: make(map[string]*account.LocalAccount),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &s.state); err != nil {
+			return nil, fmt.Errorf("invalid session file %s: %v", path, err)
+		}
+	}
+
+	s.connect()
+	return s, nil
+}
+
+// save writes the persisted state, replacing the session file atomically.
+func (s *session) save() error {
+	data, err := json.MarshalIndent(&s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), "session")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// connect creates the client for the selected network, which is either the name of a public
+// network or the URL of a node.
+func (s *session) connect() {
+	host, ok := networks[s.Network]
+	if !ok {
+		host = s.Network
+	}
+	s.client = client.New(host)
+
+	// ABIs fetched from the previous network no longer apply.
+	for _, b := range s.Contracts {
+		if b.ABIFile == "" {
+			b.abi = nil
+		}
+	}
+}
+
+// resolve parses an address, or the address of a named account or contract.
+func (s *session) resolve(str string) (address.Address, error) {
+	if addr, ok := s.Accounts[str]; ok {
+		return address.Parse(addr)
+	}
+	if b, ok := s.Contracts[str]; ok {
+		return address.Parse(b.Address)
+	}
+	return address.Parse(str)
+}
+
+// account returns an unlocked account by name.
+func (s *session) account(name string) (*account.LocalAccount, error) {
+	acc, ok := s.unlocked[name]
+	if !ok {
+		if _, known := s.Accounts[name]; known {
+			return nil, fmt.Errorf("account %s is locked, unlock it with: account import %s <key>", name, name)
+		}
+		return nil, fmt.Errorf("unknown account %s", name)
+	}
+	return acc, nil
+}
+
+// contractABI returns the ABI of a bound contract, loading it the first time.
+func (s *session) contractABI(name string) (*abi.ABI, error) {
+	b, ok := s.Contracts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown contract %s", name)
+	}
+
+	if b.abi != nil {
+		return b.abi, nil
+	}
+
+	if b.ABIFile != "" {
+		data, err := ioutil.ReadFile(b.ABIFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var a abi.ABI
+		if err := json.Unmarshal(data, &a); err != nil {
+			return nil, err
+		}
+		b.abi = &a
+		return b.abi, nil
+	}
+
+	addr, err := address.Parse(b.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := s.client.GetContractABI(addr)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, fmt.Errorf("no contract at %s on %s", b.Address, s.Network)
+	}
+
+	b.abi = a
+	return b.abi, nil
+}
+
+// function returns a function of a bound contract, named contract.method.
+func (s *session) function(ref string) (address.Address, abi.Function, error) {
+	i := strings.IndexByte(ref, '.')
+	if i < 0 {
+		return address.Zero, abi.Function{}, fmt.Errorf("expected contract.method, got %s", ref)
+	}
+
+	a, err := s.contractABI(ref[:i])
+	if err != nil {
+		return address.Zero, abi.Function{}, err
+	}
+
+	fn, err := a.Function(ref[i+1:])
+	if err != nil {
+		return address.Zero, abi.Function{}, fmt.Errorf("contract %s: %v", ref[:i], err)
+	}
+
+	addr, err := address.Parse(s.Contracts[ref[:i]].Address)
+	return addr, fn, err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,22 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package main
+
+import "errors"
+
+// termios is a placeholder for the mode of a terminal on platforms without line editing.
+type termios struct{}
+
+func makeRaw(fd int) (*termios, error) {
+	return nil, errors.New("line editing is not supported on this platform")
+}
+
+func restore(fd int, mode *termios) error {
+	return nil
+}
+
+// isTerminal reports false so that input is read a line at a time without editing.
+func isTerminal(fd int) bool {
+	return false
+}
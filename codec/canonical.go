@@ -0,0 +1,201 @@
+package codec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Canonical encodes values as canonical JSON, so that equal values always encode to the same
+// bytes and can be hashed and compared, regardless of field order or the version of the
+// library that encoded them:
+//
+//   - object keys are sorted and no insignificant whitespace is written
+//   - integers are written without exponents or fractions, other numbers in their shortest
+//     form
+//   - strings are escaped minimally, without escaping HTML characters
+//
+// Values are first encoded with encoding/json, so their JSON tags and marshalers apply.
+type Canonical struct {
+	// RedactSignatures replaces the value of every "signature" and "witness_signature" field,
+	// or each element if it is an array, with "redacted". Entries for signed and unsigned
+	// transactions then hash the same, and signatures are kept out of logs.
+	RedactSignatures bool
+}
+
+// redacted replaces each redacted signature.
+const redacted = "redacted"
+
+func (c Canonical) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := c.write(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (Canonical) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (Canonical) Name() string {
+	return "canonical-json"
+}
+
+// Hash returns the SHA-256 digest of the canonical encoding of a value.
+func (c Canonical) Hash(v interface{}) ([32]byte, error) {
+	data, err := c.Marshal(v)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+func (c Canonical) write(buf *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case json.Number:
+		n, err := canonicalNumber(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case string:
+		writeString(buf, value)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, v := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := c.write(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, k)
+			buf.WriteByte(':')
+
+			v := value[k]
+			if c.RedactSignatures && (k == "signature" || k == "witness_signature") {
+				v = redact(v)
+			}
+
+			if err := c.write(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("codec: cannot canonicalize %T", value)
+	}
+
+	return nil
+}
+
+// redact replaces a signature, or each signature of an array.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i := range v {
+			out[i] = redacted
+		}
+		return out
+	default:
+		return redacted
+	}
+}
+
+// canonicalNumber formats a number so that equal numbers are written identically. Integers are
+// kept exact, as amounts of sun exceed the precision of a float.
+func canonicalNumber(n json.Number) (string, error) {
+	str := n.String()
+	if !strings.ContainsAny(str, ".eE") {
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			return strconv.FormatInt(i, 10), nil
+		}
+		// Integers beyond 64 bits are written as they were encoded.
+		return str, nil
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return "", err
+	}
+
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return strconv.FormatInt(int64(f), 10), nil
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64), nil
+}
+
+// writeString writes a JSON string, escaping only quotes, backslashes and control characters.
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch {
+		case b == '"' || b == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(b)
+		case b == '\n':
+			buf.WriteString(`\n`)
+		case b == '\r':
+			buf.WriteString(`\r`)
+		case b == '\t':
+			buf.WriteString(`\t`)
+		case b == '\b':
+			buf.WriteString(`\b`)
+		case b == '\f':
+			buf.WriteString(`\f`)
+		case b < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[b>>4])
+			buf.WriteByte(hex[b&0xf])
+		default:
+			buf.WriteByte(b)
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,91 @@
+// Package codec provides the wire formats that persistence and transport layers can choose
+// between when serializing records.
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/go-chain/go-tron/pb"
+)
+
+// Codec serializes values to and from a wire format. The method set matches the codecs of
+// gRPC so that implementations can be used there directly.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+
+	// Name identifies the format, it is stored alongside persisted data so that the data can
+	// be decoded after the configured codec has changed.
+	Name() string
+}
+
+// JSON encodes values with encoding/json. Any value can be encoded.
+type JSON struct{}
+
+func (JSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSON) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (JSON) Name() string {
+	return "json"
+}
+
+// Proto encodes values with the protobuf encoding of the pb package. Only values which
+// implement pb.Message can be encoded, in exchange the encoding is much more compact and
+// faster to produce than JSON.
+type Proto struct{}
+
+func (Proto) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(pb.Message)
+	if !ok {
+		return nil, fmt.Errorf("codec: cannot marshal %T as proto", v)
+	}
+	return m.Marshal(), nil
+}
+
+func (Proto) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(pb.Message)
+	if !ok {
+		return fmt.Errorf("codec: cannot unmarshal %T as proto", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func (Proto) Name() string {
+	return "proto"
+}
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]Codec{
+		JSON{}.Name():      JSON{},
+		Proto{}.Name():     Proto{},
+		Canonical{}.Name(): Canonical{},
+	}
+)
+
+// Register makes a codec available by its name, replacing any codec of the same name. This
+// allows other formats, such as CBOR, to be provided without this package depending on them.
+func Register(c Codec) {
+	mu.Lock()
+	defer mu.Unlock()
+	codecs[c.Name()] = c
+}
+
+// ByName returns the registered codec with the provided name.
+func ByName(name string) (Codec, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	c, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("codec: unknown codec %q", name)
+	}
+	return c, nil
+}
@@ -0,0 +1,203 @@
+// Package energy reports who pays for the energy used by calls of a contract: the origin of the
+// contract, or the callers from their stake or by burning TRX. Contract owners can use the
+// report to tune the share of energy which callers pay, consume_user_resource_percent, and
+// the most energy the origin pays for in a call, origin_energy_limit.
+package energy
+
+import (
+	"sort"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// maxRange is the most blocks fetched in a single request while scanning.
+const maxRange = 100
+
+// Usage is the energy used by the calls of a contract made by a caller.
+type Usage struct {
+	Caller address.Address
+	Calls  int
+
+	// Origin is the energy paid for by the origin of the contract, Staked the energy paid for
+	// from the stake of the caller and Burned the energy paid for by the caller burning Fee.
+	Origin int64
+	Staked int64
+	Burned int64
+	Fee    params.Sun
+}
+
+// Total returns the total energy used.
+func (u Usage) Total() int64 {
+	return u.Origin + u.Staked + u.Burned
+}
+
+// OriginShare returns the fraction of the energy paid for by the origin, zero if no energy was
+// used.
+func (u Usage) OriginShare() float64 {
+	if u.Total() == 0 {
+		return 0
+	}
+	return float64(u.Origin) / float64(u.Total())
+}
+
+func (u *Usage) add(receipt client.TransactionReceipt) {
+	u.Calls++
+	u.Origin += int64(receipt.OriginEnergyUsage)
+	u.Staked += int64(receipt.EnergyUsage)
+	u.Fee += params.Sun(receipt.EnergyFee)
+
+	// The energy paid for by burning is the remainder of the total, rather than derived from
+	// the fee, because the price of energy changes over time.
+	if burned := int64(receipt.EnergyUsageTotal) - int64(receipt.OriginEnergyUsage) - int64(receipt.EnergyUsage); burned > 0 {
+		u.Burned += burned
+	}
+}
+
+// Report is the energy used by the calls of a contract, by caller.
+type Report struct {
+	Contract address.Address
+
+	// Start and End are the range of heights of the blocks scanned, end exclusive.
+	Start, End uint64
+
+	callers map[address.Address]*Usage
+}
+
+// NewReport creates an empty report for a contract.
+func NewReport(contract address.Address) *Report {
+	return &Report{
+		Contract: contract,
+		callers:  make(map[address.Address]*Usage),
+	}
+}
+
+// Add adds the receipt of a transaction to the report, if it is a call of the contract. The
+// caller is the owner of the call, not the contract calling it for internal transactions.
+func (r *Report) Add(tx *tron.Transaction, info client.TransactionInfo) error {
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := m.RawData.UnpackContracts()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		trigger, ok := msg.(*pb.TriggerSmartContract)
+		if !ok || len(trigger.OwnerAddress) != params.AddressLength {
+			continue
+		}
+
+		var contract, caller address.Address
+		copy(contract[:], trigger.ContractAddress)
+		copy(caller[:], trigger.OwnerAddress)
+		if contract != r.Contract {
+			continue
+		}
+
+		u, ok := r.callers[caller]
+		if !ok {
+			u = &Usage{Caller: caller}
+			r.callers[caller] = u
+		}
+		u.add(info.Receipt)
+	}
+
+	return nil
+}
+
+// Usages returns the usage of each caller, those which used the most energy first.
+func (r *Report) Usages() []Usage {
+	usages := make([]Usage, 0, len(r.callers))
+	for _, u := range r.callers {
+		usages = append(usages, *u)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if a, b := usages[i].Total(), usages[j].Total(); a != b {
+			return a > b
+		}
+		return usages[i].Caller.ToBase58() < usages[j].Caller.ToBase58()
+	})
+
+	return usages
+}
+
+// Total returns the usage of every caller combined, with a zero caller.
+func (r *Report) Total() Usage {
+	var total Usage
+	for _, u := range r.callers {
+		total.Calls += u.Calls
+		total.Origin += u.Origin
+		total.Staked += u.Staked
+		total.Burned += u.Burned
+		total.Fee += u.Fee
+	}
+	return total
+}
+
+// Scan reports the energy used by the calls of a contract in the blocks within a range of
+// heights, end exclusive.
+func Scan(c *client.Client, contract address.Address, start, end uint64) (*Report, error) {
+	r := NewReport(contract)
+	r.Start, r.End = start, end
+
+	for from := start; from < end; from += maxRange {
+		to := from + maxRange
+		if to > end {
+			to = end
+		}
+
+		blocks, err := c.GetBlockRange(from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			if err := r.addBlock(c, &block); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// addBlock adds the calls of the contract in a block to the report.
+func (r *Report) addBlock(c *client.Client, block *tron.Block) error {
+	if len(block.Transactions) == 0 {
+		return nil
+	}
+
+	infos, err := c.GetTransactionInfoByBlockNum(block.BlockHeader.RawData.Number)
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[string]client.TransactionInfo, len(infos))
+	for _, info := range infos {
+		// Only calls of the contract are of interest, which are recorded against it.
+		if info.ContractAddress == r.Contract {
+			byId[info.Id] = info
+		}
+	}
+
+	for i := range block.Transactions {
+		info, ok := byId[block.Transactions[i].Id]
+		if !ok {
+			continue
+		}
+
+		if err := r.Add(&block.Transactions[i], info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,499 @@
+// Package fakenode serves a fake Tron node over HTTP, so that code built on the client can be
+// exercised without a network. It models the bandwidth and energy accounting of accounts, so
+// that paths such as burning TRX for bandwidth or running out of energy can be reached
+// deterministically.
+//
+// Only the endpoints needed to create, broadcast and await transfers and contract calls are
+// served. Blocks are not cross validated and transfers to new addresses are not charged the
+// account creation fee.
+package fakenode
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/txbuilder"
+)
+
+// Default prices of resources, in sun per unit.
+const (
+	DefaultEnergyFee      = 420
+	DefaultTransactionFee = 1000
+)
+
+// Account is the balance and resources of an account.
+type Account struct {
+	Balance params.Sun
+
+	FreeNetLimit int64
+	FreeNetUsed  int64
+	NetLimit     int64
+	NetUsed      int64
+
+	EnergyLimit int64
+	EnergyUsed  int64
+}
+
+// Node is a fake node. A node is safe for concurrent use.
+type Node struct {
+	server *httptest.Server
+
+	mu             sync.Mutex
+	accounts       map[address.Address]*Account
+	energy         map[address.Address]int64
+	infos          map[string]map[string]interface{}
+	energyFee      int64
+	transactionFee int64
+	height         uint64
+}
+
+// Option configures optional behaviour of a node.
+type Option func(*Node)
+
+// WithEnergyFee sets the price of energy in sun.
+func WithEnergyFee(sun int64) Option {
+	return func(n *Node) {
+		n.energyFee = sun
+	}
+}
+
+// WithTransactionFee sets the price of bandwidth in sun.
+func WithTransactionFee(sun int64) Option {
+	return func(n *Node) {
+		n.transactionFee = sun
+	}
+}
+
+// New starts a fake node, which must be closed once finished with.
+func New(opts ...Option) *Node {
+	n := &Node{
+		accounts:       make(map[address.Address]*Account),
+		energy:         make(map[address.Address]int64),
+		infos:          make(map[string]map[string]interface{}),
+		energyFee:      DefaultEnergyFee,
+		transactionFee: DefaultTransactionFee,
+		height:         1,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.server = httptest.NewServer(http.HandlerFunc(n.serve))
+
+	return n
+}
+
+// URL returns the URL of the node, to create a client with.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Close stops the node.
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// SetAccount creates or replaces an account.
+func (n *Node) SetAccount(addr address.Address, acc Account) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.accounts[addr] = &acc
+}
+
+// Account returns an account, reporting false if it does not exist.
+func (n *Node) Account(addr address.Address) (Account, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	acc, ok := n.accounts[addr]
+	if !ok {
+		return Account{}, false
+	}
+	return *acc, true
+}
+
+// SetContractEnergy sets the energy that every call of a contract consumes. Calls of contracts
+// without energy set consume none.
+func (n *Node) SetContractEnergy(contract address.Address, energy int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.energy[contract] = energy
+}
+
+func (n *Node) serve(w http.ResponseWriter, r *http.Request) {
+	var request map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		request = make(map[string]interface{})
+	}
+
+	n.mu.Lock()
+	response, err := n.handle(strings.TrimPrefix(r.URL.Path, "/"), request)
+	n.mu.Unlock()
+
+	if err != nil {
+		response = map[string]interface{}{"Error": err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (n *Node) handle(endpoint string, request map[string]interface{}) (interface{}, error) {
+	switch endpoint {
+	case "wallet/getnowblock", "walletsolidity/getnowblock":
+		return n.block(), nil
+	case "wallet/getchainparameters":
+		return map[string]interface{}{
+			"chainParameter": []map[string]interface{}{
+				{"key": "getEnergyFee", "value": n.energyFee},
+				{"key": "getTransactionFee", "value": n.transactionFee},
+			},
+		}, nil
+	case "wallet/getaccount":
+		return n.getAccount(request)
+	case "wallet/getaccountresource":
+		return n.getAccountResource(request)
+	case "wallet/createtransaction":
+		return n.createTransaction(request)
+	case "wallet/triggersmartcontract":
+		return n.triggerSmartContract(request)
+	case "wallet/triggerconstantcontract":
+		return n.triggerConstantContract(request)
+	case "wallet/broadcasttransaction":
+		return n.broadcastTransaction(request)
+	case "wallet/broadcasthex":
+		return n.broadcastHex(request)
+	case "wallet/gettransactioninfobyid", "walletsolidity/gettransactioninfobyid":
+		if info, ok := n.infos[str(request, "value")]; ok {
+			return info, nil
+		}
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("fakenode: endpoint %s is not supported", endpoint)
+	}
+}
+
+// block returns the latest block, whose id is derived from its height.
+func (n *Node) block() tron.Block {
+	var block tron.Block
+	block.Id = fmt.Sprintf("%016x%048x", n.height, n.height)
+	block.BlockHeader.RawData.Number = n.height
+	return block
+}
+
+func (n *Node) getAccount(request map[string]interface{}) (interface{}, error) {
+	addr, err := address.Parse(str(request, "address"))
+	if err != nil {
+		return nil, err
+	}
+
+	acc, ok := n.accounts[addr]
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+
+	return map[string]interface{}{
+		"address": addr.ToBase16(),
+		"balance": acc.Balance,
+	}, nil
+}
+
+func (n *Node) getAccountResource(request map[string]interface{}) (interface{}, error) {
+	addr, err := address.Parse(str(request, "address"))
+	if err != nil {
+		return nil, err
+	}
+
+	acc, ok := n.accounts[addr]
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+
+	return map[string]interface{}{
+		"freeNetUsed":  acc.FreeNetUsed,
+		"freeNetLimit": acc.FreeNetLimit,
+		"NetUsed":      acc.NetUsed,
+		"NetLimit":     acc.NetLimit,
+		"EnergyUsed":   acc.EnergyUsed,
+		"EnergyLimit":  acc.EnergyLimit,
+	}, nil
+}
+
+func (n *Node) builder(feeLimit int64) (*txbuilder.Builder, error) {
+	block := n.block()
+	b, err := txbuilder.New(block.HeaderOnly())
+	if err != nil {
+		return nil, err
+	}
+	return b.FeeLimit(feeLimit), nil
+}
+
+func (n *Node) createTransaction(request map[string]interface{}) (interface{}, error) {
+	owner, err := address.Parse(str(request, "owner_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := address.Parse(str(request, "to_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := n.builder(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Transfer(owner, to, params.Sun(integer(request, "amount")))
+}
+
+func (n *Node) triggerSmartContract(request map[string]interface{}) (interface{}, error) {
+	owner, contract, data, err := call(request)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := n.builder(integer(request, "fee_limit"))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := b.TriggerSmartContract(owner, contract, data, integer(request, "call_value"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"result":      map[string]interface{}{"result": true},
+		"transaction": tx,
+	}, nil
+}
+
+func (n *Node) triggerConstantContract(request map[string]interface{}) (interface{}, error) {
+	_, contract, _, err := call(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"result":          map[string]interface{}{"result": true},
+		"energy_used":     n.energy[contract],
+		"constant_result": []string{strings.Repeat("0", 64)},
+	}, nil
+}
+
+// broadcast charges a transaction to its owner and records its outcome. Bandwidth is taken
+// from staked bandwidth, then free bandwidth, then burnt. Energy is taken from staked energy
+// and the remainder burnt, up to the fee limit of the transaction.
+func (n *Node) broadcastTransaction(request map[string]interface{}) (interface{}, error) {
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx tron.Transaction
+	if err := json.Unmarshal(bs, &tx); err != nil {
+		return nil, err
+	}
+
+	return n.broadcast(tx)
+}
+
+func (n *Node) broadcastHex(request map[string]interface{}) (interface{}, error) {
+	bs, err := hex.DecodeString(str(request, "transaction"))
+	if err != nil {
+		return nil, err
+	}
+
+	var m pb.Transaction
+	if err := m.Unmarshal(bs); err != nil {
+		return nil, err
+	}
+
+	tx, err := pb.ToTransaction(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.broadcast(tx)
+}
+
+func (n *Node) broadcast(tx tron.Transaction) (interface{}, error) {
+	fail := func(code, message string) (interface{}, error) {
+		return map[string]interface{}{
+			"result":  false,
+			"code":    code,
+			"message": hex.EncodeToString([]byte(message)),
+			"txid":    tx.Id,
+		}, nil
+	}
+
+	if _, ok := n.infos[tx.Id]; ok {
+		return fail("DUP_TRANSACTION_ERROR", "dup transaction")
+	}
+
+	if len(tx.Signatures) == 0 {
+		return fail("SIGERROR", "transaction is not signed")
+	}
+
+	m, err := pb.FromTransaction(&tx)
+	if err != nil {
+		return fail("OTHER_ERROR", err.Error())
+	}
+
+	if len(m.RawData.Contracts) != 1 {
+		return fail("CONTRACT_VALIDATE_ERROR", "transaction must have one contract")
+	}
+
+	msg, err := m.RawData.Contracts[0].Unpack()
+	if err != nil {
+		return fail("CONTRACT_VALIDATE_ERROR", err.Error())
+	}
+
+	var owner, contract, to address.Address
+	var amount int64
+	switch msg := msg.(type) {
+	case *pb.TransferContract:
+		copy(owner[:], msg.OwnerAddress)
+		copy(to[:], msg.ToAddress)
+		amount = msg.Amount
+	case *pb.TriggerSmartContract:
+		copy(owner[:], msg.OwnerAddress)
+		copy(contract[:], msg.ContractAddress)
+	default:
+		return fail("CONTRACT_VALIDATE_ERROR", "contract type is not supported")
+	}
+
+	acc, ok := n.accounts[owner]
+	if !ok {
+		return fail("CONTRACT_VALIDATE_ERROR", "account does not exist")
+	}
+
+	if params.Sun(amount) > acc.Balance {
+		return fail("CONTRACT_VALIDATE_ERROR", "balance is not sufficient")
+	}
+
+	bandwidth, err := pb.Bandwidth(&tx, len(tx.Signatures))
+	if err != nil {
+		return fail("OTHER_ERROR", err.Error())
+	}
+
+	var netUsage, netFee int64
+	switch {
+	case acc.NetLimit-acc.NetUsed >= bandwidth:
+		acc.NetUsed += bandwidth
+		netUsage = bandwidth
+	case acc.FreeNetLimit-acc.FreeNetUsed >= bandwidth:
+		acc.FreeNetUsed += bandwidth
+		netUsage = bandwidth
+	default:
+		netFee = bandwidth * n.transactionFee
+		if params.Sun(netFee+amount) > acc.Balance {
+			return fail("BANDWITH_ERROR", "account has insufficient bandwidth and balance to create transaction")
+		}
+	}
+	acc.Balance -= params.Sun(netFee)
+
+	result := "SUCCESS"
+	var energyUsage, energyFee int64
+	if _, ok := msg.(*pb.TriggerSmartContract); ok {
+		energyUsage = n.energy[contract]
+
+		staked := acc.EnergyLimit - acc.EnergyUsed
+		if staked > energyUsage {
+			staked = energyUsage
+		}
+		acc.EnergyUsed += staked
+
+		energyFee = (energyUsage - staked) * n.energyFee
+
+		limit := m.RawData.FeeLimit
+		if int64(acc.Balance) < limit {
+			limit = int64(acc.Balance)
+		}
+
+		// Execution stops once the fee limit is reached, which burns the whole limit.
+		if energyFee > limit {
+			result = "OUT_OF_ENERGY"
+			energyFee = limit
+			energyUsage = staked + limit/n.energyFee
+		}
+		acc.Balance -= params.Sun(energyFee)
+	}
+
+	if result == "SUCCESS" && amount > 0 {
+		acc.Balance -= params.Sun(amount)
+
+		dest, ok := n.accounts[to]
+		if !ok {
+			dest = new(Account)
+			n.accounts[to] = dest
+		}
+		dest.Balance += params.Sun(amount)
+	}
+
+	n.height++
+
+	info := map[string]interface{}{
+		"id":             tx.Id,
+		"fee":            netFee + energyFee,
+		"blockNumber":    n.height,
+		"blockTimestamp": n.height * uint64(params.BlockInterval/time.Millisecond),
+		"receipt": map[string]interface{}{
+			"energy_fee":         energyFee,
+			"energy_usage_total": energyUsage,
+			"net_fee":            netFee,
+			"net_usage":          netUsage,
+			"result":             result,
+		},
+	}
+	if result != "SUCCESS" {
+		info["result"] = "FAILED"
+	}
+	n.infos[tx.Id] = info
+
+	return map[string]interface{}{"result": true, "txid": tx.Id}, nil
+}
+
+// call decodes the owner, contract and call data of a contract call request.
+func call(request map[string]interface{}) (owner, contract address.Address, data []byte, err error) {
+	if owner, err = address.Parse(str(request, "owner_address")); err != nil {
+		return
+	}
+
+	if contract, err = address.Parse(str(request, "contract_address")); err != nil {
+		return
+	}
+
+	parameter, err := hex.DecodeString(str(request, "parameter"))
+	if err != nil {
+		return
+	}
+
+	data = append(crypto.Keccak256([]byte(str(request, "function_selector")))[:4], parameter...)
+	return
+}
+
+func str(request map[string]interface{}, key string) string {
+	s, _ := request[key].(string)
+	return s
+}
+
+func integer(request map[string]interface{}, key string) int64 {
+	f, _ := request[key].(float64)
+	return int64(f)
+}
@@ -0,0 +1,133 @@
+package fakenode_test
+
+import (
+	"testing"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/fakenode"
+	"github.com/go-chain/go-tron/params"
+)
+
+func mustAccount(t *testing.T, key string) *account.LocalAccount {
+	acc, err := account.FromPrivateKeyHex(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
+
+// broadcast broadcasts a transaction and returns its info.
+func broadcast(t *testing.T, cli *client.Client, tx tron.Transaction) *client.TransactionInfo {
+	if err := cli.BroadcastTransaction(&tx); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := cli.TransactionInfoById(tx.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info == nil {
+		t.Fatalf("transaction %s has no info", tx.Id)
+	}
+	return info
+}
+
+func TestTransferBandwidth(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	cli := client.New(node.URL())
+
+	staked := mustAccount(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	burner := mustAccount(t, "0000000000000000000000000000000000000000000000000000000000000002")
+	dest := mustAccount(t, "0000000000000000000000000000000000000000000000000000000000000003").Address()
+
+	node.SetAccount(staked.Address(), fakenode.Account{Balance: params.SunPerTRX, FreeNetLimit: 600})
+	node.SetAccount(burner.Address(), fakenode.Account{Balance: params.SunPerTRX})
+
+	tx, err := cli.Transfer(staked, dest, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := broadcast(t, cli, tx)
+
+	if info.Receipt.NetFee != 0 || info.Receipt.NetUsage == 0 {
+		t.Fatalf("transfer with free bandwidth burned %d sun for %d bandwidth", info.Receipt.NetFee, info.Receipt.NetUsage)
+	}
+
+	acc, _ := node.Account(staked.Address())
+	if acc.Balance != params.SunPerTRX-1000 || acc.FreeNetUsed != int64(info.Receipt.NetUsage) {
+		t.Fatalf("account after transfer is %+v", acc)
+	}
+
+	tx, err = cli.Transfer(burner, dest, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info = broadcast(t, cli, tx)
+
+	if info.Receipt.NetFee == 0 || info.Receipt.NetFee%fakenode.DefaultTransactionFee != 0 {
+		t.Fatalf("transfer without bandwidth burned %d sun", info.Receipt.NetFee)
+	}
+
+	acc, _ = node.Account(burner.Address())
+	if want := params.SunPerTRX - 1000 - params.Sun(info.Receipt.NetFee); acc.Balance != want {
+		t.Fatalf("balance after transfer is %d, want %d", acc.Balance, want)
+	}
+
+	acc, _ = node.Account(dest)
+	if acc.Balance != 2000 {
+		t.Fatalf("destination balance is %d, want 2000", acc.Balance)
+	}
+}
+
+func TestOutOfEnergy(t *testing.T) {
+	node := fakenode.New()
+	defer node.Close()
+	cli := client.New(node.URL())
+
+	caller := mustAccount(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	contract, err := address.FromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node.SetAccount(caller.Address(), fakenode.Account{Balance: 100 * params.SunPerTRX, FreeNetLimit: 5000, EnergyLimit: 1000})
+	node.SetContractEnergy(contract, 100000)
+
+	input := client.CallContractInput{
+		Address: contract,
+		Function: abi.Function{
+			Name:   "transfer",
+			Inputs: []abi.Value{{Type: "address"}, {Type: abi.TypeUint256}},
+		},
+		Arguments: []interface{}{caller.Address(), uint64(1)},
+		FeeLimit:  uint64(params.SunPerTRX),
+	}
+
+	tx, err := cli.CallContract(caller, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := broadcast(t, cli, tx)
+
+	if info.Receipt.Result != client.TxResultOutOfEnergy {
+		t.Fatalf("result is %s, want %s", info.Receipt.Result, client.TxResultOutOfEnergy)
+	}
+
+	// The staked energy is used first and the fee limit is then burned in full.
+	if info.Receipt.EnergyFee != uint64(params.SunPerTRX) {
+		t.Fatalf("energy fee is %d, want %d", info.Receipt.EnergyFee, params.SunPerTRX)
+	}
+	if want := 1000 + uint64(params.SunPerTRX)/fakenode.DefaultEnergyFee; info.Receipt.EnergyUsageTotal != want {
+		t.Fatalf("energy used is %d, want %d", info.Receipt.EnergyUsageTotal, want)
+	}
+
+	acc, _ := node.Account(caller.Address())
+	if acc.Balance != 99*params.SunPerTRX || acc.EnergyUsed != 1000 {
+		t.Fatalf("account after call is %+v", acc)
+	}
+}
@@ -0,0 +1,396 @@
+package flows
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/trc20"
+	"github.com/go-chain/go-tron/units"
+)
+
+// DefaultFeeLimit is the fee limit of contract calls when a step does not set fee_limit.
+const DefaultFeeLimit = 100 * params.SunPerTRX
+
+// values are the rendered values of a step.
+type values map[string]string
+
+// require returns a value, or an error if it is missing.
+func (v values) require(key string) (string, error) {
+	str, ok := v[key]
+	if !ok || str == "" {
+		return "", fmt.Errorf("missing %s", key)
+	}
+	return str, nil
+}
+
+// execute performs the action of a step, returning its outputs and, for actions which change
+// state, the signed transaction to broadcast.
+func (r *Runner) execute(step Step, with map[string]string, args []string) (*tron.Transaction, map[string]string, error) {
+	v := values(with)
+	outputs := make(map[string]string)
+
+	var (
+		tx  tron.Transaction
+		err error
+	)
+	switch step.Action {
+	case ActionBalance:
+		err = r.balance(v, outputs)
+		return nil, outputs, err
+	case ActionApprove:
+		tx, err = r.approve(v)
+	case ActionTransfer:
+		tx, err = r.transfer(v)
+	case ActionCall:
+		var constant bool
+		tx, constant, err = r.call(v, args, outputs)
+		if constant {
+			return nil, outputs, err
+		}
+	default:
+		err = fmt.Errorf("unknown action %q", step.Action)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &tx, outputs, nil
+}
+
+// balance checks the balance of an address against an optional minimum.
+func (r *Runner) balance(v values, outputs map[string]string) error {
+	str, err := v.require("address")
+	if err != nil {
+		return err
+	}
+
+	addr, err := r.resolve(str)
+	if err != nil {
+		return err
+	}
+
+	var balance units.Amount
+	if token, ok := v["token"]; ok {
+		t, err := r.token(token)
+		if err != nil {
+			return err
+		}
+
+		raw, err := t.BalanceOf(addr)
+		if err != nil {
+			return err
+		}
+
+		if balance, err = t.Amount(raw); err != nil {
+			return err
+		}
+	} else {
+		acc, err := r.client.GetAccount(addr.ToBase58())
+		if err != nil {
+			return err
+		}
+		balance = acc.Balance.Amount()
+	}
+
+	outputs["balance"] = balance.Format()
+
+	if min, ok := v["min"]; ok {
+		amount, err := units.Parse(min, balance.Decimals(), balance.Symbol())
+		if err != nil {
+			return err
+		}
+
+		cmp, err := balance.Cmp(amount)
+		if err != nil {
+			return err
+		}
+		if cmp < 0 {
+			return fmt.Errorf("balance of %s is %s, less than %s", addr.ToBase58(), balance, amount)
+		}
+	}
+
+	return nil
+}
+
+// approve creates a transaction which approves a spender for an amount of a token.
+func (r *Runner) approve(v values) (tron.Transaction, error) {
+	from, err := r.signer(v)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	token, err := v.require("token")
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	t, err := r.token(token)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	spender, err := v.require("spender")
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	to, err := r.resolve(spender)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	amount, err := tokenAmount(t, v)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	feeLimit, err := v.feeLimit()
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return t.Approve(from, to, amount, feeLimit)
+}
+
+// transfer creates a transaction which transfers an amount of TRX or a token.
+func (r *Runner) transfer(v values) (tron.Transaction, error) {
+	from, err := r.signer(v)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	str, err := v.require("to")
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	to, err := r.resolve(str)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	token, ok := v["token"]
+	if !ok {
+		sun, err := v.trx("amount")
+		if err != nil {
+			return tron.Transaction{}, err
+		}
+		if sun <= 0 {
+			return tron.Transaction{}, fmt.Errorf("transfer amount must be positive (%d)", sun)
+		}
+
+		return r.client.Transfer(from, to, sun)
+	}
+
+	t, err := r.token(token)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	amount, err := tokenAmount(t, v)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	feeLimit, err := v.feeLimit()
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return t.Transfer(from, to, amount, feeLimit)
+}
+
+// call calls a method of a contract. Methods which do not change state are called as constant
+// calls whose result is output, rather than creating a transaction.
+func (r *Runner) call(v values, args []string, outputs map[string]string) (tron.Transaction, bool, error) {
+	str, err := v.require("contract")
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	contract, err := r.resolve(str)
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	path, err := v.require("abi")
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	a, err := abi.ReadFile(path)
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	method, err := v.require("method")
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	fn, err := a.Function(method)
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	arguments, err := r.arguments(fn, args)
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	if fn.Immutable() {
+		results, err := r.client.TriggerSmartContract(nil, client.CallContractInput{
+			Address:   contract,
+			Function:  fn,
+			Arguments: arguments,
+		})
+		if err != nil {
+			return tron.Transaction{}, true, err
+		}
+
+		outputs["result"] = results[0]
+		return tron.Transaction{}, true, nil
+	}
+
+	from, err := r.signer(v)
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	value, err := v.trx("value")
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+
+	feeLimit, err := v.feeLimit()
+	if err != nil {
+		return tron.Transaction{}, false, err
+	}
+	if feeLimit == 0 {
+		feeLimit = DefaultFeeLimit
+	}
+
+	tx, err := r.client.CallContract(from, client.CallContractInput{
+		Address:   contract,
+		Function:  fn,
+		Arguments: arguments,
+		FeeLimit:  uint64(feeLimit),
+		CallValue: uint64(value),
+	})
+	return tx, false, err
+}
+
+// arguments parses the arguments of a method. Addresses may be given as the names of accounts,
+// integers in decimal or with a 0x prefix in hex and bytes in hex. Arrays cannot be given.
+func (r *Runner) arguments(fn abi.Function, args []string) ([]interface{}, error) {
+	if len(args) != len(fn.Inputs) {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", fn.Signature(), len(fn.Inputs), len(args))
+	}
+
+	arguments := make([]interface{}, len(args))
+	for i, in := range fn.Inputs {
+		t := string(in.Type)
+		switch {
+		case strings.HasSuffix(t, "]"):
+			return nil, fmt.Errorf("arguments of type %s are not supported", t)
+		case t == "address":
+			addr, err := r.resolve(args[i])
+			if err != nil {
+				return nil, err
+			}
+			arguments[i] = addr
+		case strings.HasPrefix(t, "uint") || strings.HasPrefix(t, "int"):
+			n, ok := new(big.Int).SetString(args[i], 0)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s %s", t, args[i])
+			}
+			arguments[i] = n
+		case t == "string":
+			arguments[i] = args[i]
+		case t == "bool":
+			b, err := strconv.ParseBool(args[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool %s", args[i])
+			}
+			arguments[i] = b
+		case strings.HasPrefix(t, "bytes"):
+			b, err := hex.DecodeString(strings.TrimPrefix(args[i], "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %s", t, args[i])
+			}
+			arguments[i] = b
+		default:
+			return nil, fmt.Errorf("arguments of type %s are not supported", t)
+		}
+	}
+
+	return arguments, nil
+}
+
+// resolve returns the address of an account by name, or parses an address.
+func (r *Runner) resolve(str string) (address.Address, error) {
+	if acc, ok := r.accounts[str]; ok {
+		return acc.Address(), nil
+	}
+	return address.Parse(str)
+}
+
+// signer returns the account named by from.
+func (r *Runner) signer(v values) (account.Account, error) {
+	name, err := v.require("from")
+	if err != nil {
+		return nil, err
+	}
+
+	acc, ok := r.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %s", name)
+	}
+	return acc, nil
+}
+
+func (r *Runner) token(str string) (*trc20.Token, error) {
+	contract, err := r.resolve(str)
+	if err != nil {
+		return nil, err
+	}
+	return trc20.New(r.client, contract), nil
+}
+
+// tokenAmount parses the amount of a token, which must be positive.
+func tokenAmount(t *trc20.Token, v values) (*big.Int, error) {
+	str, err := v.require("amount")
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := t.ParseAmount(str)
+	if err != nil {
+		return nil, err
+	}
+	return amount.Raw(), nil
+}
+
+// trx parses an optional amount of TRX into sun.
+func (v values) trx(key string) (params.Sun, error) {
+	str, ok := v[key]
+	if !ok {
+		return 0, nil
+	}
+
+	return params.ParseTRX(str)
+}
+
+// feeLimit parses the optional fee limit in TRX, zero if it is not set.
+func (v values) feeLimit() (params.Sun, error) {
+	return v.trx("fee_limit")
+}
@@ -0,0 +1,211 @@
+// Package flows executes pipelines of transactions defined in YAML, such as checking a balance,
+// approving a spender, swapping and then transferring the proceeds, so that runbooks can be
+// run rather than followed by hand.
+//
+// A flow declares its parameters and a list of steps. The values given to a step are templates
+// which may refer to parameters as {{.Params.name}} and to the outputs of earlier steps as
+// {{.Steps.step.output}}:
+//
+//	name: sweep
+//	params:
+//	  - name: amount
+//	  - name: treasury
+//	    default: TJRabPrwbZy45sbavfcjinPJC18kjpRTv8
+//	steps:
+//	  - name: check
+//	    action: balance
+//	    with: {address: hot, token: TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t, min: "{{.Params.amount}}"}
+//	  - name: send
+//	    action: transfer
+//	    confirm: true
+//	    confirmations: 19
+//	    with: {from: hot, to: "{{.Params.treasury}}", token: TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t, amount: "{{.Params.amount}}"}
+//
+// Swaps and other contract interactions are call steps, which take the path of the contract's
+// ABI, the method and its arguments.
+package flows
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Actions which a step may perform.
+const (
+	// ActionBalance checks the balance of an address, of TRX or a TRC20 token if one is given,
+	// failing if it is below min. Its output is the balance.
+	ActionBalance = "balance"
+
+	// ActionApprove approves a spender to transfer an amount of a TRC20 token from an account.
+	ActionApprove = "approve"
+
+	// ActionTransfer transfers an amount of TRX, or of a TRC20 token if one is given.
+	ActionTransfer = "transfer"
+
+	// ActionCall calls a method of a contract with arguments, such as the swap of a router.
+	ActionCall = "call"
+)
+
+// keys are the values which each action accepts.
+var keys = map[string][]string{
+	ActionBalance:  {"address", "token", "min"},
+	ActionApprove:  {"from", "token", "spender", "amount", "fee_limit"},
+	ActionTransfer: {"from", "to", "token", "amount", "fee_limit"},
+	ActionCall:     {"from", "contract", "abi", "method", "value", "fee_limit"},
+}
+
+// Flow is a pipeline of steps which are executed in order.
+type Flow struct {
+	Name   string  `yaml:"name"`
+	Params []Param `yaml:"params"`
+	Steps  []Step  `yaml:"steps"`
+}
+
+// Param is a parameter of a flow. A parameter without a default is required.
+type Param struct {
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	Default     *string `yaml:"default"`
+}
+
+// Step is a single action of a flow.
+type Step struct {
+	Name   string `yaml:"name"`
+	Action string `yaml:"action"`
+
+	// With are the values of the action, such as from, to and amount. Each is a template.
+	With map[string]string `yaml:"with"`
+
+	// Args are the arguments of the method of a call step. Each is a template.
+	Args []string `yaml:"args"`
+
+	// Confirm requires the operator to confirm the transaction of the step before it is
+	// broadcast.
+	Confirm bool `yaml:"confirm"`
+
+	// Confirmations is the number of blocks, including the block the transaction is included
+	// in, to wait for before the next step. Zero waits until it has been processed.
+	Confirmations uint64 `yaml:"confirmations"`
+
+	// Solidified waits until the transaction is solidified before the next step.
+	Solidified bool `yaml:"solidified"`
+}
+
+// Parse parses and validates a flow.
+func Parse(data []byte) (*Flow, error) {
+	var f Flow
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("flows: %v", err)
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// ReadFile reads, parses and validates a flow from a file.
+func ReadFile(path string) (*Flow, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// validate checks that a flow has a name, its parameters and steps are named uniquely and that
+// each step has a known action with valid templates.
+func (f *Flow) validate() error {
+	if f.Name == "" {
+		return errors.New("flows: flow has no name")
+	}
+
+	params := make(map[string]bool, len(f.Params))
+	for _, p := range f.Params {
+		if p.Name == "" || params[p.Name] {
+			return fmt.Errorf("flows: %s has missing or duplicate parameter name %q", f.Name, p.Name)
+		}
+		params[p.Name] = true
+	}
+
+	if len(f.Steps) == 0 {
+		return fmt.Errorf("flows: %s has no steps", f.Name)
+	}
+
+	steps := make(map[string]bool, len(f.Steps))
+	for _, s := range f.Steps {
+		if s.Name == "" || steps[s.Name] {
+			return fmt.Errorf("flows: %s has missing or duplicate step name %q", f.Name, s.Name)
+		}
+		steps[s.Name] = true
+
+		accepted, ok := keys[s.Action]
+		if !ok {
+			return fmt.Errorf("flows: step %s has unknown action %q", s.Name, s.Action)
+		}
+
+	with:
+		for key := range s.With {
+			for _, k := range accepted {
+				if k == key {
+					continue with
+				}
+			}
+			return fmt.Errorf("flows: step %s has unknown value %q for %s", s.Name, key, s.Action)
+		}
+
+		if len(s.Args) > 0 && s.Action != ActionCall {
+			return fmt.Errorf("flows: step %s has args but is not a call", s.Name)
+		}
+
+		for _, text := range s.templates() {
+			if _, err := template.New(s.Name).Parse(text); err != nil {
+				return fmt.Errorf("flows: step %s has invalid template: %v", s.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// params returns the values of the parameters of a flow, with defaults for those not given.
+func (f *Flow) params(given map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(f.Params))
+	declared := make(map[string]bool, len(f.Params))
+
+	for _, p := range f.Params {
+		declared[p.Name] = true
+
+		v, ok := given[p.Name]
+		switch {
+		case ok:
+			values[p.Name] = v
+		case p.Default != nil:
+			values[p.Name] = *p.Default
+		default:
+			return nil, fmt.Errorf("flows: %s requires parameter %s", f.Name, p.Name)
+		}
+	}
+
+	for name := range given {
+		if !declared[name] {
+			return nil, fmt.Errorf("flows: %s has no parameter %s", f.Name, name)
+		}
+	}
+
+	return values, nil
+}
+
+// templates returns the templates of the values and arguments of a step.
+func (s Step) templates() []string {
+	texts := make([]string, 0, len(s.With)+len(s.Args))
+	for _, text := range s.With {
+		texts = append(texts, text)
+	}
+	return append(texts, s.Args...)
+}
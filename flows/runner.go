@@ -0,0 +1,276 @@
+package flows
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/client"
+)
+
+// ErrDeclined is returned when the operator declines to broadcast the transaction of a step.
+// The state of the run is kept, so it may be resumed.
+var ErrDeclined = errors.New("flows: transaction declined")
+
+// ConfirmFunc asks the operator whether the transaction of a step should be broadcast.
+type ConfirmFunc func(step Step, tx *tron.Transaction) (bool, error)
+
+// Runner executes flows.
+type Runner struct {
+	client   *client.Client
+	accounts map[string]account.Account
+	store    Store
+	confirm  ConfirmFunc
+	timeout  time.Duration
+}
+
+// Option configures optional behaviour of a runner.
+type Option func(*Runner)
+
+// WithAccounts sets the accounts which steps may sign with, by the name they are referred to
+// as in flows. Their names may also be used in place of their addresses.
+func WithAccounts(accounts map[string]account.Account) Option {
+	return func(r *Runner) {
+		r.accounts = accounts
+	}
+}
+
+// WithStore sets the store which the state of runs is saved to, so that they can be resumed.
+// By default runs are not resumable.
+func WithStore(s Store) Option {
+	return func(r *Runner) {
+		r.store = s
+	}
+}
+
+// WithConfirm sets the function which asks the operator to confirm the transactions of steps
+// which require it. Without one, such steps fail.
+func WithConfirm(fn ConfirmFunc) Option {
+	return func(r *Runner) {
+		r.confirm = fn
+	}
+}
+
+// WithTimeout sets the maximum amount of time to wait for the transaction of each step, by
+// default ten minutes.
+func WithTimeout(d time.Duration) Option {
+	return func(r *Runner) {
+		r.timeout = d
+	}
+}
+
+// New creates a runner which executes flows with a client.
+func New(c *client.Client, opts ...Option) *Runner {
+	r := &Runner{
+		client:  c,
+		timeout: 10 * time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run executes a flow with parameters, returning the state of the run. If the store has the
+// state of an incomplete run of the flow it is resumed, skipping the steps that completed,
+// which requires the same parameters.
+func (r *Runner) Run(ctx context.Context, f *Flow, params map[string]string) (*State, error) {
+	values, err := f.params(params)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.load(f.Name, values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range f.Steps {
+		st := state.Steps[step.Name]
+		if st == nil {
+			st = new(StepState)
+			state.Steps[step.Name] = st
+		}
+
+		if st.Status == StepDone {
+			continue
+		}
+
+		if err := r.run(ctx, state, step, st); err == ErrDeclined {
+			return state, err
+		} else if err != nil {
+			return state, fmt.Errorf("flows: step %s: %v", step.Name, err)
+		}
+	}
+
+	if r.store != nil {
+		if err := r.store.Delete(f.Name); err != nil {
+			return state, err
+		}
+	}
+
+	return state, nil
+}
+
+// load returns the state of the incomplete run of a flow, or a new state.
+func (r *Runner) load(flow string, params map[string]string) (*State, error) {
+	if r.store != nil {
+		state, err := r.store.Load(flow)
+		if err != nil {
+			return nil, err
+		}
+
+		if state != nil {
+			for name, v := range params {
+				if state.Params[name] != v {
+					return nil, fmt.Errorf("flows: incomplete run of %s has %s %q, not %q", flow, name, state.Params[name], v)
+				}
+			}
+			return state, nil
+		}
+	}
+
+	return &State{
+		Flow:   flow,
+		Params: params,
+		Steps:  make(map[string]*StepState),
+	}, nil
+}
+
+func (r *Runner) save(state *State) error {
+	if r.store == nil {
+		return nil
+	}
+	return r.store.Save(state)
+}
+
+// run executes a step, or if its transaction was broadcast by an earlier run, waits for it.
+func (r *Runner) run(ctx context.Context, state *State, step Step, st *StepState) error {
+	if st.Status != StepBroadcast {
+		with, args, err := render(step, state)
+		if err != nil {
+			return err
+		}
+
+		tx, outputs, err := r.execute(step, with, args)
+		if err != nil {
+			return err
+		}
+		st.Outputs = outputs
+
+		if tx != nil {
+			if err := r.broadcast(step, tx); err != nil {
+				return err
+			}
+
+			st.Status = StepBroadcast
+			st.Outputs["txid"] = tx.Id
+			if err := r.save(state); err != nil {
+				return err
+			}
+		}
+	}
+
+	if id := st.Outputs["txid"]; id != "" {
+		if err := r.await(ctx, step, id); err != nil {
+			return err
+		}
+	}
+
+	st.Status = StepDone
+	return r.save(state)
+}
+
+// broadcast broadcasts the transaction of a step, once confirmed by the operator if required.
+func (r *Runner) broadcast(step Step, tx *tron.Transaction) error {
+	if step.Confirm {
+		if r.confirm == nil {
+			return errors.New("requires confirmation but the runner cannot confirm")
+		}
+
+		ok, err := r.confirm(step, tx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrDeclined
+		}
+	}
+
+	return r.client.BroadcastTransaction(tx)
+}
+
+// await waits for the transaction of a step and checks that it succeeded.
+func (r *Runner) await(ctx context.Context, step Step, id string) error {
+	result, err := r.client.Await(ctx, id, client.AwaitOptions{
+		Timeout:       r.timeout,
+		Confirmations: step.Confirmations,
+		Solidified:    step.Solidified,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only contract calls have a result, other transactions failing are not processed.
+	if res := result.Info.Receipt.Result; res != "" && res != client.TxResultSuccess {
+		return fmt.Errorf("transaction %s failed (%s)", id, res)
+	}
+
+	return nil
+}
+
+// render executes the templates of the values and arguments of a step.
+func render(step Step, state *State) (map[string]string, []string, error) {
+	data := struct {
+		Params map[string]string
+		Steps  map[string]map[string]string
+	}{
+		Params: state.Params,
+		Steps:  make(map[string]map[string]string, len(state.Steps)),
+	}
+	for name, st := range state.Steps {
+		if st.Status == StepDone {
+			data.Steps[name] = st.Outputs
+		}
+	}
+
+	execute := func(text string) (string, error) {
+		t, err := template.New(step.Name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, &data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	with := make(map[string]string, len(step.With))
+	for key, text := range step.With {
+		v, err := execute(text)
+		if err != nil {
+			return nil, nil, err
+		}
+		with[key] = v
+	}
+
+	args := make([]string, len(step.Args))
+	for i, text := range step.Args {
+		v, err := execute(text)
+		if err != nil {
+			return nil, nil, err
+		}
+		args[i] = v
+	}
+
+	return with, args, nil
+}
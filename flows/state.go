@@ -0,0 +1,97 @@
+package flows
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// StepStatus is the progress of a step of a run.
+type StepStatus string
+
+const (
+	// StepBroadcast is a step whose transaction has been broadcast but not yet confirmed.
+	StepBroadcast StepStatus = "broadcast"
+
+	// StepDone is a step which has completed, it is not repeated when a run is resumed.
+	StepDone StepStatus = "done"
+)
+
+// StepState is the progress and outputs of a step of a run.
+type StepState struct {
+	Status  StepStatus        `json:"status"`
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// State is the progress of a run of a flow, which is saved after each step so that a run which
+// fails or is interrupted can be resumed from the step it stopped at.
+type State struct {
+	Flow   string                `json:"flow"`
+	Params map[string]string     `json:"params"`
+	Steps  map[string]*StepState `json:"steps"`
+}
+
+// Store persists the state of incomplete runs, one per flow.
+type Store interface {
+	// Load returns the state of the incomplete run of a flow, or nil if there is none.
+	Load(flow string) (*State, error)
+
+	// Save saves the state of a run.
+	Save(state *State) error
+
+	// Delete deletes the state of the run of a flow once it has completed.
+	Delete(flow string) error
+}
+
+// FileStore is a store which keeps the state of each run as a JSON file in a directory.
+type FileStore string
+
+func (s FileStore) path(flow string) string {
+	return filepath.Join(string(s), flow+".json")
+}
+
+// Load returns the state of the incomplete run of a flow, or nil if there is none.
+func (s FileStore) Load(flow string) (*State, error) {
+	data, err := ioutil.ReadFile(s.path(flow))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save saves the state of a run, replacing the file atomically so that it is never partially
+// written.
+func (s FileStore) Save(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(string(s), 0700); err != nil {
+		return err
+	}
+
+	tmp := s.path(state.Flow) + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(state.Flow))
+}
+
+// Delete deletes the state of the run of a flow.
+func (s FileStore) Delete(flow string) error {
+	err := os.Remove(s.path(flow))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
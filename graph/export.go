@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Nodes are identified by their
+// base 58 address and edges are labelled with their value, token and count.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph transfers {")
+	for _, addr := range g.Nodes() {
+		fmt.Fprintf(bw, "\t%s [label=%s];\n", strconv.Quote(addr.ToBase58()), strconv.Quote(g.label(addr)))
+	}
+	for _, e := range g.Edges() {
+		label := fmt.Sprintf("%s %s (%d)", e.Value, e.Token, e.Count)
+		fmt.Fprintf(bw, "\t%s -> %s [label=%s, token=%s, value=%s, count=%d];\n",
+			strconv.Quote(e.From.ToBase58()), strconv.Quote(e.To.ToBase58()),
+			strconv.Quote(label), strconv.Quote(e.Token), strconv.Quote(e.Value.String()), e.Count)
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph in GraphML. Nodes are identified by their base 58 address and
+// have a label, edges have the token, value and count as data. Values are strings, as they
+// may not fit the numeric types of GraphML.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "label", For: "node", Name: "label", Type: "string"},
+			{Id: "token", For: "edge", Name: "token", Type: "string"},
+			{Id: "value", For: "edge", Name: "value", Type: "string"},
+			{Id: "count", For: "edge", Name: "count", Type: "long"},
+		},
+	}
+	doc.Graph.EdgeDefault = "directed"
+
+	for _, addr := range g.Nodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			Id:   addr.ToBase58(),
+			Data: []graphMLData{{Key: "label", Value: g.label(addr)}},
+		})
+	}
+
+	for _, e := range g.Edges() {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: e.From.ToBase58(),
+			Target: e.To.ToBase58(),
+			Data: []graphMLData{
+				{Key: "token", Value: e.Token},
+				{Key: "value", Value: e.Value.String()},
+				{Key: "count", Value: strconv.Itoa(e.Count)},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
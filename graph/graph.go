@@ -0,0 +1,232 @@
+// Package graph builds a directed graph of the transfers between addresses, with an edge for
+// each sender, recipient and token carrying the total value and number of transfers, and
+// exports it as DOT or GraphML for visualization in compliance and research tools.
+package graph
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/labels"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/trc20"
+)
+
+// maxRange is the most blocks fetched in a single request while scanning.
+const maxRange = 100
+
+// TRX is the token of edges of TRX transfers. Edges of TRC10 transfers have the id of the
+// asset as their token and edges of TRC20 transfers the base 58 address of the contract.
+const TRX = params.TRXSymbol
+
+// Edge is every transfer of a token from one address to another. Value is in the smallest
+// unit of the token, sun for TRX.
+type Edge struct {
+	From  address.Address
+	To    address.Address
+	Token string
+	Value *big.Int
+	Count int
+}
+
+type edgeKey struct {
+	from, to address.Address
+	token    string
+}
+
+// Option configures optional behaviour of a graph.
+type Option func(*Graph)
+
+// WithLabels names the nodes of exported graphs after their labels in a set.
+func WithLabels(s *labels.Set) Option {
+	return func(g *Graph) {
+		g.labels = s
+	}
+}
+
+// Graph is a directed graph of transfers between addresses. A graph is not safe for concurrent
+// use.
+type Graph struct {
+	labels *labels.Set
+
+	nodes map[address.Address]struct{}
+	edges map[edgeKey]*Edge
+}
+
+// New creates an empty graph.
+func New(opts ...Option) *Graph {
+	g := &Graph{
+		nodes: make(map[address.Address]struct{}),
+		edges: make(map[edgeKey]*Edge),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// AddTransfer adds a transfer of a value of a token from one address to another.
+func (g *Graph) AddTransfer(from, to address.Address, token string, value *big.Int) {
+	g.nodes[from] = struct{}{}
+	g.nodes[to] = struct{}{}
+
+	key := edgeKey{from: from, to: to, token: token}
+	e, ok := g.edges[key]
+	if !ok {
+		e = &Edge{From: from, To: to, Token: token, Value: new(big.Int)}
+		g.edges[key] = e
+	}
+	e.Value.Add(e.Value, value)
+	e.Count++
+}
+
+// AddTransaction adds the transfers made by a transaction: TRX and TRC10 transfers, TRX sent
+// with contract calls and the TRC20 transfers logged by them. Transfers made by internal
+// transactions of contracts, other than TRC20 transfers, are not added.
+func (g *Graph) AddTransaction(tx *tron.Transaction, info client.TransactionInfo) error {
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := m.RawData.UnpackContracts()
+	if err != nil {
+		return err
+	}
+
+	// Calls which failed transferred nothing, including the value sent with them.
+	if info.Receipt.Result != "" && info.Receipt.Result != client.TxResultSuccess {
+		return nil
+	}
+
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case *pb.TransferContract:
+			g.AddTransfer(toAddress(msg.OwnerAddress), toAddress(msg.ToAddress), TRX, big.NewInt(msg.Amount))
+		case *pb.TransferAssetContract:
+			g.AddTransfer(toAddress(msg.OwnerAddress), toAddress(msg.ToAddress), string(msg.AssetName), big.NewInt(msg.Amount))
+		case *pb.TriggerSmartContract:
+			if msg.CallValue > 0 {
+				g.AddTransfer(toAddress(msg.OwnerAddress), toAddress(msg.ContractAddress), TRX, big.NewInt(msg.CallValue))
+			}
+		}
+	}
+
+	transfers, err := trc20.Transfers(info)
+	if err != nil {
+		return err
+	}
+	for _, t := range transfers {
+		g.AddTransfer(t.From, t.To, t.Token.ToBase58(), t.Value)
+	}
+
+	return nil
+}
+
+func toAddress(b []byte) address.Address {
+	var addr address.Address
+	copy(addr[:], b)
+	return addr
+}
+
+// Nodes returns the addresses in the graph, sorted.
+func (g *Graph) Nodes() []address.Address {
+	nodes := make([]address.Address, 0, len(g.nodes))
+	for addr := range g.nodes {
+		nodes = append(nodes, addr)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ToBase58() < nodes[j].ToBase58()
+	})
+
+	return nodes
+}
+
+// Edges returns the edges of the graph, sorted by sender, recipient and token.
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, len(g.edges))
+	for _, e := range g.edges {
+		edges = append(edges, *e)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From.ToBase58() < b.From.ToBase58()
+		}
+		if a.To != b.To {
+			return a.To.ToBase58() < b.To.ToBase58()
+		}
+		return a.Token < b.Token
+	})
+
+	return edges
+}
+
+// Scan adds the transfers made in the blocks within a range of heights, end exclusive.
+func (g *Graph) Scan(c *client.Client, start, end uint64) error {
+	for from := start; from < end; from += maxRange {
+		to := from + maxRange
+		if to > end {
+			to = end
+		}
+
+		blocks, err := c.GetBlockRange(from, to)
+		if err != nil {
+			return err
+		}
+
+		for _, block := range blocks {
+			if err := g.addBlock(c, &block); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addBlock adds the transfers made in a block.
+func (g *Graph) addBlock(c *client.Client, block *tron.Block) error {
+	if len(block.Transactions) == 0 {
+		return nil
+	}
+
+	infos, err := c.GetTransactionInfoByBlockNum(block.BlockHeader.RawData.Number)
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[string]client.TransactionInfo, len(infos))
+	for _, info := range infos {
+		byId[info.Id] = info
+	}
+
+	for i := range block.Transactions {
+		if err := g.AddTransaction(&block.Transactions[i], byId[block.Transactions[i].Id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// label returns the name of a node, its address followed by its labels if any.
+func (g *Graph) label(addr address.Address) string {
+	name := addr.ToBase58()
+	if g.labels == nil {
+		return name
+	}
+
+	for _, l := range g.labels.Lookup(addr) {
+		name += " (" + l.Namespace + ": " + l.Name + ")"
+	}
+	return name
+}
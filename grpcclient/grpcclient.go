@@ -0,0 +1,222 @@
+// Package grpcclient provides functionality for interacting with the Tron node gRPC Wallet
+// service. The client implements client.Wallet and so can be used in place of the HTTP client.
+package grpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/codec"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"google.golang.org/grpc"
+)
+
+type Client struct {
+	conn *grpc.ClientConn
+
+	// Timeout is the maximum amount of time a single call may take.
+	timeout time.Duration
+}
+
+var _ client.Wallet = (*Client)(nil)
+
+// New creates a new client for the provided target, which is typically the host and port
+// of a full node's gRPC interface. Transport credentials must be provided with the options.
+func New(target string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:    conn,
+		timeout: 30 * time.Second,
+	}, nil
+}
+
+// Close closes the connection to the node.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetBlock returns the block for the specified id or height. When detail is false the node
+// omits the transaction bodies.
+func (c *Client) GetBlock(idOrNum string, detail bool) (*tron.Block, error) {
+	request := pb.BlockReq{
+		IdOrNum: idOrNum,
+		Detail:  detail,
+	}
+
+	var response pb.BlockExtention
+	if err := c.invoke("GetBlock", &request, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Blockid) == 0 {
+		return nil, nil
+	}
+
+	block, err := pb.ToBlock(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// Transfer transfers an amount of TRX, in sun, from a source account to a destination address.
+// The transaction is signed but not broadcast.
+func (c *Client) Transfer(src account.Account, dest address.Address, amount params.Sun) (tron.Transaction, error) {
+	if amount <= 0 {
+		return tron.Transaction{}, fmt.Errorf("grpcclient: transfer amount must be positive (%d sun)", amount)
+	}
+
+	owner := src.Address()
+	request := pb.TransferContract{
+		OwnerAddress: owner[:],
+		ToAddress:    dest[:],
+		Amount:       int64(amount),
+	}
+
+	var response pb.TransactionExtention
+	if err := c.invoke("CreateTransaction2", &request, &response); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	tx, err := transaction(&response)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if err := src.Sign(&tx); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	return tx, nil
+}
+
+// TriggerSmartContract triggers a contract function and returns the hex encoded results.
+// Immutable functions are triggered as constant calls.
+func (c *Client) TriggerSmartContract(acc account.Account, input client.CallContractInput) ([]string, error) {
+	if !input.Function.Payable() && input.CallValue > 0 {
+		return nil, errors.New("grpcclient: cannot send tron to non-payable function")
+	}
+
+	request, err := triggerRequest(acc.Address(), input)
+	if err != nil {
+		return nil, err
+	}
+
+	method := "TriggerContract"
+	if input.Function.Immutable() {
+		method = "TriggerConstantContract"
+	}
+
+	var response pb.TransactionExtention
+	if err := c.invoke(method, request, &response); err != nil {
+		return nil, err
+	}
+
+	if err := returnError(response.Result); err != nil {
+		return nil, err
+	}
+
+	if len(response.ConstantResult) < 1 {
+		return nil, errors.New("response result length err")
+	}
+
+	results := make([]string, 0, len(response.ConstantResult))
+	for _, r := range response.ConstantResult {
+		results = append(results, hex.EncodeToString(r))
+	}
+
+	return results, nil
+}
+
+// triggerRequest creates the request of a contract call, whose data is the selector of the
+// function followed by its encoded arguments.
+func triggerRequest(owner address.Address, input client.CallContractInput) (*pb.TriggerSmartContract, error) {
+	args, err := input.Function.EncodeArguments(input.Arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := input.Function.Selector()
+	return &pb.TriggerSmartContract{
+		OwnerAddress:    owner[:],
+		ContractAddress: input.Address[:],
+		CallValue:       int64(input.CallValue),
+		Data:            append(sel[:], args...),
+	}, nil
+}
+
+// BroadcastTransaction broadcasts a signed transaction to the network.
+func (c *Client) BroadcastTransaction(tx *tron.Transaction) error {
+	request, err := pb.FromTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	var response pb.Return
+	if err := c.invoke("BroadcastTransaction", request, &response); err != nil {
+		return err
+	}
+
+	if !response.Result {
+		return &client.BroadcastError{
+			Code:    client.BroadcastCode(response.Code.String()),
+			Message: string(response.Message),
+			TxId:    tx.Id,
+		}
+	}
+
+	return nil
+}
+
+// invoke calls a method of the Wallet service.
+func (c *Client) invoke(method string, request, response pb.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.conn.Invoke(ctx, "/protocol.Wallet/"+method, request, response, grpc.ForceCodec(codec.Proto{}))
+}
+
+// transaction converts the transaction of an extention, failing if the node reported an error.
+func transaction(ext *pb.TransactionExtention) (tron.Transaction, error) {
+	if err := returnError(ext.Result); err != nil {
+		return tron.Transaction{}, err
+	}
+
+	if ext.Transaction == nil {
+		return tron.Transaction{}, errors.New("grpcclient: node did not return a transaction")
+	}
+
+	tx, err := pb.ToTransaction(ext.Transaction)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	// The node returns the id it will know the transaction by, which is used rather than
+	// the id recomputed from the re-encoded raw data.
+	if len(ext.Txid) > 0 {
+		tx.Id = hex.EncodeToString(ext.Txid)
+	}
+
+	return tx, nil
+}
+
+// returnError converts an unsuccessful return into an error.
+func returnError(ret *pb.Return) error {
+	if ret == nil || ret.Result || ret.Code == pb.ReturnSuccess {
+		return nil
+	}
+	return fmt.Errorf("grpcclient: node error (%s): %s", ret.Code, ret.Message)
+}
@@ -0,0 +1,46 @@
+package grpcclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+func TestTriggerRequest(t *testing.T) {
+	owner, err := address.FromBase58("TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdt, err := address.FromBase58("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := triggerRequest(owner, client.CallContractInput{
+		Address: usdt,
+		Function: abi.Function{
+			Name:   "transfer",
+			Inputs: []abi.Value{{Type: "address"}, {Type: abi.TypeUint256}},
+		},
+		Arguments: []interface{}{usdt, big.NewInt(1000000)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "a9059cbb" +
+		"000000000000000000000000a614f803b6fd780986a42c78ec9c7f77e6ded13c" +
+		"00000000000000000000000000000000000000000000000000000000000f4240"
+	if got := hex.EncodeToString(request.Data); got != want {
+		t.Fatalf("data is %s, want %s", got, want)
+	}
+
+	if !bytes.Equal(request.OwnerAddress, owner[:]) || !bytes.Equal(request.ContractAddress, usdt[:]) {
+		t.Fatal("request has the wrong addresses")
+	}
+}
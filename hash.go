@@ -0,0 +1,44 @@
+package tron
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// HashTransaction computes the id of a transaction from its protobuf encoded raw data. The
+// id of a transaction is the SHA-256 digest of its raw data.
+func HashTransaction(raw []byte) [32]byte {
+	return sha256.Sum256(raw)
+}
+
+// HashBlockHeader computes the id of a block from its protobuf encoded raw header and its
+// height. The id of a block is the SHA-256 digest of its raw header with the first eight
+// bytes replaced by the big endian height of the block.
+func HashBlockHeader(raw []byte, number uint64) [32]byte {
+	id := sha256.Sum256(raw)
+	binary.BigEndian.PutUint64(id[:8], number)
+	return id
+}
+
+// ComputeId recomputes the id of the transaction from its raw_data_hex field.
+func (tx *Transaction) ComputeId() (string, error) {
+	if tx.RawDataHex == nil {
+		return "", errors.New("tron: transaction has no raw data hex")
+	}
+
+	var str string
+	if err := json.Unmarshal(*tx.RawDataHex, &str); err != nil {
+		return "", err
+	}
+
+	raw, err := hex.DecodeString(str)
+	if err != nil {
+		return "", err
+	}
+
+	id := HashTransaction(raw)
+	return hex.EncodeToString(id[:]), nil
+}
@@ -0,0 +1,72 @@
+// Package health provides functionality for exposing the status of long running components
+// to orchestration probes.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// Status is a snapshot of the health of a component.
+type Status struct {
+	Healthy bool        `json:"healthy"`
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Reporter is implemented by components which can report their status.
+type Reporter interface {
+	Status() Status
+}
+
+// ReporterFunc adapts a function to a Reporter.
+type ReporterFunc func() Status
+
+func (f ReporterFunc) Status() Status {
+	return f()
+}
+
+// Handler returns an HTTP handler which serves /healthz and /statusz for the named reporters.
+// /healthz responds 200 when every reporter is healthy and 503 otherwise, /statusz responds
+// with the status of every reporter as json.
+func Handler(reporters map[string]Reporter) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		for _, name := range names(reporters) {
+			if !reporters[name].Status().Healthy {
+				http.Error(w, name+" is unhealthy", http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/statusz", func(w http.ResponseWriter, r *http.Request) {
+		statuses := make(map[string]Status, len(reporters))
+		healthy := true
+		for name, reporter := range reporters {
+			status := reporter.Status()
+			statuses[name] = status
+			healthy = healthy && status.Healthy
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(statuses)
+	})
+
+	return mux
+}
+
+func names(reporters map[string]Reporter) []string {
+	out := make([]string, 0, len(reporters))
+	for name := range reporters {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,184 @@
+// Package labels maintains names for addresses, loaded from datasets or tagged by hand, such as
+// the wallets of exchanges or addresses reported for scams. Labels are grouped by namespace so
+// that datasets from different sources do not overwrite each other.
+package labels
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// Well-known namespaces.
+const (
+	// Exchange labels the wallets of exchanges with the name of the exchange.
+	Exchange = "exchange"
+
+	// User labels addresses tagged by the user.
+	User = "user"
+)
+
+// Label is the name of an address within a namespace.
+type Label struct {
+	Namespace string
+	Name      string
+}
+
+// Set is a set of labels. A set is safe for concurrent use.
+type Set struct {
+	mu      sync.RWMutex
+	byAddr  map[address.Address]map[string]string
+	entries int
+}
+
+// New creates an empty set.
+func New() *Set {
+	return &Set{byAddr: make(map[address.Address]map[string]string)}
+}
+
+// Add labels an address within a namespace, replacing any label it had in the namespace.
+func (s *Set) Add(namespace string, addr address.Address, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names, ok := s.byAddr[addr]
+	if !ok {
+		names = make(map[string]string)
+		s.byAddr[addr] = names
+	}
+
+	if _, ok := names[namespace]; !ok {
+		s.entries++
+	}
+	names[namespace] = name
+}
+
+// Remove removes the label of an address within a namespace.
+func (s *Set) Remove(namespace string, addr address.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := s.byAddr[addr]
+	if _, ok := names[namespace]; !ok {
+		return
+	}
+
+	delete(names, namespace)
+	s.entries--
+
+	if len(names) == 0 {
+		delete(s.byAddr, addr)
+	}
+}
+
+// Get returns the label of an address within a namespace.
+func (s *Set) Get(namespace string, addr address.Address) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name, ok := s.byAddr[addr][namespace]
+	return name, ok
+}
+
+// Lookup returns every label of an address, sorted by namespace.
+func (s *Set) Lookup(addr address.Address) []Label {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := s.byAddr[addr]
+	if len(names) == 0 {
+		return nil
+	}
+
+	labels := make([]Label, 0, len(names))
+	for ns, name := range names {
+		labels = append(labels, Label{Namespace: ns, Name: name})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Namespace < labels[j].Namespace
+	})
+
+	return labels
+}
+
+// Len returns the number of labels in the set.
+func (s *Set) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.entries
+}
+
+// LoadJSON loads labels into a namespace from a JSON object of addresses to names, for example
+// {"T...": "Name"}. Addresses may be in base 58 or base 16.
+func (s *Set) LoadJSON(namespace string, r io.Reader) error {
+	var raw map[string]string
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return err
+	}
+
+	labels := make(map[address.Address]string, len(raw))
+	for str, name := range raw {
+		addr, err := address.Parse(str)
+		if err != nil {
+			return fmt.Errorf("labels: invalid address %q: %v", str, err)
+		}
+		labels[addr] = name
+	}
+
+	for addr, name := range labels {
+		s.Add(namespace, addr, name)
+	}
+
+	return nil
+}
+
+// LoadCSV loads labels into a namespace from CSV rows of an address followed by its name. An
+// optional header row is skipped, as are empty rows and rows starting with '#'. Addresses may
+// be in base 58 or base 16.
+func (s *Set) LoadCSV(namespace string, r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	labels := make(map[address.Address]string)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(record) < 2 {
+			return fmt.Errorf("labels: row %d has %d fields, expected an address and a name", line, len(record))
+		}
+
+		str := strings.TrimSpace(record[0])
+		addr, err := address.Parse(str)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return fmt.Errorf("labels: row %d has invalid address %q: %v", line, str, err)
+		}
+
+		labels[addr] = strings.TrimSpace(record[1])
+	}
+
+	for addr, name := range labels {
+		s.Add(namespace, addr, name)
+	}
+
+	return nil
+}
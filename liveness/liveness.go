@@ -0,0 +1,232 @@
+// Package liveness monitors the production of blocks, tracking the average interval between
+// them and alerting when no block has been produced for longer than a threshold. Unlike the
+// health of a node, which is whether it keeps up with its peers, liveness is whether the
+// chain as seen by the node is moving at all, which fails when the chain halts or the node
+// stops syncing.
+package liveness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/health"
+	"github.com/go-chain/go-tron/params"
+)
+
+const (
+	// DefaultWindow is the number of blocks the average interval is taken over.
+	DefaultWindow = 20
+
+	// DefaultThreshold is how long after the latest block production is considered stalled.
+	DefaultThreshold = 10 * params.BlockInterval
+)
+
+// EventKind is the kind of a liveness event.
+type EventKind int
+
+const (
+	// Stalled is when no block has been produced for longer than the threshold.
+	Stalled EventKind = iota
+
+	// Recovered is when a block is produced after a stall.
+	Recovered
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Stalled:
+		return "stalled"
+	case Recovered:
+		return "recovered"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change in the liveness of the chain.
+type Event struct {
+	Kind EventKind
+
+	// Height and Head are the height and time of the latest block, and Age how long ago it was
+	// produced when the event occurred.
+	Height uint64
+	Head   tron.Time
+	Age    time.Duration
+
+	// Lag is the number of blocks the peers of the node reported it was missing when it
+	// stalled. A lag suggests the node stopped syncing rather than the chain halting.
+	Lag uint64
+}
+
+func (e Event) String() string {
+	switch e.Kind {
+	case Stalled:
+		cause := "chain halted"
+		if e.Lag > 0 {
+			cause = fmt.Sprintf("node is %d blocks behind its peers", e.Lag)
+		}
+		return fmt.Sprintf("no block since %d at %s, %s ago (%s)", e.Height, e.Head, e.Age.Round(time.Second), cause)
+	default:
+		return fmt.Sprintf("block %d produced at %s", e.Height, e.Head)
+	}
+}
+
+// Option configures optional behaviour of a monitor.
+type Option func(*Monitor)
+
+// WithWindow sets the number of blocks the average interval is taken over, the default is
+// DefaultWindow.
+func WithWindow(n int) Option {
+	return func(m *Monitor) {
+		m.window = n
+	}
+}
+
+// WithThreshold sets how long after the latest block production is considered stalled, the
+// default is DefaultThreshold.
+func WithThreshold(d time.Duration) Option {
+	return func(m *Monitor) {
+		m.threshold = d
+	}
+}
+
+type sample struct {
+	height uint64
+	time   tron.Time
+}
+
+// Monitor tracks the production of blocks. A monitor is safe for concurrent use.
+type Monitor struct {
+	client    *client.Client
+	window    int
+	threshold time.Duration
+
+	mu      sync.Mutex
+	samples []sample
+	age     time.Duration
+	stalled bool
+}
+
+// New creates a monitor of the blocks produced as seen by a node.
+func New(c *client.Client, opts ...Option) *Monitor {
+	m := &Monitor{
+		client:    c,
+		window:    DefaultWindow,
+		threshold: DefaultThreshold,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.window < 2 {
+		m.window = 2
+	}
+
+	return m
+}
+
+// Poll reads the latest block and returns an event if production stalled or recovered since
+// the last poll, nil otherwise.
+func (m *Monitor) Poll() (*Event, error) {
+	block, err := m.client.GetLatestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	height, head := block.BlockHeader.RawData.Number, block.BlockHeader.RawData.Timestamp
+	age := time.Since(head.Time())
+
+	m.mu.Lock()
+	if n := len(m.samples); n == 0 || m.samples[n-1].height < height {
+		m.samples = append(m.samples, sample{height: height, time: head})
+		if len(m.samples) > m.window {
+			m.samples = m.samples[len(m.samples)-m.window:]
+		}
+	}
+	m.age = age
+
+	wasStalled, stalled := m.stalled, age > m.threshold
+	m.stalled = stalled
+	m.mu.Unlock()
+
+	switch {
+	case stalled && !wasStalled:
+		e := &Event{Kind: Stalled, Height: height, Head: head, Age: age}
+
+		// The lag is only a hint of the cause, so the stall is reported even if the node
+		// info cannot be read.
+		if info, err := m.client.GetNodeInfo(); err == nil {
+			e.Lag = info.Lag()
+		}
+		return e, nil
+	case !stalled && wasStalled:
+		return &Event{Kind: Recovered, Height: height, Head: head, Age: age}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// AverageInterval returns the average interval between the blocks seen in the window, zero
+// until two blocks have been seen.
+func (m *Monitor) AverageInterval() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.samples) < 2 {
+		return 0
+	}
+
+	first, last := m.samples[0], m.samples[len(m.samples)-1]
+	elapsed := last.time.Time().Sub(first.time.Time())
+	return elapsed / time.Duration(last.height-first.height)
+}
+
+// Status reports the liveness of the chain as of the last poll, for health.Handler. Production
+// is healthy unless it is stalled.
+func (m *Monitor) Status() health.Status {
+	interval := m.AverageInterval()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	details := map[string]interface{}{
+		"averageInterval": interval.String(),
+		"latestBlockAge":  m.age.Round(time.Millisecond).String(),
+	}
+	if n := len(m.samples); n > 0 {
+		details["height"] = m.samples[n-1].height
+	}
+
+	if m.stalled {
+		return health.Status{Healthy: false, Message: "block production stalled", Details: details}
+	}
+	return health.Status{Healthy: true, Details: details}
+}
+
+// Run polls every interval until the context is done, calling alert for each event and
+// onError, if not nil, when a poll fails.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, alert func(Event), onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e, err := m.Poll()
+		if e != nil {
+			alert(*e)
+		}
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Package marketplace constructs and signs NFT marketplace orders as TIP-712 typed data, so
+// that listings and bids can be made programmatically.
+package marketplace
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/tip712"
+)
+
+// Side is whether an order buys or sells.
+type Side uint8
+
+const (
+	Buy  Side = 0
+	Sell Side = 1
+)
+
+// SaleKind is how the price of an order is determined.
+type SaleKind uint8
+
+const (
+	// FixedPrice orders are filled at their base price.
+	FixedPrice SaleKind = 0
+
+	// DutchAuction orders start at their base price, which decreases by Extra over the
+	// lifetime of the order.
+	DutchAuction SaleKind = 1
+)
+
+// Order is an offer to buy or sell a single TRC721 token.
+type Order struct {
+	// Exchange is the marketplace contract which fills the order, it verifies signatures.
+	Exchange address.Address
+
+	Maker address.Address
+
+	// Taker is the only address which may fill the order, or the zero address for anyone.
+	Taker address.Address
+
+	Side     Side
+	SaleKind SaleKind
+
+	Collection address.Address
+	TokenId    *big.Int
+
+	// PaymentToken is the TRC20 token the price is paid in, or the zero address for TRX.
+	PaymentToken address.Address
+	BasePrice    *big.Int
+	Extra        *big.Int
+
+	// FeeRecipient receives the marketplace fee, in basis points of the price.
+	FeeRecipient address.Address
+	Fee          *big.Int
+
+	// ListingTime and ExpirationTime are in seconds since the epoch, an expiration time of
+	// zero never expires.
+	ListingTime    int64
+	ExpirationTime int64
+
+	// Salt makes otherwise identical orders distinct.
+	Salt *big.Int
+}
+
+// Format describes how a marketplace represents orders as typed data. Marketplaces each define
+// their own order struct, a format with their domain and type definitions is needed to sign
+// orders they will accept.
+type Format struct {
+	// Name and Version are those of the marketplace's TIP-712 domain.
+	Name    string
+	Version string
+
+	Types       tip712.Types
+	PrimaryType string
+
+	// Values returns the message for an order.
+	Values func(o *Order) map[string]interface{}
+}
+
+// DefaultFormat is a Wyvern style order, as used by marketplaces derived from the Wyvern
+// exchange, which covers fixed price and dutch auction listings and bids of a single token.
+var DefaultFormat = Format{
+	Name:    "Wyvern Exchange Contract",
+	Version: "2.3",
+	Types: tip712.Types{
+		"Order": {
+			{Name: "exchange", Type: "address"},
+			{Name: "maker", Type: "address"},
+			{Name: "taker", Type: "address"},
+			{Name: "feeRecipient", Type: "address"},
+			{Name: "fee", Type: "uint256"},
+			{Name: "side", Type: "uint8"},
+			{Name: "saleKind", Type: "uint8"},
+			{Name: "collection", Type: "address"},
+			{Name: "tokenId", Type: "uint256"},
+			{Name: "paymentToken", Type: "address"},
+			{Name: "basePrice", Type: "uint256"},
+			{Name: "extra", Type: "uint256"},
+			{Name: "listingTime", Type: "uint256"},
+			{Name: "expirationTime", Type: "uint256"},
+			{Name: "salt", Type: "uint256"},
+		},
+	},
+	PrimaryType: "Order",
+	Values: func(o *Order) map[string]interface{} {
+		return map[string]interface{}{
+			"exchange":       o.Exchange,
+			"maker":          o.Maker,
+			"taker":          o.Taker,
+			"feeRecipient":   o.FeeRecipient,
+			"fee":            orZero(o.Fee),
+			"side":           int(o.Side),
+			"saleKind":       int(o.SaleKind),
+			"collection":     o.Collection,
+			"tokenId":        orZero(o.TokenId),
+			"paymentToken":   o.PaymentToken,
+			"basePrice":      orZero(o.BasePrice),
+			"extra":          orZero(o.Extra),
+			"listingTime":    o.ListingTime,
+			"expirationTime": o.ExpirationTime,
+			"salt":           orZero(o.Salt),
+		}
+	},
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
+
+// NewListing creates a fixed price order selling a token, listed now and expiring after the
+// provided duration. A duration of zero never expires.
+func NewListing(exchange, maker, collection address.Address, tokenId, price *big.Int, paymentToken address.Address, duration time.Duration) (*Order, error) {
+	return newOrder(Sell, exchange, maker, collection, tokenId, price, paymentToken, duration)
+}
+
+// NewBid creates a fixed price order buying a token, made now and expiring after the provided
+// duration. A duration of zero never expires.
+func NewBid(exchange, maker, collection address.Address, tokenId, price *big.Int, paymentToken address.Address, duration time.Duration) (*Order, error) {
+	return newOrder(Buy, exchange, maker, collection, tokenId, price, paymentToken, duration)
+}
+
+func newOrder(side Side, exchange, maker, collection address.Address, tokenId, price *big.Int, paymentToken address.Address, duration time.Duration) (*Order, error) {
+	salt, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 256))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	o := &Order{
+		Exchange:     exchange,
+		Maker:        maker,
+		Side:         side,
+		SaleKind:     FixedPrice,
+		Collection:   collection,
+		TokenId:      tokenId,
+		PaymentToken: paymentToken,
+		BasePrice:    price,
+		ListingTime:  now.Unix(),
+		Salt:         salt,
+	}
+
+	if duration > 0 {
+		o.ExpirationTime = now.Add(duration).Unix()
+	}
+
+	return o, nil
+}
+
+// TypedData returns the order as typed data for the network with the provided chain id.
+func (f Format) TypedData(chainId int64, o *Order) *tip712.TypedData {
+	return &tip712.TypedData{
+		Types:       f.Types,
+		PrimaryType: f.PrimaryType,
+		Domain: tip712.Domain{
+			Name:              f.Name,
+			Version:           f.Version,
+			ChainId:           big.NewInt(chainId),
+			VerifyingContract: o.Exchange,
+		},
+		Message: f.Values(o),
+	}
+}
+
+// SignedOrder is an order along with the maker's signature.
+type SignedOrder struct {
+	Order     *Order
+	Hash      []byte
+	Signature []byte
+}
+
+// Sign signs an order with the maker's account for the network with the provided chain id,
+// see params for the chain ids of well-known networks.
+func (f Format) Sign(signer tron.Signer, chainId int64, o *Order) (*SignedOrder, error) {
+	sig, err := tip712.NewSignature(f.TypedData(chainId, o))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signer.Sign(sig); err != nil {
+		return nil, err
+	}
+
+	return &SignedOrder{Order: o, Hash: sig.Hash, Signature: sig.Signature}, nil
+}
@@ -0,0 +1,219 @@
+// Package names resolves TRON Name Service (TNS) names such as "alice.trx" to addresses, and
+// addresses back to their primary names. TNS follows ENS: a registry contract maps the hash of
+// each name to a resolver contract, which holds the records of the name.
+package names
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Suffix is the top level domain of TNS names.
+const Suffix = ".trx"
+
+var (
+	resolverFunction = abi.Function{
+		Name:       "resolver",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "node", Type: abi.TypeBytes32}},
+	}
+
+	addrFunction = abi.Function{
+		Name:       "addr",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "node", Type: abi.TypeBytes32}},
+	}
+
+	nameFunction = abi.Function{
+		Name:       "name",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "node", Type: abi.TypeBytes32}},
+	}
+)
+
+// NotFoundError is returned when a name has no address, or an address has no name.
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("names: %s not found", e.Name)
+}
+
+// Hash returns the namehash of a name, the key under which the registry stores it. Labels are
+// lower cased, full UTS-46 normalization is not performed.
+func Hash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		copy(node[:], crypto.Keccak256(node[:], crypto.Keccak256([]byte(labels[i]))))
+	}
+
+	return node
+}
+
+// IsName returns whether a string is a TNS name rather than an address.
+func IsName(str string) bool {
+	return strings.HasSuffix(strings.ToLower(str), Suffix) && len(str) > len(Suffix)
+}
+
+// Resolver resolves names with the TNS registry.
+type Resolver struct {
+	client   *client.Client
+	registry address.Address
+}
+
+// New creates a resolver for the registry contract deployed at registry.
+func New(c *client.Client, registry address.Address) *Resolver {
+	return &Resolver{
+		client:   c,
+		registry: registry,
+	}
+}
+
+// Resolve returns the address a name resolves to.
+func (r *Resolver) Resolve(name string) (address.Address, error) {
+	node := Hash(name)
+
+	resolver, err := r.resolver(node)
+	if err != nil {
+		return address.Zero, err
+	}
+	if resolver == address.Zero {
+		return address.Zero, &NotFoundError{Name: name}
+	}
+
+	addr, err := r.callAddress(resolver, addrFunction, node)
+	if err != nil {
+		return address.Zero, err
+	}
+	if addr == address.Zero {
+		return address.Zero, &NotFoundError{Name: name}
+	}
+
+	return addr, nil
+}
+
+// Lookup returns the primary name of an address, as set by its reverse record. The name is
+// only returned if it resolves back to the address, as anyone can claim any name in their
+// reverse record.
+func (r *Resolver) Lookup(addr address.Address) (string, error) {
+	reverse := hex.EncodeToString(addr[1:]) + ".addr.reverse"
+	node := Hash(reverse)
+
+	resolver, err := r.resolver(node)
+	if err != nil {
+		return "", err
+	}
+	if resolver == address.Zero {
+		return "", &NotFoundError{Name: reverse}
+	}
+
+	result, err := r.call(resolver, nameFunction, node)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := decodeString(result)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", &NotFoundError{Name: reverse}
+	}
+
+	forward, err := r.Resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if forward != addr {
+		return "", &NotFoundError{Name: reverse}
+	}
+
+	return name, nil
+}
+
+// Parse parses an address from its base 16 or base 58 encoding, or resolves it if it is a
+// name, so that names can be accepted wherever addresses are.
+func (r *Resolver) Parse(str string) (address.Address, error) {
+	if IsName(str) {
+		return r.Resolve(str)
+	}
+	return address.Parse(str)
+}
+
+// resolver returns the resolver of a node, or the zero address if it has none.
+func (r *Resolver) resolver(node [32]byte) (address.Address, error) {
+	return r.callAddress(r.registry, resolverFunction, node)
+}
+
+// callAddress calls a function of a contract which returns an address.
+func (r *Resolver) callAddress(contract address.Address, fn abi.Function, node [32]byte) (address.Address, error) {
+	result, err := r.call(contract, fn, node)
+	if err != nil {
+		return address.Zero, err
+	}
+
+	if len(result) < 32 {
+		return address.Zero, errors.New("names: malformed address result")
+	}
+
+	var evm [20]byte
+	copy(evm[:], result[12:32])
+	if evm == [20]byte{} {
+		return address.Zero, nil
+	}
+
+	var addr address.Address
+	addr[0] = params.AddressPrefix
+	copy(addr[1:], evm[:])
+	return addr, nil
+}
+
+// call calls a function of a contract with a node and returns its raw result.
+func (r *Resolver) call(contract address.Address, fn abi.Function, node [32]byte) ([]byte, error) {
+	results, err := r.client.TriggerSmartContract(account.WatchOnly(r.registry), client.CallContractInput{
+		Address:   contract,
+		Function:  fn,
+		Arguments: []interface{}{new(big.Int).SetBytes(node[:])},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(results[0])
+}
+
+// decodeString decodes an ABI encoded string return value.
+func decodeString(bs []byte) (string, error) {
+	if len(bs) < 64 {
+		return "", errors.New("names: malformed string result")
+	}
+
+	offset := new(big.Int).SetBytes(bs[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(bs)) {
+		return "", errors.New("names: malformed string result")
+	}
+
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(bs[start-32 : start])
+	if !length.IsUint64() || length.Uint64() > uint64(len(bs))-start {
+		return "", errors.New("names: malformed string result")
+	}
+
+	return string(bs[start : start+length.Uint64()]), nil
+}
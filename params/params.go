@@ -0,0 +1,73 @@
+// Package params defines well-known values of the Tron protocol, so that they are named once
+// and shared by the packages which depend on them.
+package params
+
+import "time"
+
+// Sun is an amount of TRX in its smallest unit.
+type Sun int64
+
+const (
+	// SunPerTRX is the number of sun in one TRX.
+	SunPerTRX Sun = 1000000
+
+	// MaxFeeLimit is the largest fee limit a transaction may set on mainnet. It is governed
+	// by the getMaxFeeLimit chain parameter, so the node is authoritative if it changes.
+	MaxFeeLimit = 15000 * SunPerTRX
+)
+
+const (
+	// BlockInterval is the time between blocks.
+	BlockInterval = 3 * time.Second
+
+	// MaxExpiration is the furthest in the future that a node will accept a transaction
+	// expiring.
+	MaxExpiration = 24 * time.Hour
+
+	// DefaultExpiration is how long after being created transactions expire by default, as
+	// with transactions created by the node.
+	DefaultExpiration = time.Minute
+
+	// RefBlockWindow is the number of most recent blocks that a transaction may reference.
+	RefBlockWindow = 65536
+)
+
+const (
+	// AddressPrefix is the first byte of every address, so that in base 58 they start with 'T'.
+	AddressPrefix byte = 0x41
+
+	// AddressLength is the number of bytes of an address, including its prefix.
+	AddressLength = 21
+
+	// AddressBase16Length and AddressBase58Length are the lengths of the encoded forms of an
+	// address.
+	AddressBase16Length = 2 * AddressLength
+	AddressBase58Length = 34
+)
+
+const (
+	// SignatureLength is the size of a recoverable secp256k1 signature.
+	SignatureLength = 65
+
+	// MaxTransactionSize is the largest serialized transaction that a node will accept.
+	MaxTransactionSize = 500 * 1024
+
+	// MaxVoteWitnesses is the most witnesses that a single vote transaction may vote for.
+	MaxVoteWitnesses = 30
+)
+
+// P2P versions identify the network a node belongs to, they are reported by the node as
+// p2pVersion.
+const (
+	MainnetP2PVersion = 11111
+	ShastaP2PVersion  = 1
+	NileP2PVersion    = 201910292
+)
+
+// Chain ids identify the network in TIP-712 domains, they are the last four bytes of the id of
+// the network's genesis block.
+const (
+	MainnetChainId = 0x2b6653dc
+	ShastaChainId  = 0x94a9059e
+	NileChainId    = 0xcd8690dc
+)
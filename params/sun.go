@@ -0,0 +1,43 @@
+package params
+
+import (
+	"math/big"
+
+	"github.com/go-chain/go-tron/units"
+)
+
+// TRXDecimals is the number of decimal places of TRX amounts, so one TRX is SunPerTRX sun.
+const TRXDecimals = 6
+
+// TRXSymbol is the symbol of TRX.
+const TRXSymbol = "TRX"
+
+// Amount returns the amount of sun as a fixed-point amount of TRX.
+func (s Sun) Amount() units.Amount {
+	return units.FromInt64(int64(s), TRXDecimals, TRXSymbol)
+}
+
+// TRX formats the amount of sun as a decimal string of TRX, such as "12.5" for 12500000.
+func (s Sun) TRX() string {
+	return units.Format(big.NewInt(int64(s)), TRXDecimals)
+}
+
+// String formats the amount of sun in TRX with its symbol, such as "12.5 TRX".
+func (s Sun) String() string {
+	return s.TRX() + " " + TRXSymbol
+}
+
+// ParseTRX parses a decimal string of TRX, such as "12.5" or "12.5 TRX", into sun. More than
+// six decimal places is an error rather than being rounded.
+func ParseTRX(str string) (Sun, error) {
+	amount, err := units.Parse(str, TRXDecimals, TRXSymbol)
+	if err != nil {
+		return 0, err
+	}
+
+	sun, err := amount.Int64()
+	if err != nil {
+		return 0, err
+	}
+	return Sun(sun), nil
+}
@@ -0,0 +1,75 @@
+package payouts
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// Ledger is the amount of each token reserved by authorized payouts which have not been
+// captured or voided. A ledger is safe for concurrent use and may be shared by the payouts of
+// several accounts.
+type Ledger struct {
+	mu       sync.Mutex
+	reserved map[reservationKey]*big.Int
+}
+
+// reservationKey identifies the balance of a token, address.Zero for TRX, held by an account.
+type reservationKey struct {
+	account address.Address
+	token   address.Address
+}
+
+// NewLedger creates an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{reserved: make(map[reservationKey]*big.Int)}
+}
+
+// Reserved returns the amount of a token, address.Zero for TRX, reserved from the balance of
+// an account.
+func (l *Ledger) Reserved(account, token address.Address) *big.Int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n, ok := l.reserved[reservationKey{account, token}]; ok {
+		return new(big.Int).Set(n)
+	}
+	return new(big.Int)
+}
+
+// reserve reserves an amount if the balance less what is already reserved covers it, and
+// returns whether it did.
+func (l *Ledger) reserve(account, token address.Address, amount, balance *big.Int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := reservationKey{account, token}
+	n, ok := l.reserved[key]
+	if !ok {
+		n = new(big.Int)
+	}
+
+	if new(big.Int).Add(n, amount).Cmp(balance) > 0 {
+		return false
+	}
+
+	l.reserved[key] = n.Add(n, amount)
+	return true
+}
+
+// release releases an amount reserved by reserve.
+func (l *Ledger) release(account, token address.Address, amount *big.Int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := reservationKey{account, token}
+	n, ok := l.reserved[key]
+	if !ok {
+		return
+	}
+
+	if n.Sub(n, amount).Sign() <= 0 {
+		delete(l.reserved, key)
+	}
+}
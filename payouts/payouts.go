@@ -0,0 +1,395 @@
+// Package payouts makes payouts in two phases, as card payments are: a payout is authorized,
+// which checks it against policies and reserves the amount from the balance of the paying
+// account, and later captured, which broadcasts it, or voided, which releases the amount.
+// Reservations are kept in a Ledger until the transaction of a captured payout is confirmed or
+// expires, so that payouts authorized but not yet spent cannot together spend more than the
+// balance.
+package payouts
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/trc20"
+)
+
+// DefaultExpiry is how long an authorization lasts before it is voided, see WithExpiry.
+const DefaultExpiry = time.Hour
+
+var (
+	// ErrNotFound is returned when capturing or voiding an authorization which does not exist,
+	// has expired or has already been captured or voided.
+	ErrNotFound = errors.New("payouts: authorization not found")
+
+	// ErrInsufficientFunds is returned when the balance of the paying account, less the
+	// amounts reserved by other authorizations, does not cover a payout.
+	ErrInsufficientFunds = errors.New("payouts: insufficient funds")
+)
+
+// Payout is an amount of a token to pay to an address.
+type Payout struct {
+	// Id identifies the payout, typically the id of the payment in the upstream system. An
+	// authorization has the id of its payout.
+	Id string
+
+	// Token is the address of a TRC20 contract, or address.Zero for TRX.
+	Token address.Address
+
+	To address.Address
+
+	// Amount is in the smallest unit of the token, sun for TRX.
+	Amount *big.Int
+}
+
+// Policy checks whether a payout may be made, returning why not if it may not.
+type Policy func(Payout) error
+
+// MaxAmount is a policy which refuses payouts of a token above an amount.
+func MaxAmount(token address.Address, max *big.Int) Policy {
+	return func(p Payout) error {
+		if p.Token == token && p.Amount.Cmp(max) > 0 {
+			return fmt.Errorf("payouts: amount %s is above the limit of %s", p.Amount, max)
+		}
+		return nil
+	}
+}
+
+// Authorization is an authorized payout, whose amount is reserved until it is voided or
+// expires, or its transaction is confirmed or expires.
+type Authorization struct {
+	Payout  Payout
+	Expires time.Time
+
+	// Transaction is the signed transaction of the payout, created by the first attempt to
+	// capture it. Later attempts broadcast this transaction rather than creating another, so
+	// that the payout cannot be made twice.
+	Transaction *tron.Transaction
+
+	// txExpires is when the transaction expires, after which it cannot be included in a block.
+	txExpires time.Time
+}
+
+// Option configures optional behaviour of payouts.
+type Option func(*Payouts)
+
+// WithPolicies sets the policies which payouts are checked against when authorized.
+func WithPolicies(policies ...Policy) Option {
+	return func(p *Payouts) {
+		p.policies = append(p.policies, policies...)
+	}
+}
+
+// WithLedger sets the ledger amounts are reserved in, so that it can be shared by several
+// Payouts paying from the same account. By default each Payouts has a ledger of its own.
+func WithLedger(l *Ledger) Option {
+	return func(p *Payouts) {
+		p.ledger = l
+	}
+}
+
+// WithExpiry sets how long authorizations last, the default is DefaultExpiry.
+func WithExpiry(d time.Duration) Option {
+	return func(p *Payouts) {
+		p.expiry = d
+	}
+}
+
+// Payouts authorizes and captures payouts from an account. Payouts are safe for concurrent
+// use.
+type Payouts struct {
+	client   *client.Client
+	from     account.Account
+	policies []Policy
+	ledger   *Ledger
+	expiry   time.Duration
+
+	mu    sync.Mutex
+	auths map[string]*Authorization
+
+	// capturing are the ids of authorizations being captured, which cannot be voided.
+	capturing map[string]bool
+
+	// unsettled are the authorizations whose transaction may have been broadcast but is not
+	// yet known to be confirmed or expired, and whose amount therefore stays reserved.
+	unsettled map[string]*Authorization
+}
+
+// New creates payouts from an account.
+func New(c *client.Client, from account.Account, opts ...Option) *Payouts {
+	p := &Payouts{
+		client:    c,
+		from:      from,
+		expiry:    DefaultExpiry,
+		auths:     make(map[string]*Authorization),
+		capturing: make(map[string]bool),
+		unsettled: make(map[string]*Authorization),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.ledger == nil {
+		p.ledger = NewLedger()
+	}
+
+	return p
+}
+
+// Authorize checks a payout against the policies and reserves its amount from the balance of
+// the paying account. Authorizing a payout again with the same id returns the existing
+// authorization if it is for the same payout. ErrInsufficientFunds is returned if the balance
+// does not cover the payout; fees are not reserved.
+func (p *Payouts) Authorize(payout Payout) (*Authorization, error) {
+	if payout.Id == "" {
+		return nil, errors.New("payouts: payout has no id")
+	}
+	if payout.Amount == nil || payout.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("payouts: amount must be positive (%s)", payout.Amount)
+	}
+
+	for _, policy := range p.policies {
+		if err := policy(payout); err != nil {
+			return nil, err
+		}
+	}
+
+	p.expire()
+	p.settle()
+
+	p.mu.Lock()
+	if _, ok := p.unsettled[payout.Id]; ok {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("payouts: payout %s has already been captured", payout.Id)
+	}
+	if auth, ok := p.auths[payout.Id]; ok {
+		p.mu.Unlock()
+		if !samePayout(auth.Payout, payout) {
+			return nil, fmt.Errorf("payouts: payout %s is already authorized with other details", payout.Id)
+		}
+		return auth, nil
+	}
+	p.mu.Unlock()
+
+	balance, err := p.balance(payout.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.ledger.reserve(p.from.Address(), payout.Token, payout.Amount, balance) {
+		return nil, ErrInsufficientFunds
+	}
+
+	auth := &Authorization{
+		Payout:  payout,
+		Expires: time.Now().Add(p.expiry),
+	}
+	auth.Payout.Amount = new(big.Int).Set(payout.Amount)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another authorization of the same id may have been made while the balance was read.
+	if existing, ok := p.auths[payout.Id]; ok {
+		p.ledger.release(p.from.Address(), payout.Token, payout.Amount)
+		if !samePayout(existing.Payout, payout) {
+			return nil, fmt.Errorf("payouts: payout %s is already authorized with other details", payout.Id)
+		}
+		return existing, nil
+	}
+
+	p.auths[payout.Id] = auth
+	return auth, nil
+}
+
+// Capture broadcasts the transaction of an authorized payout, creating and signing it on the
+// first attempt. If it cannot be broadcast the payout stays authorized, and capturing it again
+// broadcasts the same transaction. Once broadcast the payout is no longer authorized, but its
+// amount stays reserved until the transaction is confirmed or expires.
+func (p *Payouts) Capture(id string) (tron.Transaction, error) {
+	p.expire()
+
+	p.mu.Lock()
+	auth, ok := p.auths[id]
+	if !ok || p.capturing[id] {
+		p.mu.Unlock()
+		return tron.Transaction{}, ErrNotFound
+	}
+	p.capturing[id] = true
+	tx := auth.Transaction
+	p.mu.Unlock()
+
+	var err error
+	if tx == nil {
+		var expires time.Time
+		if tx, expires, err = p.create(auth.Payout); err == nil {
+			p.mu.Lock()
+			auth.Transaction, auth.txExpires = tx, expires
+			p.mu.Unlock()
+		}
+	}
+	if err == nil {
+		err = p.broadcast(tx)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.capturing, id)
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+
+	delete(p.auths, id)
+	p.unsettled[id] = auth
+
+	return *tx, nil
+}
+
+// Void cancels an authorized payout and releases its reservation. If capturing it created a
+// transaction, which may have been broadcast, the reservation is kept until the transaction
+// is confirmed or expires.
+func (p *Payouts) Void(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.auths[id]
+	if !ok || p.capturing[id] {
+		return ErrNotFound
+	}
+
+	p.drop(id, auth)
+
+	return nil
+}
+
+// Authorizations returns the payouts which are authorized and not yet captured or voided.
+func (p *Payouts) Authorizations() []Authorization {
+	p.expire()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auths := make([]Authorization, 0, len(p.auths))
+	for _, auth := range p.auths {
+		auths = append(auths, *auth)
+	}
+	return auths
+}
+
+// expire voids the authorizations which have expired.
+func (p *Payouts) expire() {
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, auth := range p.auths {
+		if now.After(auth.Expires) && !p.capturing[id] {
+			p.drop(id, auth)
+		}
+	}
+}
+
+// drop removes an authorization which is voided or has expired, releasing its reservation
+// unless it has a transaction which has yet to settle. The lock must be held.
+func (p *Payouts) drop(id string, auth *Authorization) {
+	delete(p.auths, id)
+
+	if auth.Transaction != nil {
+		p.unsettled[id] = auth
+		return
+	}
+	p.ledger.release(p.from.Address(), auth.Payout.Token, auth.Payout.Amount)
+}
+
+// settle releases the reservations of unsettled transactions which have been confirmed, and
+// are then spent from the balance, or have expired, and so can no longer be.
+func (p *Payouts) settle() {
+	p.mu.Lock()
+	auths := make(map[string]*Authorization, len(p.unsettled))
+	for id, auth := range p.unsettled {
+		auths[id] = auth
+	}
+	p.mu.Unlock()
+
+	for id, auth := range auths {
+		// A block including the transaction may be produced up until it expires.
+		if time.Now().Before(auth.txExpires.Add(params.BlockInterval)) {
+			info, err := p.client.TransactionInfoById(auth.Transaction.Id)
+			if err != nil || info == nil {
+				continue
+			}
+		}
+
+		p.mu.Lock()
+		if p.unsettled[id] == auth {
+			delete(p.unsettled, id)
+			p.ledger.release(p.from.Address(), auth.Payout.Token, auth.Payout.Amount)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// balance returns the balance of a token held by the paying account.
+func (p *Payouts) balance(token address.Address) (*big.Int, error) {
+	if token != address.Zero {
+		return trc20.New(p.client, token).BalanceOf(p.from.Address())
+	}
+
+	state, err := p.client.GetAccountState(p.from.Address())
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return new(big.Int), nil
+	}
+	return big.NewInt(int64(state.Balance)), nil
+}
+
+// create creates and signs the transaction of a payout, returning when it expires.
+func (p *Payouts) create(payout Payout) (*tron.Transaction, time.Time, error) {
+	var tx tron.Transaction
+	var err error
+	if payout.Token == address.Zero {
+		if !payout.Amount.IsInt64() {
+			return nil, time.Time{}, fmt.Errorf("payouts: amount %s is too large", payout.Amount)
+		}
+		tx, err = p.client.Transfer(p.from, payout.To, params.Sun(payout.Amount.Int64()))
+	} else {
+		tx, err = trc20.New(p.client, payout.Token).Transfer(p.from, payout.To, payout.Amount, 0)
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	m, err := pb.FromTransaction(&tx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return &tx, tron.Time(m.RawData.Expiration).Time(), nil
+}
+
+// broadcast broadcasts the transaction of a payout. A transaction the node already has was
+// broadcast by an earlier attempt whose response was lost, and is not an error.
+func (p *Payouts) broadcast(tx *tron.Transaction) error {
+	err := p.client.BroadcastTransaction(tx)
+	if e, ok := err.(*client.BroadcastError); ok && e.Code == client.BroadcastDupTransactionError {
+		return nil
+	}
+	return err
+}
+
+func samePayout(a, b Payout) bool {
+	return a.Id == b.Id && a.Token == b.Token && a.To == b.To && a.Amount.Cmp(b.Amount) == 0
+}
@@ -0,0 +1,285 @@
+package pb
+
+// AccountCreateContract mirrors protocol.AccountCreateContract.
+type AccountCreateContract struct {
+	OwnerAddress   []byte
+	AccountAddress []byte
+	Type           AccountType
+}
+
+func (m *AccountCreateContract) ContractType() ContractType {
+	return AccountCreateContractType
+}
+
+func (m *AccountCreateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.AccountAddress)
+	e.int64(3, int64(m.Type))
+	return e.buf
+}
+
+func (m *AccountCreateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.AccountAddress, err = d.bytes()
+		case 3:
+			var v int64
+			v, err = d.int64()
+			m.Type = AccountType(v)
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AccountUpdateContract mirrors protocol.AccountUpdateContract.
+type AccountUpdateContract struct {
+	AccountName  []byte
+	OwnerAddress []byte
+}
+
+func (m *AccountUpdateContract) ContractType() ContractType {
+	return AccountUpdateContractType
+}
+
+func (m *AccountUpdateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.AccountName)
+	e.bytes(2, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *AccountUpdateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.AccountName, err = d.bytes()
+		case 2:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// SetAccountIdContract mirrors protocol.SetAccountIdContract.
+type SetAccountIdContract struct {
+	AccountId    []byte
+	OwnerAddress []byte
+}
+
+func (m *SetAccountIdContract) ContractType() ContractType {
+	return SetAccountIdContractType
+}
+
+func (m *SetAccountIdContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.AccountId)
+	e.bytes(2, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *SetAccountIdContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.AccountId, err = d.bytes()
+		case 2:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AccountPermissionUpdateContract mirrors protocol.AccountPermissionUpdateContract.
+type AccountPermissionUpdateContract struct {
+	OwnerAddress []byte
+	Owner        *Permission
+	Witness      *Permission
+	Actives      []*Permission
+}
+
+func (m *AccountPermissionUpdateContract) ContractType() ContractType {
+	return AccountPermissionUpdateContractType
+}
+
+func (m *AccountPermissionUpdateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	if m.Owner != nil {
+		e.message(2, m.Owner)
+	}
+	if m.Witness != nil {
+		e.message(3, m.Witness)
+	}
+	for _, v := range m.Actives {
+		e.message(4, v)
+	}
+	return e.buf
+}
+
+func (m *AccountPermissionUpdateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.Owner = new(Permission)
+			err = d.message(m.Owner)
+		case 3:
+			m.Witness = new(Permission)
+			err = d.message(m.Witness)
+		case 4:
+			v := new(Permission)
+			if err = d.message(v); err == nil {
+				m.Actives = append(m.Actives, v)
+			}
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Permission mirrors protocol.Permission.
+type Permission struct {
+	Type           PermissionType
+	Id             int64
+	PermissionName string
+	Threshold      int64
+	ParentId       int64
+	Operations     []byte
+	Keys           []*Key
+}
+
+func (m *Permission) Marshal() []byte {
+	var e encoder
+	e.int64(1, int64(m.Type))
+	e.int64(2, m.Id)
+	e.string(3, m.PermissionName)
+	e.int64(4, m.Threshold)
+	e.int64(5, m.ParentId)
+	e.bytes(6, m.Operations)
+	for _, v := range m.Keys {
+		e.message(7, v)
+	}
+	return e.buf
+}
+
+func (m *Permission) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			var v int64
+			v, err = d.int64()
+			m.Type = PermissionType(v)
+		case 2:
+			m.Id, err = d.int64()
+		case 3:
+			m.PermissionName, err = d.string()
+		case 4:
+			m.Threshold, err = d.int64()
+		case 5:
+			m.ParentId, err = d.int64()
+		case 6:
+			m.Operations, err = d.bytes()
+		case 7:
+			v := new(Key)
+			if err = d.message(v); err == nil {
+				m.Keys = append(m.Keys, v)
+			}
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Key mirrors protocol.Key.
+type Key struct {
+	Address []byte
+	Weight  int64
+}
+
+func (m *Key) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.Address)
+	e.int64(2, m.Weight)
+	return e.buf
+}
+
+func (m *Key) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Address, err = d.bytes()
+		case 2:
+			m.Weight, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
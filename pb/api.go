@@ -0,0 +1,318 @@
+package pb
+
+// EmptyMessage mirrors protocol.EmptyMessage.
+type EmptyMessage struct{}
+
+func (m *EmptyMessage) Marshal() []byte {
+	return nil
+}
+
+func (m *EmptyMessage) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		_, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		if err := d.skip(wire); err != nil {
+			return err
+		}
+	}
+}
+
+// NumberMessage mirrors protocol.NumberMessage.
+type NumberMessage struct {
+	Num int64
+}
+
+func (m *NumberMessage) Marshal() []byte {
+	var e encoder
+	e.int64(1, m.Num)
+	return e.buf
+}
+
+func (m *NumberMessage) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Num, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BytesMessage mirrors protocol.BytesMessage.
+type BytesMessage struct {
+	Value []byte
+}
+
+func (m *BytesMessage) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.Value)
+	return e.buf
+}
+
+func (m *BytesMessage) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Value, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BlockReq mirrors protocol.BlockReq.
+type BlockReq struct {
+	IdOrNum string
+	Detail  bool
+}
+
+func (m *BlockReq) Marshal() []byte {
+	var e encoder
+	e.string(1, m.IdOrNum)
+	e.bool(2, m.Detail)
+	return e.buf
+}
+
+func (m *BlockReq) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.IdOrNum, err = d.string()
+		case 2:
+			m.Detail, err = d.bool()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ReturnCode mirrors protocol.Return.response_code.
+type ReturnCode int64
+
+const (
+	ReturnSuccess                      ReturnCode = 0
+	ReturnSigError                     ReturnCode = 1
+	ReturnContractValidateError        ReturnCode = 2
+	ReturnContractExeError             ReturnCode = 3
+	ReturnBandwidthError               ReturnCode = 4
+	ReturnDupTransactionError          ReturnCode = 5
+	ReturnTaposError                   ReturnCode = 6
+	ReturnTooBigTransactionError       ReturnCode = 7
+	ReturnTransactionExpirationError   ReturnCode = 8
+	ReturnServerBusy                   ReturnCode = 9
+	ReturnNoConnection                 ReturnCode = 10
+	ReturnNotEnoughEffectiveConnection ReturnCode = 11
+	ReturnBlockUnsolidified            ReturnCode = 12
+	ReturnOtherError                   ReturnCode = 20
+)
+
+var returnCodeNames = map[ReturnCode]string{
+	ReturnSuccess:                      "SUCCESS",
+	ReturnSigError:                     "SIGERROR",
+	ReturnContractValidateError:        "CONTRACT_VALIDATE_ERROR",
+	ReturnContractExeError:             "CONTRACT_EXE_ERROR",
+	ReturnBandwidthError:               "BANDWITH_ERROR",
+	ReturnDupTransactionError:          "DUP_TRANSACTION_ERROR",
+	ReturnTaposError:                   "TAPOS_ERROR",
+	ReturnTooBigTransactionError:       "TOO_BIG_TRANSACTION_ERROR",
+	ReturnTransactionExpirationError:   "TRANSACTION_EXPIRATION_ERROR",
+	ReturnServerBusy:                   "SERVER_BUSY",
+	ReturnNoConnection:                 "NO_CONNECTION",
+	ReturnNotEnoughEffectiveConnection: "NOT_ENOUGH_EFFECTIVE_CONNECTION",
+	ReturnBlockUnsolidified:            "BLOCK_UNSOLIDIFIED",
+	ReturnOtherError:                   "OTHER_ERROR",
+}
+
+// String returns the name of the code as it appears in the JSON APIs.
+func (c ReturnCode) String() string {
+	if name, ok := returnCodeNames[c]; ok {
+		return name
+	}
+	return "OTHER_ERROR"
+}
+
+// Return mirrors protocol.Return.
+type Return struct {
+	Result  bool
+	Code    ReturnCode
+	Message []byte
+}
+
+func (m *Return) Marshal() []byte {
+	var e encoder
+	e.bool(1, m.Result)
+	e.int64(2, int64(m.Code))
+	e.bytes(3, m.Message)
+	return e.buf
+}
+
+func (m *Return) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Result, err = d.bool()
+		case 2:
+			var v int64
+			v, err = d.int64()
+			m.Code = ReturnCode(v)
+		case 3:
+			m.Message, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TransactionExtention mirrors protocol.TransactionExtention, the spelling is the schema's.
+type TransactionExtention struct {
+	Transaction    *Transaction
+	Txid           []byte
+	ConstantResult [][]byte
+	Result         *Return
+	EnergyUsed     int64
+}
+
+func (m *TransactionExtention) Marshal() []byte {
+	var e encoder
+	if m.Transaction != nil {
+		e.message(1, m.Transaction)
+	}
+	e.bytes(2, m.Txid)
+	for _, r := range m.ConstantResult {
+		e.tag(3, wireBytes)
+		e.uvarint(uint64(len(r)))
+		e.buf = append(e.buf, r...)
+	}
+	if m.Result != nil {
+		e.message(4, m.Result)
+	}
+	e.int64(5, m.EnergyUsed)
+	return e.buf
+}
+
+func (m *TransactionExtention) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Transaction = new(Transaction)
+			err = d.message(m.Transaction)
+		case 2:
+			m.Txid, err = d.bytes()
+		case 3:
+			var r []byte
+			if r, err = d.bytes(); err == nil {
+				m.ConstantResult = append(m.ConstantResult, r)
+			}
+		case 4:
+			m.Result = new(Return)
+			err = d.message(m.Result)
+		case 5:
+			m.EnergyUsed, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BlockExtention mirrors protocol.BlockExtention, the spelling is the schema's.
+type BlockExtention struct {
+	Transactions []*TransactionExtention
+	BlockHeader  *BlockHeader
+	Blockid      []byte
+}
+
+func (m *BlockExtention) Marshal() []byte {
+	var e encoder
+	for _, tx := range m.Transactions {
+		e.message(1, tx)
+	}
+	if m.BlockHeader != nil {
+		e.message(2, m.BlockHeader)
+	}
+	e.bytes(3, m.Blockid)
+	return e.buf
+}
+
+func (m *BlockExtention) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			tx := new(TransactionExtention)
+			if err = d.message(tx); err == nil {
+				m.Transactions = append(m.Transactions, tx)
+			}
+		case 2:
+			m.BlockHeader = new(BlockHeader)
+			err = d.message(m.BlockHeader)
+		case 3:
+			m.Blockid, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
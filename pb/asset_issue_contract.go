@@ -0,0 +1,334 @@
+package pb
+
+// AssetIssueContract mirrors protocol.AssetIssueContract.
+type AssetIssueContract struct {
+	OwnerAddress            []byte
+	Name                    []byte
+	Abbr                    []byte
+	TotalSupply             int64
+	FrozenSupply            []*FrozenSupply
+	TrxNum                  int64
+	Precision               int64
+	Num                     int64
+	StartTime               int64
+	EndTime                 int64
+	Order                   int64
+	VoteScore               int64
+	Description             []byte
+	Url                     []byte
+	FreeAssetNetLimit       int64
+	PublicFreeAssetNetLimit int64
+	PublicFreeAssetNetUsage int64
+	PublicLatestFreeNetTime int64
+	Id                      string
+}
+
+func (m *AssetIssueContract) ContractType() ContractType {
+	return AssetIssueContractType
+}
+
+func (m *AssetIssueContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.Name)
+	e.bytes(3, m.Abbr)
+	e.int64(4, m.TotalSupply)
+	for _, v := range m.FrozenSupply {
+		e.message(5, v)
+	}
+	e.int64(6, m.TrxNum)
+	e.int64(7, m.Precision)
+	e.int64(8, m.Num)
+	e.int64(9, m.StartTime)
+	e.int64(10, m.EndTime)
+	e.int64(11, m.Order)
+	e.int64(16, m.VoteScore)
+	e.bytes(20, m.Description)
+	e.bytes(21, m.Url)
+	e.int64(22, m.FreeAssetNetLimit)
+	e.int64(23, m.PublicFreeAssetNetLimit)
+	e.int64(24, m.PublicFreeAssetNetUsage)
+	e.int64(25, m.PublicLatestFreeNetTime)
+	e.string(41, m.Id)
+	return e.buf
+}
+
+func (m *AssetIssueContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.Name, err = d.bytes()
+		case 3:
+			m.Abbr, err = d.bytes()
+		case 4:
+			m.TotalSupply, err = d.int64()
+		case 5:
+			v := new(FrozenSupply)
+			if err = d.message(v); err == nil {
+				m.FrozenSupply = append(m.FrozenSupply, v)
+			}
+		case 6:
+			m.TrxNum, err = d.int64()
+		case 7:
+			m.Precision, err = d.int64()
+		case 8:
+			m.Num, err = d.int64()
+		case 9:
+			m.StartTime, err = d.int64()
+		case 10:
+			m.EndTime, err = d.int64()
+		case 11:
+			m.Order, err = d.int64()
+		case 16:
+			m.VoteScore, err = d.int64()
+		case 20:
+			m.Description, err = d.bytes()
+		case 21:
+			m.Url, err = d.bytes()
+		case 22:
+			m.FreeAssetNetLimit, err = d.int64()
+		case 23:
+			m.PublicFreeAssetNetLimit, err = d.int64()
+		case 24:
+			m.PublicFreeAssetNetUsage, err = d.int64()
+		case 25:
+			m.PublicLatestFreeNetTime, err = d.int64()
+		case 41:
+			m.Id, err = d.string()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// FrozenSupply mirrors protocol.AssetIssueContract.FrozenSupply.
+type FrozenSupply struct {
+	FrozenAmount int64
+	FrozenDays   int64
+}
+
+func (m *FrozenSupply) Marshal() []byte {
+	var e encoder
+	e.int64(1, m.FrozenAmount)
+	e.int64(2, m.FrozenDays)
+	return e.buf
+}
+
+func (m *FrozenSupply) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.FrozenAmount, err = d.int64()
+		case 2:
+			m.FrozenDays, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TransferAssetContract mirrors protocol.TransferAssetContract.
+type TransferAssetContract struct {
+	AssetName    []byte
+	OwnerAddress []byte
+	ToAddress    []byte
+	Amount       int64
+}
+
+func (m *TransferAssetContract) ContractType() ContractType {
+	return TransferAssetContractType
+}
+
+func (m *TransferAssetContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.AssetName)
+	e.bytes(2, m.OwnerAddress)
+	e.bytes(3, m.ToAddress)
+	e.int64(4, m.Amount)
+	return e.buf
+}
+
+func (m *TransferAssetContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.AssetName, err = d.bytes()
+		case 2:
+			m.OwnerAddress, err = d.bytes()
+		case 3:
+			m.ToAddress, err = d.bytes()
+		case 4:
+			m.Amount, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UnfreezeAssetContract mirrors protocol.UnfreezeAssetContract.
+type UnfreezeAssetContract struct {
+	OwnerAddress []byte
+}
+
+func (m *UnfreezeAssetContract) ContractType() ContractType {
+	return UnfreezeAssetContractType
+}
+
+func (m *UnfreezeAssetContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *UnfreezeAssetContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateAssetContract mirrors protocol.UpdateAssetContract.
+type UpdateAssetContract struct {
+	OwnerAddress   []byte
+	Description    []byte
+	Url            []byte
+	NewLimit       int64
+	NewPublicLimit int64
+}
+
+func (m *UpdateAssetContract) ContractType() ContractType {
+	return UpdateAssetContractType
+}
+
+func (m *UpdateAssetContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.Description)
+	e.bytes(3, m.Url)
+	e.int64(4, m.NewLimit)
+	e.int64(5, m.NewPublicLimit)
+	return e.buf
+}
+
+func (m *UpdateAssetContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.Description, err = d.bytes()
+		case 3:
+			m.Url, err = d.bytes()
+		case 4:
+			m.NewLimit, err = d.int64()
+		case 5:
+			m.NewPublicLimit, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ParticipateAssetIssueContract mirrors protocol.ParticipateAssetIssueContract.
+type ParticipateAssetIssueContract struct {
+	OwnerAddress []byte
+	ToAddress    []byte
+	AssetName    []byte
+	Amount       int64
+}
+
+func (m *ParticipateAssetIssueContract) ContractType() ContractType {
+	return ParticipateAssetIssueContractType
+}
+
+func (m *ParticipateAssetIssueContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ToAddress)
+	e.bytes(3, m.AssetName)
+	e.int64(4, m.Amount)
+	return e.buf
+}
+
+func (m *ParticipateAssetIssueContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ToAddress, err = d.bytes()
+		case 3:
+			m.AssetName, err = d.bytes()
+		case 4:
+			m.Amount, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
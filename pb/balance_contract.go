@@ -0,0 +1,453 @@
+package pb
+
+// FreezeBalanceContract mirrors protocol.FreezeBalanceContract.
+type FreezeBalanceContract struct {
+	OwnerAddress    []byte
+	FrozenBalance   int64
+	FrozenDuration  int64
+	Resource        ResourceCode
+	ReceiverAddress []byte
+}
+
+func (m *FreezeBalanceContract) ContractType() ContractType {
+	return FreezeBalanceContractType
+}
+
+func (m *FreezeBalanceContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.FrozenBalance)
+	e.int64(3, m.FrozenDuration)
+	e.int64(10, int64(m.Resource))
+	e.bytes(15, m.ReceiverAddress)
+	return e.buf
+}
+
+func (m *FreezeBalanceContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.FrozenBalance, err = d.int64()
+		case 3:
+			m.FrozenDuration, err = d.int64()
+		case 10:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		case 15:
+			m.ReceiverAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UnfreezeBalanceContract mirrors protocol.UnfreezeBalanceContract.
+type UnfreezeBalanceContract struct {
+	OwnerAddress    []byte
+	Resource        ResourceCode
+	ReceiverAddress []byte
+}
+
+func (m *UnfreezeBalanceContract) ContractType() ContractType {
+	return UnfreezeBalanceContractType
+}
+
+func (m *UnfreezeBalanceContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(10, int64(m.Resource))
+	e.bytes(13, m.ReceiverAddress)
+	return e.buf
+}
+
+func (m *UnfreezeBalanceContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 10:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		case 13:
+			m.ReceiverAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WithdrawBalanceContract mirrors protocol.WithdrawBalanceContract.
+type WithdrawBalanceContract struct {
+	OwnerAddress []byte
+}
+
+func (m *WithdrawBalanceContract) ContractType() ContractType {
+	return WithdrawBalanceContractType
+}
+
+func (m *WithdrawBalanceContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *WithdrawBalanceContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TransferContract mirrors protocol.TransferContract.
+type TransferContract struct {
+	OwnerAddress []byte
+	ToAddress    []byte
+	Amount       int64
+}
+
+func (m *TransferContract) ContractType() ContractType {
+	return TransferContractType
+}
+
+func (m *TransferContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ToAddress)
+	e.int64(3, m.Amount)
+	return e.buf
+}
+
+func (m *TransferContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ToAddress, err = d.bytes()
+		case 3:
+			m.Amount, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// FreezeBalanceV2Contract mirrors protocol.FreezeBalanceV2Contract.
+type FreezeBalanceV2Contract struct {
+	OwnerAddress  []byte
+	FrozenBalance int64
+	Resource      ResourceCode
+}
+
+func (m *FreezeBalanceV2Contract) ContractType() ContractType {
+	return FreezeBalanceV2ContractType
+}
+
+func (m *FreezeBalanceV2Contract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.FrozenBalance)
+	e.int64(3, int64(m.Resource))
+	return e.buf
+}
+
+func (m *FreezeBalanceV2Contract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.FrozenBalance, err = d.int64()
+		case 3:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UnfreezeBalanceV2Contract mirrors protocol.UnfreezeBalanceV2Contract.
+type UnfreezeBalanceV2Contract struct {
+	OwnerAddress    []byte
+	UnfreezeBalance int64
+	Resource        ResourceCode
+}
+
+func (m *UnfreezeBalanceV2Contract) ContractType() ContractType {
+	return UnfreezeBalanceV2ContractType
+}
+
+func (m *UnfreezeBalanceV2Contract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.UnfreezeBalance)
+	e.int64(3, int64(m.Resource))
+	return e.buf
+}
+
+func (m *UnfreezeBalanceV2Contract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.UnfreezeBalance, err = d.int64()
+		case 3:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WithdrawExpireUnfreezeContract mirrors protocol.WithdrawExpireUnfreezeContract.
+type WithdrawExpireUnfreezeContract struct {
+	OwnerAddress []byte
+}
+
+func (m *WithdrawExpireUnfreezeContract) ContractType() ContractType {
+	return WithdrawExpireUnfreezeContractType
+}
+
+func (m *WithdrawExpireUnfreezeContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *WithdrawExpireUnfreezeContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// DelegateResourceContract mirrors protocol.DelegateResourceContract.
+type DelegateResourceContract struct {
+	OwnerAddress    []byte
+	Resource        ResourceCode
+	Balance         int64
+	ReceiverAddress []byte
+	Lock            bool
+	LockPeriod      int64
+}
+
+func (m *DelegateResourceContract) ContractType() ContractType {
+	return DelegateResourceContractType
+}
+
+func (m *DelegateResourceContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, int64(m.Resource))
+	e.int64(3, m.Balance)
+	e.bytes(4, m.ReceiverAddress)
+	e.bool(5, m.Lock)
+	e.int64(6, m.LockPeriod)
+	return e.buf
+}
+
+func (m *DelegateResourceContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		case 3:
+			m.Balance, err = d.int64()
+		case 4:
+			m.ReceiverAddress, err = d.bytes()
+		case 5:
+			m.Lock, err = d.bool()
+		case 6:
+			m.LockPeriod, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UnDelegateResourceContract mirrors protocol.UnDelegateResourceContract.
+type UnDelegateResourceContract struct {
+	OwnerAddress    []byte
+	Resource        ResourceCode
+	Balance         int64
+	ReceiverAddress []byte
+}
+
+func (m *UnDelegateResourceContract) ContractType() ContractType {
+	return UnDelegateResourceContractType
+}
+
+func (m *UnDelegateResourceContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, int64(m.Resource))
+	e.int64(3, m.Balance)
+	e.bytes(4, m.ReceiverAddress)
+	return e.buf
+}
+
+func (m *UnDelegateResourceContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			var v int64
+			v, err = d.int64()
+			m.Resource = ResourceCode(v)
+		case 3:
+			m.Balance, err = d.int64()
+		case 4:
+			m.ReceiverAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// CancelAllUnfreezeV2Contract mirrors protocol.CancelAllUnfreezeV2Contract.
+type CancelAllUnfreezeV2Contract struct {
+	OwnerAddress []byte
+}
+
+func (m *CancelAllUnfreezeV2Contract) ContractType() ContractType {
+	return CancelAllUnfreezeV2ContractType
+}
+
+func (m *CancelAllUnfreezeV2Contract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	return e.buf
+}
+
+func (m *CancelAllUnfreezeV2Contract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package pb
+
+import "sort"
+
+// ResourceCode mirrors protocol.ResourceCode.
+type ResourceCode int64
+
+const (
+	ResourceBandwidth ResourceCode = 0
+	ResourceEnergy    ResourceCode = 1
+	ResourceTronPower ResourceCode = 2
+)
+
+// AccountType mirrors protocol.AccountType.
+type AccountType int64
+
+const (
+	NormalAccount     AccountType = 0
+	AssetIssueAccount AccountType = 1
+	ContractAccount   AccountType = 2
+)
+
+// PermissionType mirrors protocol.Permission.PermissionType.
+type PermissionType int64
+
+const (
+	OwnerPermission   PermissionType = 0
+	WitnessPermission PermissionType = 1
+	ActivePermission  PermissionType = 2
+)
+
+// int64Entry is an entry of a map<int64, int64> field, which is encoded as a repeated message.
+type int64Entry struct {
+	Key   int64
+	Value int64
+}
+
+func (m *int64Entry) Marshal() []byte {
+	var e encoder
+	e.int64(1, m.Key)
+	e.int64(2, m.Value)
+	return e.buf
+}
+
+func (m *int64Entry) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Key, err = d.int64()
+		case 2:
+			m.Value, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sortedKeys returns the keys of a map in ascending order so that maps encode deterministically.
+func sortedKeys(m map[int64]int64) []int64 {
+	keys := make([]int64, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
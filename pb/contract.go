@@ -0,0 +1,211 @@
+package pb
+
+import "fmt"
+
+// ContractType mirrors protocol.Transaction.Contract.ContractType.
+type ContractType int64
+
+const (
+	AccountCreateContractType           ContractType = 0
+	TransferContractType                ContractType = 1
+	TransferAssetContractType           ContractType = 2
+	VoteAssetContractType               ContractType = 3
+	VoteWitnessContractType             ContractType = 4
+	WitnessCreateContractType           ContractType = 5
+	AssetIssueContractType              ContractType = 6
+	WitnessUpdateContractType           ContractType = 8
+	ParticipateAssetIssueContractType   ContractType = 9
+	AccountUpdateContractType           ContractType = 10
+	FreezeBalanceContractType           ContractType = 11
+	UnfreezeBalanceContractType         ContractType = 12
+	WithdrawBalanceContractType         ContractType = 13
+	UnfreezeAssetContractType           ContractType = 14
+	UpdateAssetContractType             ContractType = 15
+	ProposalCreateContractType          ContractType = 16
+	ProposalApproveContractType         ContractType = 17
+	ProposalDeleteContractType          ContractType = 18
+	SetAccountIdContractType            ContractType = 19
+	CustomContractType                  ContractType = 20
+	CreateSmartContractType             ContractType = 30
+	TriggerSmartContractType            ContractType = 31
+	GetContractType                     ContractType = 32
+	UpdateSettingContractType           ContractType = 33
+	ExchangeCreateContractType          ContractType = 41
+	ExchangeInjectContractType          ContractType = 42
+	ExchangeWithdrawContractType        ContractType = 43
+	ExchangeTransactionContractType     ContractType = 44
+	UpdateEnergyLimitContractType       ContractType = 45
+	AccountPermissionUpdateContractType ContractType = 46
+	ClearABIContractType                ContractType = 48
+	UpdateBrokerageContractType         ContractType = 49
+	ShieldedTransferContractType        ContractType = 51
+	MarketSellAssetContractType         ContractType = 52
+	MarketCancelOrderContractType       ContractType = 53
+	FreezeBalanceV2ContractType         ContractType = 54
+	UnfreezeBalanceV2ContractType       ContractType = 55
+	WithdrawExpireUnfreezeContractType  ContractType = 56
+	DelegateResourceContractType        ContractType = 57
+	UnDelegateResourceContractType      ContractType = 58
+	CancelAllUnfreezeV2ContractType     ContractType = 59
+)
+
+var contractTypeNames = map[ContractType]string{
+	AccountCreateContractType:           "AccountCreateContract",
+	TransferContractType:                "TransferContract",
+	TransferAssetContractType:           "TransferAssetContract",
+	VoteAssetContractType:               "VoteAssetContract",
+	VoteWitnessContractType:             "VoteWitnessContract",
+	WitnessCreateContractType:           "WitnessCreateContract",
+	AssetIssueContractType:              "AssetIssueContract",
+	WitnessUpdateContractType:           "WitnessUpdateContract",
+	ParticipateAssetIssueContractType:   "ParticipateAssetIssueContract",
+	AccountUpdateContractType:           "AccountUpdateContract",
+	FreezeBalanceContractType:           "FreezeBalanceContract",
+	UnfreezeBalanceContractType:         "UnfreezeBalanceContract",
+	WithdrawBalanceContractType:         "WithdrawBalanceContract",
+	UnfreezeAssetContractType:           "UnfreezeAssetContract",
+	UpdateAssetContractType:             "UpdateAssetContract",
+	ProposalCreateContractType:          "ProposalCreateContract",
+	ProposalApproveContractType:         "ProposalApproveContract",
+	ProposalDeleteContractType:          "ProposalDeleteContract",
+	SetAccountIdContractType:            "SetAccountIdContract",
+	CustomContractType:                  "CustomContract",
+	CreateSmartContractType:             "CreateSmartContract",
+	TriggerSmartContractType:            "TriggerSmartContract",
+	GetContractType:                     "GetContract",
+	UpdateSettingContractType:           "UpdateSettingContract",
+	ExchangeCreateContractType:          "ExchangeCreateContract",
+	ExchangeInjectContractType:          "ExchangeInjectContract",
+	ExchangeWithdrawContractType:        "ExchangeWithdrawContract",
+	ExchangeTransactionContractType:     "ExchangeTransactionContract",
+	UpdateEnergyLimitContractType:       "UpdateEnergyLimitContract",
+	AccountPermissionUpdateContractType: "AccountPermissionUpdateContract",
+	ClearABIContractType:                "ClearABIContract",
+	UpdateBrokerageContractType:         "UpdateBrokerageContract",
+	ShieldedTransferContractType:        "ShieldedTransferContract",
+	MarketSellAssetContractType:         "MarketSellAssetContract",
+	MarketCancelOrderContractType:       "MarketCancelOrderContract",
+	FreezeBalanceV2ContractType:         "FreezeBalanceV2Contract",
+	UnfreezeBalanceV2ContractType:       "UnfreezeBalanceV2Contract",
+	WithdrawExpireUnfreezeContractType:  "WithdrawExpireUnfreezeContract",
+	DelegateResourceContractType:        "DelegateResourceContract",
+	UnDelegateResourceContractType:      "UnDelegateResourceContract",
+	CancelAllUnfreezeV2ContractType:     "CancelAllUnfreezeV2Contract",
+}
+
+// String returns the name of the contract type as it appears in the schema and in the
+// "type" field of the JSON APIs.
+func (t ContractType) String() string {
+	if name, ok := contractTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ContractType(%d)", int64(t))
+}
+
+// ParseContractType returns the contract type with the provided name.
+func ParseContractType(name string) (ContractType, error) {
+	for t, n := range contractTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("pb: unknown contract type (%s)", name)
+}
+
+// TypeUrl returns the type URL that the parameter of a contract of this type is packed with.
+func (t ContractType) TypeUrl() string {
+	return "type.googleapis.com/protocol." + t.String()
+}
+
+// ContractMessage is implemented by the parameter messages of contracts.
+type ContractMessage interface {
+	Message
+	ContractType() ContractType
+}
+
+// contractMessages creates an empty parameter message for each supported contract type. Shielded
+// transfers and the custom and get contracts, which cannot be executed, are not supported.
+var contractMessages = map[ContractType]func() ContractMessage{
+	AccountCreateContractType:           func() ContractMessage { return new(AccountCreateContract) },
+	TransferContractType:                func() ContractMessage { return new(TransferContract) },
+	TransferAssetContractType:           func() ContractMessage { return new(TransferAssetContract) },
+	VoteAssetContractType:               func() ContractMessage { return new(VoteAssetContract) },
+	VoteWitnessContractType:             func() ContractMessage { return new(VoteWitnessContract) },
+	WitnessCreateContractType:           func() ContractMessage { return new(WitnessCreateContract) },
+	AssetIssueContractType:              func() ContractMessage { return new(AssetIssueContract) },
+	WitnessUpdateContractType:           func() ContractMessage { return new(WitnessUpdateContract) },
+	ParticipateAssetIssueContractType:   func() ContractMessage { return new(ParticipateAssetIssueContract) },
+	AccountUpdateContractType:           func() ContractMessage { return new(AccountUpdateContract) },
+	FreezeBalanceContractType:           func() ContractMessage { return new(FreezeBalanceContract) },
+	UnfreezeBalanceContractType:         func() ContractMessage { return new(UnfreezeBalanceContract) },
+	WithdrawBalanceContractType:         func() ContractMessage { return new(WithdrawBalanceContract) },
+	UnfreezeAssetContractType:           func() ContractMessage { return new(UnfreezeAssetContract) },
+	UpdateAssetContractType:             func() ContractMessage { return new(UpdateAssetContract) },
+	ProposalCreateContractType:          func() ContractMessage { return new(ProposalCreateContract) },
+	ProposalApproveContractType:         func() ContractMessage { return new(ProposalApproveContract) },
+	ProposalDeleteContractType:          func() ContractMessage { return new(ProposalDeleteContract) },
+	SetAccountIdContractType:            func() ContractMessage { return new(SetAccountIdContract) },
+	CreateSmartContractType:             func() ContractMessage { return new(CreateSmartContract) },
+	TriggerSmartContractType:            func() ContractMessage { return new(TriggerSmartContract) },
+	UpdateSettingContractType:           func() ContractMessage { return new(UpdateSettingContract) },
+	ExchangeCreateContractType:          func() ContractMessage { return new(ExchangeCreateContract) },
+	ExchangeInjectContractType:          func() ContractMessage { return new(ExchangeInjectContract) },
+	ExchangeWithdrawContractType:        func() ContractMessage { return new(ExchangeWithdrawContract) },
+	ExchangeTransactionContractType:     func() ContractMessage { return new(ExchangeTransactionContract) },
+	UpdateEnergyLimitContractType:       func() ContractMessage { return new(UpdateEnergyLimitContract) },
+	AccountPermissionUpdateContractType: func() ContractMessage { return new(AccountPermissionUpdateContract) },
+	ClearABIContractType:                func() ContractMessage { return new(ClearABIContract) },
+	UpdateBrokerageContractType:         func() ContractMessage { return new(UpdateBrokerageContract) },
+	MarketSellAssetContractType:         func() ContractMessage { return new(MarketSellAssetContract) },
+	MarketCancelOrderContractType:       func() ContractMessage { return new(MarketCancelOrderContract) },
+	FreezeBalanceV2ContractType:         func() ContractMessage { return new(FreezeBalanceV2Contract) },
+	UnfreezeBalanceV2ContractType:       func() ContractMessage { return new(UnfreezeBalanceV2Contract) },
+	WithdrawExpireUnfreezeContractType:  func() ContractMessage { return new(WithdrawExpireUnfreezeContract) },
+	DelegateResourceContractType:        func() ContractMessage { return new(DelegateResourceContract) },
+	UnDelegateResourceContractType:      func() ContractMessage { return new(UnDelegateResourceContract) },
+	CancelAllUnfreezeV2ContractType:     func() ContractMessage { return new(CancelAllUnfreezeV2Contract) },
+}
+
+// NewContract wraps a parameter message into a contract.
+func NewContract(m ContractMessage) *Contract {
+	return &Contract{
+		Type: m.ContractType(),
+		Parameter: &Any{
+			TypeUrl: m.ContractType().TypeUrl(),
+			Value:   m.Marshal(),
+		},
+	}
+}
+
+// Unpack decodes the parameter of the contract into its typed message.
+func (m *Contract) Unpack() (ContractMessage, error) {
+	fn, ok := contractMessages[m.Type]
+	if !ok {
+		return nil, fmt.Errorf("pb: unsupported contract type (%s)", m.Type)
+	}
+
+	if m.Parameter == nil {
+		return nil, fmt.Errorf("pb: %s has no parameter", m.Type)
+	}
+
+	if m.Parameter.TypeUrl != m.Type.TypeUrl() {
+		return nil, fmt.Errorf("pb: %s has mismatched parameter type (%s)", m.Type, m.Parameter.TypeUrl)
+	}
+
+	msg := fn()
+	if err := msg.Unmarshal(m.Parameter.Value); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// ContractCountError is returned where a transaction is required to carry exactly one contract
+// but does not. java-tron rejects transactions with any other number of contracts.
+type ContractCountError struct {
+	Count int
+}
+
+func (e *ContractCountError) Error() string {
+	return fmt.Sprintf("pb: transaction has %d contracts, exactly one is supported", e.Count)
+}
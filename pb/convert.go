@@ -0,0 +1,163 @@
+package pb
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+)
+
+// FromTransaction decodes the protobuf form of a transaction returned by the JSON APIs. The
+// raw data is taken from raw_data_hex, which is exactly what the transaction id and
+// signatures were computed over.
+func FromTransaction(tx *tron.Transaction) (*Transaction, error) {
+	bs, err := rawDataBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Transaction{RawData: new(TransactionRaw)}
+	if err := m.RawData.Unmarshal(bs); err != nil {
+		return nil, err
+	}
+
+	for _, sig := range tx.Signatures {
+		bs, err := hex.DecodeString(sig)
+		if err != nil {
+			return nil, err
+		}
+		m.Signatures = append(m.Signatures, bs)
+	}
+
+	return m, nil
+}
+
+// ToTransaction converts a protobuf transaction into the JSON facing form which can be
+// signed and broadcast. Only the id, signatures and raw_data_hex are populated.
+func ToTransaction(m *Transaction) (tron.Transaction, error) {
+	if m.RawData == nil {
+		return tron.Transaction{}, errors.New("pb: transaction has no raw data")
+	}
+
+	raw := m.RawData.Marshal()
+	id := tron.HashTransaction(raw)
+
+	rawHex, err := json.Marshal(hex.EncodeToString(raw))
+	if err != nil {
+		return tron.Transaction{}, err
+	}
+	rawHexMsg := json.RawMessage(rawHex)
+
+	tx := tron.Transaction{
+		Id:         hex.EncodeToString(id[:]),
+		RawDataHex: &rawHexMsg,
+	}
+
+	for _, sig := range m.Signatures {
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(sig))
+	}
+
+	return tx, nil
+}
+
+// FromBlockHeader converts the JSON facing form of a block header into its protobuf form.
+func FromBlockHeader(h *tron.BlockHeader) (*BlockHeader, error) {
+	raw, err := fromBlockHeaderRaw(h)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := hex.DecodeString(h.WitnessSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockHeader{RawData: raw, WitnessSignature: sig}, nil
+}
+
+func fromBlockHeaderRaw(h *tron.BlockHeader) (*BlockHeaderRaw, error) {
+	txTrieRoot, err := hex.DecodeString(h.RawData.TransactionTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	parentHash, err := hex.DecodeString(h.RawData.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	witness, err := address.DecodeString(h.RawData.WitnessAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockHeaderRaw{
+		Timestamp:      int64(h.RawData.Timestamp),
+		TxTrieRoot:     txTrieRoot,
+		ParentHash:     parentHash,
+		Number:         int64(h.RawData.Number),
+		WitnessAddress: witness,
+		Version:        int64(h.RawData.Version),
+	}, nil
+}
+
+// MarshalBlockHeaderRaw encodes the raw data of a block header as the protobuf message
+// BlockHeader.raw, which is the payload that block ids are computed from and witnesses sign.
+func MarshalBlockHeaderRaw(h *tron.BlockHeader) ([]byte, error) {
+	raw, err := fromBlockHeaderRaw(h)
+	if err != nil {
+		return nil, err
+	}
+	return raw.Marshal(), nil
+}
+
+// BlockId recomputes the id of a block from the raw data of its header.
+func BlockId(b *tron.Block) (string, error) {
+	raw, err := MarshalBlockHeaderRaw(&b.BlockHeader)
+	if err != nil {
+		return "", err
+	}
+
+	id := tron.HashBlockHeader(raw, b.BlockHeader.RawData.Number)
+	return hex.EncodeToString(id[:]), nil
+}
+
+// ToBlockHeader converts a protobuf block header into its JSON facing form.
+func ToBlockHeader(m *BlockHeader) tron.BlockHeader {
+	var h tron.BlockHeader
+	if m.RawData != nil {
+		h.RawData.Timestamp = tron.Time(m.RawData.Timestamp)
+		h.RawData.TransactionTrieRoot = hex.EncodeToString(m.RawData.TxTrieRoot)
+		h.RawData.ParentHash = hex.EncodeToString(m.RawData.ParentHash)
+		h.RawData.Number = uint64(m.RawData.Number)
+		h.RawData.WitnessAddress = hex.EncodeToString(m.RawData.WitnessAddress)
+		h.RawData.Version = uint64(m.RawData.Version)
+	}
+	h.WitnessSignature = hex.EncodeToString(m.WitnessSignature)
+	return h
+}
+
+// ToBlock converts a block returned by the gRPC API into its JSON facing form.
+func ToBlock(m *BlockExtention) (tron.Block, error) {
+	block := tron.Block{Id: hex.EncodeToString(m.Blockid)}
+
+	if m.BlockHeader != nil {
+		block.BlockHeader = ToBlockHeader(m.BlockHeader)
+	}
+
+	for _, ext := range m.Transactions {
+		if ext.Transaction == nil {
+			continue
+		}
+
+		tx, err := ToTransaction(ext.Transaction)
+		if err != nil {
+			return tron.Block{}, err
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,201 @@
+package pb
+
+// ExchangeCreateContract mirrors protocol.ExchangeCreateContract.
+type ExchangeCreateContract struct {
+	OwnerAddress       []byte
+	FirstTokenId       []byte
+	FirstTokenBalance  int64
+	SecondTokenId      []byte
+	SecondTokenBalance int64
+}
+
+func (m *ExchangeCreateContract) ContractType() ContractType {
+	return ExchangeCreateContractType
+}
+
+func (m *ExchangeCreateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.FirstTokenId)
+	e.int64(3, m.FirstTokenBalance)
+	e.bytes(4, m.SecondTokenId)
+	e.int64(5, m.SecondTokenBalance)
+	return e.buf
+}
+
+func (m *ExchangeCreateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.FirstTokenId, err = d.bytes()
+		case 3:
+			m.FirstTokenBalance, err = d.int64()
+		case 4:
+			m.SecondTokenId, err = d.bytes()
+		case 5:
+			m.SecondTokenBalance, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ExchangeInjectContract mirrors protocol.ExchangeInjectContract.
+type ExchangeInjectContract struct {
+	OwnerAddress []byte
+	ExchangeId   int64
+	TokenId      []byte
+	Quant        int64
+}
+
+func (m *ExchangeInjectContract) ContractType() ContractType {
+	return ExchangeInjectContractType
+}
+
+func (m *ExchangeInjectContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.ExchangeId)
+	e.bytes(3, m.TokenId)
+	e.int64(4, m.Quant)
+	return e.buf
+}
+
+func (m *ExchangeInjectContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ExchangeId, err = d.int64()
+		case 3:
+			m.TokenId, err = d.bytes()
+		case 4:
+			m.Quant, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ExchangeWithdrawContract mirrors protocol.ExchangeWithdrawContract.
+type ExchangeWithdrawContract struct {
+	OwnerAddress []byte
+	ExchangeId   int64
+	TokenId      []byte
+	Quant        int64
+}
+
+func (m *ExchangeWithdrawContract) ContractType() ContractType {
+	return ExchangeWithdrawContractType
+}
+
+func (m *ExchangeWithdrawContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.ExchangeId)
+	e.bytes(3, m.TokenId)
+	e.int64(4, m.Quant)
+	return e.buf
+}
+
+func (m *ExchangeWithdrawContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ExchangeId, err = d.int64()
+		case 3:
+			m.TokenId, err = d.bytes()
+		case 4:
+			m.Quant, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ExchangeTransactionContract mirrors protocol.ExchangeTransactionContract.
+type ExchangeTransactionContract struct {
+	OwnerAddress []byte
+	ExchangeId   int64
+	TokenId      []byte
+	Quant        int64
+	Expected     int64
+}
+
+func (m *ExchangeTransactionContract) ContractType() ContractType {
+	return ExchangeTransactionContractType
+}
+
+func (m *ExchangeTransactionContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.ExchangeId)
+	e.bytes(3, m.TokenId)
+	e.int64(4, m.Quant)
+	e.int64(5, m.Expected)
+	return e.buf
+}
+
+func (m *ExchangeTransactionContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ExchangeId, err = d.int64()
+		case 3:
+			m.TokenId, err = d.bytes()
+		case 4:
+			m.Quant, err = d.int64()
+		case 5:
+			m.Expected, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
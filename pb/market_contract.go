@@ -0,0 +1,93 @@
+package pb
+
+// MarketSellAssetContract mirrors protocol.MarketSellAssetContract.
+type MarketSellAssetContract struct {
+	OwnerAddress      []byte
+	SellTokenId       []byte
+	SellTokenQuantity int64
+	BuyTokenId        []byte
+	BuyTokenQuantity  int64
+}
+
+func (m *MarketSellAssetContract) ContractType() ContractType {
+	return MarketSellAssetContractType
+}
+
+func (m *MarketSellAssetContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.SellTokenId)
+	e.int64(3, m.SellTokenQuantity)
+	e.bytes(4, m.BuyTokenId)
+	e.int64(5, m.BuyTokenQuantity)
+	return e.buf
+}
+
+func (m *MarketSellAssetContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.SellTokenId, err = d.bytes()
+		case 3:
+			m.SellTokenQuantity, err = d.int64()
+		case 4:
+			m.BuyTokenId, err = d.bytes()
+		case 5:
+			m.BuyTokenQuantity, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// MarketCancelOrderContract mirrors protocol.MarketCancelOrderContract.
+type MarketCancelOrderContract struct {
+	OwnerAddress []byte
+	OrderId      []byte
+}
+
+func (m *MarketCancelOrderContract) ContractType() ContractType {
+	return MarketCancelOrderContractType
+}
+
+func (m *MarketCancelOrderContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.OrderId)
+	return e.buf
+}
+
+func (m *MarketCancelOrderContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.OrderId, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package pb
+
+// ProposalApproveContract mirrors protocol.ProposalApproveContract.
+type ProposalApproveContract struct {
+	OwnerAddress  []byte
+	ProposalId    int64
+	IsAddApproval bool
+}
+
+func (m *ProposalApproveContract) ContractType() ContractType {
+	return ProposalApproveContractType
+}
+
+func (m *ProposalApproveContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.ProposalId)
+	e.bool(3, m.IsAddApproval)
+	return e.buf
+}
+
+func (m *ProposalApproveContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ProposalId, err = d.int64()
+		case 3:
+			m.IsAddApproval, err = d.bool()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ProposalCreateContract mirrors protocol.ProposalCreateContract.
+type ProposalCreateContract struct {
+	OwnerAddress []byte
+	Parameters   map[int64]int64
+}
+
+func (m *ProposalCreateContract) ContractType() ContractType {
+	return ProposalCreateContractType
+}
+
+func (m *ProposalCreateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	for _, k := range sortedKeys(m.Parameters) {
+		e.message(2, &int64Entry{Key: k, Value: m.Parameters[k]})
+	}
+	return e.buf
+}
+
+func (m *ProposalCreateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			var v int64Entry
+			if err = d.message(&v); err == nil {
+				if m.Parameters == nil {
+					m.Parameters = make(map[int64]int64)
+				}
+				m.Parameters[v.Key] = v.Value
+			}
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ProposalDeleteContract mirrors protocol.ProposalDeleteContract.
+type ProposalDeleteContract struct {
+	OwnerAddress []byte
+	ProposalId   int64
+}
+
+func (m *ProposalDeleteContract) ContractType() ContractType {
+	return ProposalDeleteContractType
+}
+
+func (m *ProposalDeleteContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.ProposalId)
+	return e.buf
+}
+
+func (m *ProposalDeleteContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ProposalId, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package pb
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/params"
+)
+
+const (
+	// SignatureSize is the size of a recoverable secp256k1 signature.
+	SignatureSize = params.SignatureLength
+
+	// MaxResultSize is the number of bytes that the node reserves for the result of each
+	// contract when charging bandwidth.
+	MaxResultSize = 64
+
+	// MaxTransactionSize is the largest serialized transaction that a node will accept.
+	MaxTransactionSize = params.MaxTransactionSize
+)
+
+// Size returns the number of bytes of the serialized transaction, including its signatures
+// but excluding its results, which is the size that nodes charge bandwidth for.
+func Size(tx *tron.Transaction) (int, error) {
+	raw, err := rawDataBytes(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	n := fieldSize(1, len(raw))
+	for _, sig := range tx.Signatures {
+		n += fieldSize(2, hex.DecodedLen(len(sig)))
+	}
+
+	return n, nil
+}
+
+// EstimateSize returns the size the transaction will have once it carries the provided number
+// of signatures, regardless of how many it currently has.
+func EstimateSize(tx *tron.Transaction, signatures int) (int, error) {
+	raw, err := rawDataBytes(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return fieldSize(1, len(raw)) + signatures*fieldSize(2, SignatureSize), nil
+}
+
+// Bandwidth returns the bandwidth points that the transaction costs once it carries the
+// provided number of signatures. If the owner has insufficient bandwidth the cost is burned
+// as sun at the rate of the transaction fee chain parameter.
+func Bandwidth(tx *tron.Transaction, signatures int) (int64, error) {
+	size, err := EstimateSize(tx, signatures)
+	if err != nil {
+		return 0, err
+	}
+
+	raw, err := rawDataBytes(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var m TransactionRaw
+	if err := m.Unmarshal(raw); err != nil {
+		return 0, err
+	}
+
+	return int64(size + len(m.Contracts)*MaxResultSize), nil
+}
+
+// fieldSize returns the encoded size of a length delimited field with a payload of n bytes.
+func fieldSize(field, n int) int {
+	var scratch [binary.MaxVarintLen64]byte
+	tag := binary.PutUvarint(scratch[:], uint64(field)<<3|wireBytes)
+	length := binary.PutUvarint(scratch[:], uint64(n))
+	return tag + length + n
+}
+
+func rawDataBytes(tx *tron.Transaction) ([]byte, error) {
+	if tx.RawDataHex == nil {
+		return nil, errors.New("pb: transaction has no raw data hex")
+	}
+
+	var str string
+	if err := json.Unmarshal(*tx.RawDataHex, &str); err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(str)
+}
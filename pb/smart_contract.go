@@ -0,0 +1,309 @@
+package pb
+
+// SmartContract mirrors protocol.SmartContract. The ABI is kept as the encoded
+// protocol.SmartContract.ABI message.
+type SmartContract struct {
+	OriginAddress              []byte
+	ContractAddress            []byte
+	Abi                        []byte
+	Bytecode                   []byte
+	CallValue                  int64
+	ConsumeUserResourcePercent int64
+	Name                       string
+	OriginEnergyLimit          int64
+	CodeHash                   []byte
+	TrxHash                    []byte
+	Version                    int64
+}
+
+func (m *SmartContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OriginAddress)
+	e.bytes(2, m.ContractAddress)
+	e.bytes(3, m.Abi)
+	e.bytes(4, m.Bytecode)
+	e.int64(5, m.CallValue)
+	e.int64(6, m.ConsumeUserResourcePercent)
+	e.string(7, m.Name)
+	e.int64(8, m.OriginEnergyLimit)
+	e.bytes(9, m.CodeHash)
+	e.bytes(10, m.TrxHash)
+	e.int64(11, m.Version)
+	return e.buf
+}
+
+func (m *SmartContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OriginAddress, err = d.bytes()
+		case 2:
+			m.ContractAddress, err = d.bytes()
+		case 3:
+			m.Abi, err = d.bytes()
+		case 4:
+			m.Bytecode, err = d.bytes()
+		case 5:
+			m.CallValue, err = d.int64()
+		case 6:
+			m.ConsumeUserResourcePercent, err = d.int64()
+		case 7:
+			m.Name, err = d.string()
+		case 8:
+			m.OriginEnergyLimit, err = d.int64()
+		case 9:
+			m.CodeHash, err = d.bytes()
+		case 10:
+			m.TrxHash, err = d.bytes()
+		case 11:
+			m.Version, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// CreateSmartContract mirrors protocol.CreateSmartContract.
+type CreateSmartContract struct {
+	OwnerAddress   []byte
+	NewContract    *SmartContract
+	CallTokenValue int64
+	TokenId        int64
+}
+
+func (m *CreateSmartContract) ContractType() ContractType {
+	return CreateSmartContractType
+}
+
+func (m *CreateSmartContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	if m.NewContract != nil {
+		e.message(2, m.NewContract)
+	}
+	e.int64(3, m.CallTokenValue)
+	e.int64(4, m.TokenId)
+	return e.buf
+}
+
+func (m *CreateSmartContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.NewContract = new(SmartContract)
+			err = d.message(m.NewContract)
+		case 3:
+			m.CallTokenValue, err = d.int64()
+		case 4:
+			m.TokenId, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TriggerSmartContract mirrors protocol.TriggerSmartContract.
+type TriggerSmartContract struct {
+	OwnerAddress    []byte
+	ContractAddress []byte
+	CallValue       int64
+	Data            []byte
+	CallTokenValue  int64
+	TokenId         int64
+}
+
+func (m *TriggerSmartContract) ContractType() ContractType {
+	return TriggerSmartContractType
+}
+
+func (m *TriggerSmartContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ContractAddress)
+	e.int64(3, m.CallValue)
+	e.bytes(4, m.Data)
+	e.int64(5, m.CallTokenValue)
+	e.int64(6, m.TokenId)
+	return e.buf
+}
+
+func (m *TriggerSmartContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ContractAddress, err = d.bytes()
+		case 3:
+			m.CallValue, err = d.int64()
+		case 4:
+			m.Data, err = d.bytes()
+		case 5:
+			m.CallTokenValue, err = d.int64()
+		case 6:
+			m.TokenId, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ClearABIContract mirrors protocol.ClearABIContract.
+type ClearABIContract struct {
+	OwnerAddress    []byte
+	ContractAddress []byte
+}
+
+func (m *ClearABIContract) ContractType() ContractType {
+	return ClearABIContractType
+}
+
+func (m *ClearABIContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ContractAddress)
+	return e.buf
+}
+
+func (m *ClearABIContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ContractAddress, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateSettingContract mirrors protocol.UpdateSettingContract.
+type UpdateSettingContract struct {
+	OwnerAddress               []byte
+	ContractAddress            []byte
+	ConsumeUserResourcePercent int64
+}
+
+func (m *UpdateSettingContract) ContractType() ContractType {
+	return UpdateSettingContractType
+}
+
+func (m *UpdateSettingContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ContractAddress)
+	e.int64(3, m.ConsumeUserResourcePercent)
+	return e.buf
+}
+
+func (m *UpdateSettingContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ContractAddress, err = d.bytes()
+		case 3:
+			m.ConsumeUserResourcePercent, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateEnergyLimitContract mirrors protocol.UpdateEnergyLimitContract.
+type UpdateEnergyLimitContract struct {
+	OwnerAddress      []byte
+	ContractAddress   []byte
+	OriginEnergyLimit int64
+}
+
+func (m *UpdateEnergyLimitContract) ContractType() ContractType {
+	return UpdateEnergyLimitContractType
+}
+
+func (m *UpdateEnergyLimitContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.ContractAddress)
+	e.int64(3, m.OriginEnergyLimit)
+	return e.buf
+}
+
+func (m *UpdateEnergyLimitContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.ContractAddress, err = d.bytes()
+		case 3:
+			m.OriginEnergyLimit, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pb
+
+// UpdateBrokerageContract mirrors protocol.UpdateBrokerageContract.
+type UpdateBrokerageContract struct {
+	OwnerAddress []byte
+	Brokerage    int64
+}
+
+func (m *UpdateBrokerageContract) ContractType() ContractType {
+	return UpdateBrokerageContractType
+}
+
+func (m *UpdateBrokerageContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.int64(2, m.Brokerage)
+	return e.buf
+}
+
+func (m *UpdateBrokerageContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.Brokerage, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
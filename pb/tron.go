@@ -0,0 +1,545 @@
+package pb
+
+import "fmt"
+
+// Any mirrors google.protobuf.Any, which wraps the parameter of a contract.
+type Any struct {
+	TypeUrl string
+	Value   []byte
+
+	// unknown are the fields this package does not mirror, written back as they were read.
+	unknown []byte
+}
+
+func (m *Any) Marshal() []byte {
+	var e encoder
+	e.string(1, m.TypeUrl)
+	e.bytes(2, m.Value)
+	e.unknown(m.unknown)
+	return e.buf
+}
+
+func (m *Any) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.TypeUrl, err = d.string()
+		case 2:
+			m.Value, err = d.bytes()
+		default:
+			var bs []byte
+			if bs, err = d.unknown(field, wire); err == nil {
+				m.unknown = append(m.unknown, bs...)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Contract mirrors protocol.Transaction.Contract.
+type Contract struct {
+	Type         ContractType
+	Parameter    *Any
+	Provider     []byte
+	ContractName []byte
+	PermissionId int64
+
+	// unknown are the fields this package does not mirror, written back as they were read.
+	unknown []byte
+}
+
+func (m *Contract) Marshal() []byte {
+	var e encoder
+	e.int64(1, int64(m.Type))
+	if m.Parameter != nil {
+		e.message(2, m.Parameter)
+	}
+	e.bytes(3, m.Provider)
+	e.bytes(4, m.ContractName)
+	e.int64(5, m.PermissionId)
+	e.unknown(m.unknown)
+	return e.buf
+}
+
+func (m *Contract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			var v int64
+			v, err = d.int64()
+			m.Type = ContractType(v)
+		case 2:
+			m.Parameter = new(Any)
+			err = d.message(m.Parameter)
+		case 3:
+			m.Provider, err = d.bytes()
+		case 4:
+			m.ContractName, err = d.bytes()
+		case 5:
+			m.PermissionId, err = d.int64()
+		default:
+			var bs []byte
+			if bs, err = d.unknown(field, wire); err == nil {
+				m.unknown = append(m.unknown, bs...)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AccountId mirrors protocol.AccountId.
+type AccountId struct {
+	Name    []byte
+	Address []byte
+}
+
+func (m *AccountId) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.Name)
+	e.bytes(2, m.Address)
+	return e.buf
+}
+
+func (m *AccountId) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Name, err = d.bytes()
+		case 2:
+			m.Address, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Authority mirrors protocol.authority.
+type Authority struct {
+	Account        *AccountId
+	PermissionName []byte
+}
+
+func (m *Authority) Marshal() []byte {
+	var e encoder
+	if m.Account != nil {
+		e.message(1, m.Account)
+	}
+	e.bytes(2, m.PermissionName)
+	return e.buf
+}
+
+func (m *Authority) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Account = new(AccountId)
+			err = d.message(m.Account)
+		case 2:
+			m.PermissionName, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TransactionRaw mirrors protocol.Transaction.raw, the signed portion of a transaction.
+type TransactionRaw struct {
+	RefBlockBytes []byte
+	RefBlockNum   int64
+	RefBlockHash  []byte
+	Expiration    int64
+	Auths         []*Authority
+	Data          []byte
+	Contracts     []*Contract
+	Scripts       []byte
+	Timestamp     int64
+	FeeLimit      int64
+
+	// unknown are the fields this package does not mirror, written back as they were read.
+	unknown []byte
+}
+
+func (m *TransactionRaw) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.RefBlockBytes)
+	e.int64(3, m.RefBlockNum)
+	e.bytes(4, m.RefBlockHash)
+	e.int64(8, m.Expiration)
+	for _, a := range m.Auths {
+		e.message(9, a)
+	}
+	e.bytes(10, m.Data)
+	for _, c := range m.Contracts {
+		e.message(11, c)
+	}
+	e.bytes(12, m.Scripts)
+	e.int64(14, m.Timestamp)
+	e.int64(18, m.FeeLimit)
+	e.unknown(m.unknown)
+	return e.buf
+}
+
+func (m *TransactionRaw) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.RefBlockBytes, err = d.bytes()
+		case 3:
+			m.RefBlockNum, err = d.int64()
+		case 4:
+			m.RefBlockHash, err = d.bytes()
+		case 8:
+			m.Expiration, err = d.int64()
+		case 9:
+			a := new(Authority)
+			if err = d.message(a); err == nil {
+				m.Auths = append(m.Auths, a)
+			}
+		case 10:
+			m.Data, err = d.bytes()
+		case 11:
+			c := new(Contract)
+			if err = d.message(c); err == nil {
+				m.Contracts = append(m.Contracts, c)
+			}
+		case 12:
+			m.Scripts, err = d.bytes()
+		case 14:
+			m.Timestamp, err = d.int64()
+		case 18:
+			m.FeeLimit, err = d.int64()
+		default:
+			var bs []byte
+			if bs, err = d.unknown(field, wire); err == nil {
+				m.unknown = append(m.unknown, bs...)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TransactionResult mirrors protocol.Transaction.Result.
+type TransactionResult struct {
+	Fee            int64
+	Ret            int64
+	ContractRet    int64
+	AssetIssueId   string
+	WithdrawAmount int64
+	UnfreezeAmount int64
+}
+
+func (m *TransactionResult) Marshal() []byte {
+	var e encoder
+	e.int64(1, m.Fee)
+	e.int64(2, m.Ret)
+	e.int64(3, m.ContractRet)
+	e.string(14, m.AssetIssueId)
+	e.int64(15, m.WithdrawAmount)
+	e.int64(16, m.UnfreezeAmount)
+	return e.buf
+}
+
+func (m *TransactionResult) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Fee, err = d.int64()
+		case 2:
+			m.Ret, err = d.int64()
+		case 3:
+			m.ContractRet, err = d.int64()
+		case 14:
+			m.AssetIssueId, err = d.string()
+		case 15:
+			m.WithdrawAmount, err = d.int64()
+		case 16:
+			m.UnfreezeAmount, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Transaction mirrors protocol.Transaction.
+type Transaction struct {
+	RawData    *TransactionRaw
+	Signatures [][]byte
+	Results    []*TransactionResult
+}
+
+func (m *Transaction) Marshal() []byte {
+	var e encoder
+	if m.RawData != nil {
+		e.message(1, m.RawData)
+	}
+	for _, sig := range m.Signatures {
+		e.tag(2, wireBytes)
+		e.uvarint(uint64(len(sig)))
+		e.buf = append(e.buf, sig...)
+	}
+	for _, r := range m.Results {
+		e.message(5, r)
+	}
+	return e.buf
+}
+
+func (m *Transaction) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.RawData = new(TransactionRaw)
+			err = d.message(m.RawData)
+		case 2:
+			var sig []byte
+			if sig, err = d.bytes(); err == nil {
+				m.Signatures = append(m.Signatures, sig)
+			}
+		case 5:
+			r := new(TransactionResult)
+			if err = d.message(r); err == nil {
+				m.Results = append(m.Results, r)
+			}
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BlockHeaderRaw mirrors protocol.BlockHeader.raw, the signed portion of a block header.
+type BlockHeaderRaw struct {
+	Timestamp        int64
+	TxTrieRoot       []byte
+	ParentHash       []byte
+	Number           int64
+	WitnessId        int64
+	WitnessAddress   []byte
+	Version          int64
+	AccountStateRoot []byte
+
+	// unknown are the fields this package does not mirror, written back as they were read.
+	unknown []byte
+}
+
+func (m *BlockHeaderRaw) Marshal() []byte {
+	var e encoder
+	e.int64(1, m.Timestamp)
+	e.bytes(2, m.TxTrieRoot)
+	e.bytes(3, m.ParentHash)
+	e.int64(7, m.Number)
+	e.int64(8, m.WitnessId)
+	e.bytes(9, m.WitnessAddress)
+	e.int64(10, m.Version)
+	e.bytes(11, m.AccountStateRoot)
+	e.unknown(m.unknown)
+	return e.buf
+}
+
+func (m *BlockHeaderRaw) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.Timestamp, err = d.int64()
+		case 2:
+			m.TxTrieRoot, err = d.bytes()
+		case 3:
+			m.ParentHash, err = d.bytes()
+		case 7:
+			m.Number, err = d.int64()
+		case 8:
+			m.WitnessId, err = d.int64()
+		case 9:
+			m.WitnessAddress, err = d.bytes()
+		case 10:
+			m.Version, err = d.int64()
+		case 11:
+			m.AccountStateRoot, err = d.bytes()
+		default:
+			var bs []byte
+			if bs, err = d.unknown(field, wire); err == nil {
+				m.unknown = append(m.unknown, bs...)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BlockHeader mirrors protocol.BlockHeader.
+type BlockHeader struct {
+	RawData          *BlockHeaderRaw
+	WitnessSignature []byte
+}
+
+func (m *BlockHeader) Marshal() []byte {
+	var e encoder
+	if m.RawData != nil {
+		e.message(1, m.RawData)
+	}
+	e.bytes(2, m.WitnessSignature)
+	return e.buf
+}
+
+func (m *BlockHeader) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.RawData = new(BlockHeaderRaw)
+			err = d.message(m.RawData)
+		case 2:
+			m.WitnessSignature, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Block mirrors protocol.Block.
+type Block struct {
+	Transactions []*Transaction
+	BlockHeader  *BlockHeader
+}
+
+func (m *Block) Marshal() []byte {
+	var e encoder
+	for _, tx := range m.Transactions {
+		e.message(1, tx)
+	}
+	if m.BlockHeader != nil {
+		e.message(2, m.BlockHeader)
+	}
+	return e.buf
+}
+
+func (m *Block) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			tx := new(Transaction)
+			if err = d.message(tx); err == nil {
+				m.Transactions = append(m.Transactions, tx)
+			}
+		case 2:
+			m.BlockHeader = new(BlockHeader)
+			err = d.message(m.BlockHeader)
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Contract returns the single contract of the transaction, or a *ContractCountError if the
+// transaction does not have exactly one.
+func (m *TransactionRaw) Contract() (*Contract, error) {
+	if len(m.Contracts) != 1 {
+		return nil, &ContractCountError{Count: len(m.Contracts)}
+	}
+	return m.Contracts[0], nil
+}
+
+// UnpackContracts decodes the parameters of every contract of the transaction in order.
+func (m *TransactionRaw) UnpackContracts() ([]ContractMessage, error) {
+	msgs := make([]ContractMessage, 0, len(m.Contracts))
+	for i, c := range m.Contracts {
+		msg, err := c.Unpack()
+		if err != nil {
+			return nil, fmt.Errorf("pb: contract %d: %v", i, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
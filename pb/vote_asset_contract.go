@@ -0,0 +1,56 @@
+package pb
+
+// VoteAssetContract mirrors protocol.VoteAssetContract.
+type VoteAssetContract struct {
+	OwnerAddress []byte
+	VoteAddress  [][]byte
+	Support      bool
+	Count        int64
+}
+
+func (m *VoteAssetContract) ContractType() ContractType {
+	return VoteAssetContractType
+}
+
+func (m *VoteAssetContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	for _, v := range m.VoteAddress {
+		e.tag(2, wireBytes)
+		e.uvarint(uint64(len(v)))
+		e.buf = append(e.buf, v...)
+	}
+	e.bool(3, m.Support)
+	e.int64(5, m.Count)
+	return e.buf
+}
+
+func (m *VoteAssetContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			var v []byte
+			if v, err = d.bytes(); err == nil {
+				m.VoteAddress = append(m.VoteAddress, v)
+			}
+		case 3:
+			m.Support, err = d.bool()
+		case 5:
+			m.Count, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package pb provides Go types for the subset of the Tron protocol buffer messages that are
+// needed to build, decode and hash transactions and blocks offline. The schema is vendored
+// under proto/ and the types in this package mirror it field for field.
+//
+// The messages are encoded by hand rather than generated so that the package carries no
+// dependencies. Fields are always written in field number order and zero values are
+// omitted, which matches the canonical encoding that java-tron hashes and signs. The messages
+// which transaction and block ids are computed over keep the fields they do not mirror and
+// write them back as read, so a transaction decoded from a node re-encodes to the same bytes
+// and id.
+package pb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Message is implemented by every protocol buffer message in this package.
+type Message interface {
+	Marshal() []byte
+	Unmarshal(b []byte) error
+}
+
+// Wire types used by the Tron schema.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("pb: message is truncated")
+
+// encoder appends fields to a buffer.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) tag(field int, wire int) {
+	e.uvarint(uint64(field)<<3 | uint64(wire))
+}
+
+func (e *encoder) uvarint(v uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], v)
+	e.buf = append(e.buf, scratch[:n]...)
+}
+
+func (e *encoder) int64(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireVarint)
+	e.uvarint(uint64(v))
+}
+
+func (e *encoder) bool(field int, v bool) {
+	if !v {
+		return
+	}
+	e.tag(field, wireVarint)
+	e.uvarint(1)
+}
+
+func (e *encoder) bytes(field int, bs []byte) {
+	if len(bs) == 0 {
+		return
+	}
+	e.tag(field, wireBytes)
+	e.uvarint(uint64(len(bs)))
+	e.buf = append(e.buf, bs...)
+}
+
+func (e *encoder) string(field int, s string) {
+	e.bytes(field, []byte(s))
+}
+
+// unknown writes back fields that were not known to this package when they were decoded.
+func (e *encoder) unknown(bs []byte) {
+	e.buf = append(e.buf, bs...)
+}
+
+// message writes an embedded message. Unlike scalar fields, an empty embedded message is
+// still written when it is present so that repeated messages keep their positions.
+func (e *encoder) message(field int, m Message) {
+	bs := m.Marshal()
+	e.tag(field, wireBytes)
+	e.uvarint(uint64(len(bs)))
+	e.buf = append(e.buf, bs...)
+}
+
+// decoder reads fields from a buffer.
+type decoder struct {
+	buf []byte
+}
+
+// next returns the field number and wire type of the next field, done is true when there
+// are no fields remaining.
+func (d *decoder) next() (field int, wire int, done bool, err error) {
+	if len(d.buf) == 0 {
+		return 0, 0, true, nil
+	}
+
+	tag, err := d.uvarint()
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return int(tag >> 3), int(tag & 7), false, nil
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *decoder) int64() (int64, error) {
+	v, err := d.uvarint()
+	return int64(v), err
+}
+
+func (d *decoder) bool() (bool, error) {
+	v, err := d.uvarint()
+	return v != 0, err
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(d.buf)) < n {
+		return nil, errTruncated
+	}
+
+	bs := make([]byte, n)
+	copy(bs, d.buf[:n])
+	d.buf = d.buf[n:]
+
+	return bs, nil
+}
+
+func (d *decoder) string() (string, error) {
+	bs, err := d.bytes()
+	return string(bs), err
+}
+
+func (d *decoder) message(m Message) error {
+	bs, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	return m.Unmarshal(bs)
+}
+
+// unknown reads the value of a field that is not known to this package and returns the
+// whole field, tag included, so that it can be written back unchanged.
+func (d *decoder) unknown(field int, wire int) ([]byte, error) {
+	var e encoder
+	e.tag(field, wire)
+
+	rest := d.buf
+	if err := d.skip(wire); err != nil {
+		return nil, err
+	}
+
+	return append(e.buf, rest[:len(rest)-len(d.buf)]...), nil
+}
+
+// skip discards the value of a field that is not known to this package.
+func (d *decoder) skip(wire int) error {
+	var n int
+	switch wire {
+	case wireVarint:
+		_, err := d.uvarint()
+		return err
+	case wireFixed64:
+		n = 8
+	case wireFixed32:
+		n = 4
+	case wireBytes:
+		_, err := d.bytes()
+		return err
+	default:
+		return fmt.Errorf("pb: unsupported wire type (%d)", wire)
+	}
+
+	if len(d.buf) < n {
+		return errTruncated
+	}
+	d.buf = d.buf[n:]
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package pb
+
+// WitnessCreateContract mirrors protocol.WitnessCreateContract.
+type WitnessCreateContract struct {
+	OwnerAddress []byte
+	Url          []byte
+}
+
+func (m *WitnessCreateContract) ContractType() ContractType {
+	return WitnessCreateContractType
+}
+
+func (m *WitnessCreateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(2, m.Url)
+	return e.buf
+}
+
+func (m *WitnessCreateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			m.Url, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WitnessUpdateContract mirrors protocol.WitnessUpdateContract.
+type WitnessUpdateContract struct {
+	OwnerAddress []byte
+	UpdateUrl    []byte
+}
+
+func (m *WitnessUpdateContract) ContractType() ContractType {
+	return WitnessUpdateContractType
+}
+
+func (m *WitnessUpdateContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	e.bytes(12, m.UpdateUrl)
+	return e.buf
+}
+
+func (m *WitnessUpdateContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 12:
+			m.UpdateUrl, err = d.bytes()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// VoteWitnessContract mirrors protocol.VoteWitnessContract.
+type VoteWitnessContract struct {
+	OwnerAddress []byte
+	Votes        []*Vote
+	Support      bool
+}
+
+func (m *VoteWitnessContract) ContractType() ContractType {
+	return VoteWitnessContractType
+}
+
+func (m *VoteWitnessContract) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.OwnerAddress)
+	for _, v := range m.Votes {
+		e.message(2, v)
+	}
+	e.bool(3, m.Support)
+	return e.buf
+}
+
+func (m *VoteWitnessContract) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.OwnerAddress, err = d.bytes()
+		case 2:
+			v := new(Vote)
+			if err = d.message(v); err == nil {
+				m.Votes = append(m.Votes, v)
+			}
+		case 3:
+			m.Support, err = d.bool()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Vote mirrors protocol.VoteWitnessContract.Vote.
+type Vote struct {
+	VoteAddress []byte
+	VoteCount   int64
+}
+
+func (m *Vote) Marshal() []byte {
+	var e encoder
+	e.bytes(1, m.VoteAddress)
+	e.int64(2, m.VoteCount)
+	return e.buf
+}
+
+func (m *Vote) Unmarshal(b []byte) error {
+	d := decoder{buf: b}
+	for {
+		field, wire, done, err := d.next()
+		if err != nil || done {
+			return err
+		}
+
+		switch field {
+		case 1:
+			m.VoteAddress, err = d.bytes()
+		case 2:
+			m.VoteCount, err = d.int64()
+		default:
+			err = d.skip(wire)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Package proxy serves the HTTP API of a node to other services, requiring each request to be
+// signed with an API key. Keys are scoped so that services which only read the chain cannot
+// broadcast transactions, and signed requests carry a nonce and timestamp so that they cannot
+// be replayed. Only the endpoints listed for a scope are served; endpoints which sign with keys
+// held by the node, such as easytransferbyprivate, are never reachable through the proxy.
+package proxy
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scope is a set of permissions of an API key.
+type Scope int
+
+const (
+	// ScopeRead allows the endpoints that query the chain and the node.
+	ScopeRead Scope = 1 << iota
+
+	// ScopeBroadcast allows the endpoints that create and broadcast transactions.
+	ScopeBroadcast
+)
+
+// readEndpoints are the endpoints which ScopeRead allows, under both /wallet/ and
+// /walletsolidity/.
+var readEndpoints = endpointSet(
+	"estimateenergy",
+	"getaccount",
+	"getaccountbalance",
+	"getaccountnet",
+	"getaccountresource",
+	"getassetissuebyaccount",
+	"getassetissuebyid",
+	"getassetissuebyname",
+	"getassetissuelist",
+	"getassetissuelistbyname",
+	"getavailableunfreezecount",
+	"getbandwidthprices",
+	"getblock",
+	"getblockbalance",
+	"getblockbyid",
+	"getblockbylatestnum",
+	"getblockbylimitnext",
+	"getblockbynum",
+	"getBrokerage",
+	"getburntrx",
+	"getcandelegatedmaxsize",
+	"getcanwithdrawunfreezeamount",
+	"getchainparameters",
+	"getcontract",
+	"getcontractinfo",
+	"getdelegatedresource",
+	"getdelegatedresourceaccountindex",
+	"getdelegatedresourceaccountindexv2",
+	"getdelegatedresourcev2",
+	"getenergyprices",
+	"getexchangebyid",
+	"getmarketorderbyaccount",
+	"getmarketorderbyid",
+	"getmarketorderlistbypair",
+	"getmarketpairlist",
+	"getmarketpricebypair",
+	"getnextmaintenancetime",
+	"getnodeinfo",
+	"getnowblock",
+	"getpaginatedassetissuelist",
+	"getpaginatedexchangelist",
+	"getpaginatedproposallist",
+	"getproposalbyid",
+	"getReward",
+	"gettransactionbyid",
+	"gettransactioncountbyblocknum",
+	"gettransactioninfobyblocknum",
+	"gettransactioninfobyid",
+	"listexchanges",
+	"listnodes",
+	"listproposals",
+	"listwitnesses",
+	"triggerconstantcontract",
+	"validateaddress",
+)
+
+// broadcastEndpoints are the endpoints under /wallet/ which ScopeBroadcast allows, those which
+// create unsigned transactions and those which broadcast signed ones.
+var broadcastEndpoints = endpointSet(
+	"accountpermissionupdate",
+	"broadcasthex",
+	"broadcasttransaction",
+	"cancelallunfreezev2",
+	"clearabi",
+	"createaccount",
+	"createassetissue",
+	"createtransaction",
+	"createwitness",
+	"delegateresource",
+	"deploycontract",
+	"exchangecreate",
+	"exchangeinject",
+	"exchangetransaction",
+	"exchangewithdraw",
+	"freezebalance",
+	"freezebalancev2",
+	"marketcancelorder",
+	"marketsellasset",
+	"participateassetissue",
+	"proposalapprove",
+	"proposalcreate",
+	"proposaldelete",
+	"transferasset",
+	"triggersmartcontract",
+	"undelegateresource",
+	"unfreezeasset",
+	"unfreezebalance",
+	"unfreezebalancev2",
+	"updateaccount",
+	"updateasset",
+	"updateBrokerage",
+	"updateenergylimit",
+	"updatesetting",
+	"updatewitness",
+	"votewitnessaccount",
+	"withdrawbalance",
+	"withdrawexpireunfreeze",
+)
+
+func endpointSet(endpoints ...string) map[string]bool {
+	set := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		set[endpoint] = true
+	}
+	return set
+}
+
+// requiredScope returns the scope which allows a path, and false if no scope does.
+func requiredScope(path string) (Scope, bool) {
+	switch {
+	case strings.HasPrefix(path, "/wallet/"):
+		endpoint := strings.TrimPrefix(path, "/wallet/")
+		if readEndpoints[endpoint] {
+			return ScopeRead, true
+		}
+		if broadcastEndpoints[endpoint] {
+			return ScopeBroadcast, true
+		}
+	case strings.HasPrefix(path, "/walletsolidity/"):
+		if readEndpoints[strings.TrimPrefix(path, "/walletsolidity/")] {
+			return ScopeRead, true
+		}
+	}
+	return 0, false
+}
+
+// DefaultMaxSkew is the default difference allowed between the timestamp of a request and
+// the clock of the proxy.
+const DefaultMaxSkew = 30 * time.Second
+
+// maxBodySize limits the size of request bodies, which are read in full to be verified.
+const maxBodySize = 1 << 20
+
+// Key is an API key of a client of the proxy.
+type Key struct {
+	Id     string
+	Secret []byte
+	Scopes Scope
+}
+
+// Option configures optional behaviour of a proxy.
+type Option func(*Proxy)
+
+// WithMaxSkew sets the difference allowed between the timestamp of a request and the clock
+// of the proxy. Nonces are remembered for twice this long.
+func WithMaxSkew(d time.Duration) Option {
+	return func(p *Proxy) {
+		p.maxSkew = d
+	}
+}
+
+// Proxy is an http.Handler which forwards authenticated requests to a node.
+type Proxy struct {
+	keys    map[string]Key
+	maxSkew time.Duration
+	forward http.Handler
+
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	pruned time.Time
+
+	now func() time.Time
+}
+
+// New creates a proxy to the node at target, which accepts requests signed with keys.
+func New(target *url.URL, keys []Key, opts ...Option) *Proxy {
+	p := &Proxy{
+		keys:    make(map[string]Key, len(keys)),
+		maxSkew: DefaultMaxSkew,
+		forward: httputil.NewSingleHostReverseProxy(target),
+		nonces:  make(map[string]time.Time),
+		now:     time.Now,
+	}
+
+	for _, key := range keys {
+		p.keys[key.Id] = key
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	required, ok := requiredScope(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	key, ok := p.keys[r.Header.Get(HeaderKey)]
+	if !ok {
+		http.Error(w, "unknown api key", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	r.Body.Close()
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid timestamp", http.StatusUnauthorized)
+		return
+	}
+
+	nonce := r.Header.Get(HeaderNonce)
+	if nonce == "" {
+		http.Error(w, "missing nonce", http.StatusUnauthorized)
+		return
+	}
+
+	expected := Signature(key.Secret, timestamp, nonce, r.Method, r.URL.RequestURI(), body)
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get(HeaderSignature))) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	now := p.now()
+	skew := now.Sub(time.Unix(timestamp, 0))
+	if skew > p.maxSkew || skew < -p.maxSkew {
+		http.Error(w, "timestamp outside allowed skew", http.StatusUnauthorized)
+		return
+	}
+
+	if !p.useNonce(key.Id+"/"+nonce, now) {
+		http.Error(w, "nonce already used", http.StatusUnauthorized)
+		return
+	}
+
+	if key.Scopes&required == 0 {
+		http.Error(w, "api key not permitted", http.StatusForbidden)
+		return
+	}
+
+	for _, h := range []string{HeaderKey, HeaderNonce, HeaderTimestamp, HeaderSignature} {
+		r.Header.Del(h)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+
+	p.forward.ServeHTTP(w, r)
+}
+
+// useNonce records a nonce, returning false if it has already been used. Nonces older than
+// twice the allowed skew are forgotten, as requests carrying them are rejected by timestamp.
+func (p *Proxy) useNonce(nonce string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if now.Sub(p.pruned) > p.maxSkew {
+		for n, seen := range p.nonces {
+			if now.Sub(seen) > 2*p.maxSkew {
+				delete(p.nonces, n)
+			}
+		}
+		p.pruned = now
+	}
+
+	if _, ok := p.nonces[nonce]; ok {
+		return false
+	}
+
+	p.nonces[nonce] = now
+	return true
+}
@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chain/go-tron/client"
+)
+
+// Headers which authenticate a request to the proxy.
+const (
+	HeaderKey       = "X-Api-Key"
+	HeaderNonce     = "X-Nonce"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderSignature = "X-Signature"
+)
+
+// Signature returns the hex encoded HMAC-SHA256 of a request under a secret. The timestamp is
+// in seconds since the epoch, and the nonce must be unique within the allowed clock skew. The
+// uri is the path and query of the request, as returned by url.URL.RequestURI.
+func Signature(secret []byte, timestamp int64, nonce, method, uri string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(nonce))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(method))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(uri))
+	mac.Write([]byte{'\n'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Signer returns client middleware which authenticates each request to a proxy with an API
+// key, a random nonce and the current time.
+func Signer(id string, secret []byte) client.Middleware {
+	return func(next client.RoundTripFunc) client.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var body []byte
+			if req.Body != nil {
+				var err error
+				body, err = ioutil.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			}
+
+			var nonce [16]byte
+			if _, err := rand.Read(nonce[:]); err != nil {
+				return nil, err
+			}
+
+			timestamp := time.Now().Unix()
+			n := hex.EncodeToString(nonce[:])
+
+			req.Header.Set(HeaderKey, id)
+			req.Header.Set(HeaderNonce, n)
+			req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+			req.Header.Set(HeaderSignature, Signature(secret, timestamp, n, req.Method, req.URL.RequestURI(), body))
+
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package tenant provides isolation between logical tenants that share a single client and node.
+package tenant
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/client"
+)
+
+// Config configures the limits and instrumentation of a tenant.
+type Config struct {
+	// RateLimit is the number of requests per second the tenant may make on average, and
+	// Burst the number it may make at once. Zero leaves the tenant limited only by the
+	// shared client.
+	RateLimit float64
+	Burst     int
+
+	// Metrics receives the measurements of the tenant's requests. Nil uses the collector of
+	// the shared client.
+	Metrics client.MetricsCollector
+
+	// Options are applied to the tenant's client after the limits and metrics.
+	Options []client.Option
+}
+
+// Tenant is a logical user of a shared client, with its own rate quota, metrics and keys.
+type Tenant struct {
+	id     string
+	client *client.Client
+
+	mu   sync.RWMutex
+	keys map[string]account.Account
+}
+
+// Id returns the id of the tenant.
+func (t *Tenant) Id() string {
+	return t.id
+}
+
+// Client returns the client to use for requests made on behalf of the tenant. Its request ids
+// are prefixed with the id of the tenant.
+func (t *Tenant) Client() *client.Client {
+	return t.client
+}
+
+// AddKey adds an account to the key namespace of the tenant under a name.
+func (t *Tenant) AddKey(name string, acc account.Account) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.keys[name]; ok {
+		return fmt.Errorf("tenant: key %q already exists for tenant %q", name, t.id)
+	}
+
+	t.keys[name] = acc
+	return nil
+}
+
+// Key returns the named account from the key namespace of the tenant. Keys of other tenants
+// are never visible.
+func (t *Tenant) Key(name string) (account.Account, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	acc, ok := t.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("tenant: no key %q for tenant %q", name, t.id)
+	}
+
+	return acc, nil
+}
+
+// RemoveKey removes the named account from the key namespace of the tenant.
+func (t *Tenant) RemoveKey(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.keys, name)
+}
+
+// Registry holds the tenants of a shared client.
+type Registry struct {
+	base *client.Client
+
+	mu      sync.RWMutex
+	tenants map[string]*Tenant
+}
+
+// NewRegistry creates a registry of tenants which share the provided client. Limits
+// configured on the shared client apply to all tenants together.
+func NewRegistry(base *client.Client) *Registry {
+	return &Registry{
+		base:    base,
+		tenants: make(map[string]*Tenant),
+	}
+}
+
+// Add creates a tenant with the provided id and configuration.
+func (r *Registry) Add(id string, cfg Config) (*Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tenants[id]; ok {
+		return nil, fmt.Errorf("tenant: tenant %q already exists", id)
+	}
+
+	var opts []client.Option
+	if cfg.RateLimit > 0 {
+		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.Burst))
+	}
+	if cfg.Metrics != nil {
+		opts = append(opts, client.WithMetrics(cfg.Metrics))
+	}
+	opts = append(opts, cfg.Options...)
+
+	t := &Tenant{
+		id:     id,
+		client: r.base.With(opts...).WithCorrelationId(id),
+		keys:   make(map[string]account.Account),
+	}
+	r.tenants[id] = t
+
+	return t, nil
+}
+
+// Get returns the tenant with the provided id.
+func (r *Registry) Get(id string) (*Tenant, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tenants[id]
+	return t, ok
+}
+
+// Remove removes the tenant with the provided id, along with its keys.
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tenants, id)
+}
+
+// Ids returns the ids of all tenants.
+func (r *Registry) Ids() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.tenants))
+	for id := range r.tenants {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,64 @@
+package tron
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Time is a time in milliseconds since the epoch, as the node encodes timestamps. The zero
+// value is used by the node for times that are not set.
+type Time int64
+
+// NewTime returns the time t truncated to milliseconds.
+func NewTime(t time.Time) Time {
+	return Time(t.UnixNano() / int64(time.Millisecond))
+}
+
+// Time returns the time as a time.Time, or the zero time.Time if it is not set.
+func (t Time) Time() time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(t)*int64(time.Millisecond))
+}
+
+// IsZero returns whether the time is not set.
+func (t Time) IsZero() bool {
+	return t == 0
+}
+
+// String formats the time in RFC 3339 in UTC, or as "0" if it is not set.
+func (t Time) String() string {
+	if t == 0 {
+		return "0"
+	}
+	return t.Time().UTC().Format(time.RFC3339Nano)
+}
+
+// MarshalJSON encodes the time as a number of milliseconds.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(t), 10)), nil
+}
+
+// UnmarshalJSON decodes the time from a number of milliseconds, which may be quoted. Null and
+// the empty string decode as zero.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		data = []byte(s)
+	}
+
+	if len(data) == 0 || string(data) == "null" {
+		*t = 0
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*t = Time(ms)
+	return nil
+}
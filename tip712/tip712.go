@@ -0,0 +1,396 @@
+// Package tip712 implements TIP-712, the Tron variant of EIP-712 typed structured data hashing
+// and signing. Addresses are encoded without their 0x41 prefix and the chain id of a network
+// is the last four bytes of its genesis block id.
+package tip712
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron/address"
+)
+
+// Field is a member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types are the struct types used by typed data, by name.
+type Types map[string][]Field
+
+// Domain separates the signatures of one application and network from those of others. Fields
+// which are zero are omitted from the domain type.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract address.Address
+	Salt              []byte
+}
+
+// TypedData is a message along with its type and domain.
+type TypedData struct {
+	Types       Types
+	PrimaryType string
+	Domain      Domain
+	Message     map[string]interface{}
+}
+
+// fields returns the domain type and values for the fields which are set.
+func (d Domain) fields() ([]Field, map[string]interface{}) {
+	var (
+		fields []Field
+		values = make(map[string]interface{})
+	)
+
+	if d.Name != "" {
+		fields = append(fields, Field{Name: "name", Type: "string"})
+		values["name"] = d.Name
+	}
+	if d.Version != "" {
+		fields = append(fields, Field{Name: "version", Type: "string"})
+		values["version"] = d.Version
+	}
+	if d.ChainId != nil {
+		fields = append(fields, Field{Name: "chainId", Type: "uint256"})
+		values["chainId"] = d.ChainId
+	}
+	if d.VerifyingContract != address.Zero {
+		fields = append(fields, Field{Name: "verifyingContract", Type: "address"})
+		values["verifyingContract"] = d.VerifyingContract
+	}
+	if d.Salt != nil {
+		fields = append(fields, Field{Name: "salt", Type: "bytes32"})
+		values["salt"] = d.Salt
+	}
+
+	return fields, values
+}
+
+// DomainSeparator returns the hash of the domain.
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	fields, values := td.Domain.fields()
+
+	types := Types{"EIP712Domain": fields}
+	return types.HashStruct("EIP712Domain", values)
+}
+
+// Hash returns the digest that is signed, keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	separator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := td.Types.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, separator, message), nil
+}
+
+// EncodeType returns the encoding of a struct type, for example
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func (t Types) EncodeType(primary string) (string, error) {
+	deps := make(map[string]bool)
+	if err := t.dependencies(primary, deps); err != nil {
+		return "", err
+	}
+	delete(deps, primary)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf strings.Builder
+	for _, name := range append([]string{primary}, names...) {
+		buf.WriteString(name)
+		buf.WriteByte('(')
+		for i, f := range t[name] {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(f.Type)
+			buf.WriteByte(' ')
+			buf.WriteString(f.Name)
+		}
+		buf.WriteByte(')')
+	}
+
+	return buf.String(), nil
+}
+
+func (t Types) dependencies(name string, deps map[string]bool) error {
+	if deps[name] {
+		return nil
+	}
+
+	fields, ok := t[name]
+	if !ok {
+		return fmt.Errorf("tip712: undefined type %q", name)
+	}
+	deps[name] = true
+
+	for _, f := range fields {
+		if base := baseType(f.Type); t.isStruct(base) {
+			if err := t.dependencies(base, deps); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TypeHash returns the hash of the encoding of a struct type.
+func (t Types) TypeHash(primary string) ([]byte, error) {
+	enc, err := t.EncodeType(primary)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256([]byte(enc)), nil
+}
+
+// HashStruct returns the hash of a value of a struct type.
+func (t Types) HashStruct(primary string, value map[string]interface{}) ([]byte, error) {
+	enc, err := t.encodeData(primary, value)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(enc), nil
+}
+
+func (t Types) encodeData(primary string, value map[string]interface{}) ([]byte, error) {
+	typeHash, err := t.TypeHash(primary)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(typeHash)
+	for _, f := range t[primary] {
+		enc, err := t.encodeValue(f.Type, value[f.Name])
+		if err != nil {
+			return nil, fmt.Errorf("tip712: %s.%s: %v", primary, f.Name, err)
+		}
+		buf.Write(enc)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (t Types) isStruct(name string) bool {
+	_, ok := t[name]
+	return ok
+}
+
+// baseType strips any array suffixes from a type.
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+// encodeValue encodes a value of a type into 32 bytes.
+func (t Types) encodeValue(typ string, v interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		elem := typ[:strings.LastIndexByte(typ, '[')]
+
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+
+		var buf bytes.Buffer
+		for _, item := range items {
+			enc, err := t.encodeValue(elem, item)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(enc)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+
+	if t.isStruct(typ) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected struct %s, got %T", typ, v)
+		}
+		return t.HashStruct(typ, m)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		bs, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(bs), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		if b {
+			return word(big.NewInt(1)), nil
+		}
+		return word(new(big.Int)), nil
+	case typ == "address":
+		addr, err := toAddress(v)
+		if err != nil {
+			return nil, err
+		}
+		enc := make([]byte, 32)
+		copy(enc[12:], addr[1:])
+		return enc, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+		bs, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(bs) != n {
+			return nil, fmt.Errorf("expected %d bytes, got %d", n, len(bs))
+		}
+		enc := make([]byte, 32)
+		copy(enc, bs)
+		return enc, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		n, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() < 0 && strings.HasPrefix(typ, "uint") {
+			return nil, fmt.Errorf("negative value for %s", typ)
+		}
+		return word(n), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", typ)
+	}
+}
+
+// word encodes an integer as a 32 byte two's complement word.
+func word(n *big.Int) []byte {
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	enc := make([]byte, 32)
+	bs := n.Bytes()
+	copy(enc[32-len(bs):], bs)
+	return enc
+}
+
+func toAddress(v interface{}) (address.Address, error) {
+	switch v := v.(type) {
+	case address.Address:
+		return v, nil
+	case string:
+		return address.Parse(v)
+	default:
+		return address.Zero, fmt.Errorf("expected address, got %T", v)
+	}
+}
+
+func toBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return hex.DecodeString(strings.TrimPrefix(v, "0x"))
+	default:
+		return nil, fmt.Errorf("expected bytes, got %T", v)
+	}
+}
+
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", v)
+	}
+}
+
+// Signature is the signature of typed data. It implements tron.Signable so that typed data
+// can be signed by any account.
+type Signature struct {
+	// Hash is the digest that is signed.
+	Hash []byte
+
+	// Signature is the 65 byte signature, with a recovery id of 27 or 28 as expected by
+	// ecrecover.
+	Signature []byte
+}
+
+// NewSignature creates an unsigned signature for typed data.
+func NewSignature(td *TypedData) (*Signature, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{Hash: hash}, nil
+}
+
+// Sign signs the hash with the provided key.
+func (s *Signature) Sign(key *ecdsa.PrivateKey) error {
+	sig, err := crypto.Sign(s.Hash, key)
+	if err != nil {
+		return err
+	}
+
+	sig[64] += 27
+	s.Signature = sig
+	return nil
+}
+
+// Recover returns the address of the key that produced the signature.
+func (s *Signature) Recover() (address.Address, error) {
+	if len(s.Signature) != 65 {
+		return address.Zero, fmt.Errorf("tip712: signature is invalid length (%d)", len(s.Signature))
+	}
+
+	sig := append([]byte(nil), s.Signature...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(s.Hash, sig)
+	if err != nil {
+		return address.Zero, err
+	}
+
+	return address.FromPublicKey(pub), nil
+}
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/trc20"
+)
+
+// Analyzer finds how tokens deviate from the standard by transferring a small amount of each
+// from an account to a recipient and checking what the recipient received. The transfers are
+// real, so the recipient should be an address of the same owner.
+type Analyzer struct {
+	client *client.Client
+	from   account.Account
+	to     address.Address
+	await  client.AwaitOptions
+}
+
+// AnalyzerOption configures optional behaviour of an analyzer.
+type AnalyzerOption func(*Analyzer)
+
+// WithAwaitOptions sets how long test transfers are waited for and how many confirmations
+// they need before the balance of the recipient is checked. By default they are waited for
+// for up to a minute.
+func WithAwaitOptions(opts client.AwaitOptions) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.await = opts
+	}
+}
+
+// NewAnalyzer creates an analyzer which transfers tokens from an account to a recipient.
+func NewAnalyzer(c *client.Client, from account.Account, to address.Address, opts ...AnalyzerOption) *Analyzer {
+	a := &Analyzer{
+		client: c,
+		from:   from,
+		to:     to,
+		await:  client.AwaitOptions{Timeout: time.Minute},
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Analyze transfers an amount of a token, in the smallest unit, and returns what was found
+// about it, typically to be added to a registry. An error is returned if the token could not
+// be analyzed, such as when the transfer fails.
+//
+// A transfer to the recipient at the same time as the test transfer is mistaken for rebasing,
+// so the recipient should not be used for anything else.
+func (a *Analyzer) Analyze(ctx context.Context, contract address.Address, amount *big.Int) (Info, error) {
+	token := trc20.New(a.client, contract)
+	info := Info{Contract: contract, Checked: time.Now()}
+
+	// Symbols are informational, tokens without one are not flagged.
+	info.Symbol, _ = token.Symbol()
+
+	decimals, err := token.Decimals()
+	if err != nil {
+		if _, ok := err.(*client.RequestError); ok {
+			return Info{}, err
+		}
+		info.Flags = append(info.Flags, MissingDecimals)
+	} else {
+		info.Decimals = decimals
+	}
+
+	check, err := token.GuardedTransfer(ctx, a.from, a.to, amount, 0, a.await)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if len(check.Info.ContractResult) == 0 || check.Info.ContractResult[0] == "" {
+		info.Flags = append(info.Flags, NoReturnValue)
+	}
+
+	switch {
+	case check.Exact():
+	case check.Logged.Cmp(check.Sent) == 0:
+		// The full amount was logged but not received, so the balance changed without a
+		// transfer being logged.
+		info.Flags = append(info.Flags, Rebasing)
+	default:
+		info.Flags = append(info.Flags, FeeOnTransfer)
+	}
+
+	return info, nil
+}
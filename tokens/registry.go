@@ -0,0 +1,165 @@
+// Package tokens keeps a registry of TRC20 tokens annotated with how they deviate from the
+// standard, such as taking a fee on transfer, so that payout systems can refuse or special
+// case them. The deviations are found empirically by an Analyzer.
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chain/go-tron/address"
+)
+
+// Flag is a way in which a token deviates from the standard.
+type Flag string
+
+const (
+	// FeeOnTransfer tokens deliver less than the amount transferred, logging the reduced
+	// amount, typically with the fee transferred elsewhere.
+	FeeOnTransfer Flag = "fee-on-transfer"
+
+	// Rebasing tokens change balances without logging transfers, so the balance of a
+	// recipient differs from the amount it was logged to receive.
+	Rebasing Flag = "rebasing"
+
+	// NoReturnValue tokens return nothing from transfer rather than a bool.
+	NoReturnValue Flag = "no-return-value"
+
+	// MissingDecimals tokens do not implement decimals.
+	MissingDecimals Flag = "missing-decimals"
+)
+
+// Info is what is known about a token.
+type Info struct {
+	Contract address.Address
+	Symbol   string
+
+	// Decimals is the number of decimals of the token, zero if it has MissingDecimals.
+	Decimals int
+
+	// Flags are the deviations of the token from the standard, none for standard tokens.
+	Flags []Flag
+
+	// Checked is when the token was last analyzed.
+	Checked time.Time
+}
+
+// Has returns whether the token has a flag.
+func (i Info) Has(flag Flag) bool {
+	for _, f := range i.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Standard returns whether the token has no flags.
+func (i Info) Standard() bool {
+	return len(i.Flags) == 0
+}
+
+// Registry is a set of tokens by contract. A registry is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	tokens map[address.Address]Info
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{tokens: make(map[address.Address]Info)}
+}
+
+// Add adds a token to the registry, replacing what was known about it.
+func (r *Registry) Add(info Info) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[info.Contract] = info
+}
+
+// Get returns what is known about the token of a contract, false if it is not registered.
+func (r *Registry) Get(contract address.Address) (Info, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.tokens[contract]
+	return info, ok
+}
+
+// Tokens returns every registered token, sorted by contract.
+func (r *Registry) Tokens() []Info {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := make([]Info, 0, len(r.tokens))
+	for _, info := range r.tokens {
+		tokens = append(tokens, info)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Contract.ToBase58() < tokens[j].Contract.ToBase58()
+	})
+
+	return tokens
+}
+
+// infoJSON is the JSON form of a token in a registry, keyed by the base 58 address of its
+// contract.
+type infoJSON struct {
+	Symbol   string    `json:"symbol"`
+	Decimals int       `json:"decimals"`
+	Flags    []Flag    `json:"flags,omitempty"`
+	Checked  time.Time `json:"checked"`
+}
+
+// LoadJSON adds the tokens of a JSON object of contracts to tokens, as written by WriteJSON.
+// Contracts may be in base 58 or base 16.
+func (r *Registry) LoadJSON(rd io.Reader) error {
+	var raw map[string]infoJSON
+	if err := json.NewDecoder(rd).Decode(&raw); err != nil {
+		return err
+	}
+
+	tokens := make([]Info, 0, len(raw))
+	for str, info := range raw {
+		addr, err := address.Parse(str)
+		if err != nil {
+			return fmt.Errorf("tokens: invalid contract %q: %v", str, err)
+		}
+		tokens = append(tokens, Info{
+			Contract: addr,
+			Symbol:   info.Symbol,
+			Decimals: info.Decimals,
+			Flags:    info.Flags,
+			Checked:  info.Checked,
+		})
+	}
+
+	for _, info := range tokens {
+		r.Add(info)
+	}
+
+	return nil
+}
+
+// WriteJSON writes the registry as a JSON object of base 58 contracts to tokens.
+func (r *Registry) WriteJSON(w io.Writer) error {
+	raw := make(map[string]infoJSON)
+	for _, info := range r.Tokens() {
+		raw[info.Contract.ToBase58()] = infoJSON{
+			Symbol:   info.Symbol,
+			Decimals: info.Decimals,
+			Flags:    info.Flags,
+			Checked:  info.Checked,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(raw)
+}
@@ -0,0 +1,122 @@
+package trc20
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Topics of the TRC20 events Transfer(address,address,uint256) and
+// Approval(address,address,uint256).
+const (
+	TransferTopic = "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	ApprovalTopic = "8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+)
+
+// TransferEvent is a transfer of an amount of a token.
+type TransferEvent struct {
+	Token address.Address
+	From  address.Address
+	To    address.Address
+	Value *big.Int
+}
+
+// ApprovalEvent is an allowance granted by an owner to a spender.
+type ApprovalEvent struct {
+	Token   address.Address
+	Owner   address.Address
+	Spender address.Address
+	Value   *big.Int
+}
+
+// ParseTransferLog decodes a Transfer event from a log, or returns nil if the log is not a
+// TRC20 Transfer event. TRC721 transfers share the signature but index the token id, and are
+// not decoded.
+func ParseTransferLog(log client.Log) (*TransferEvent, error) {
+	token, from, to, value, ok, err := parseLog(log, TransferTopic)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &TransferEvent{Token: token, From: from, To: to, Value: value}, nil
+}
+
+// ParseApprovalLog decodes an Approval event from a log, or returns nil if the log is not a
+// TRC20 Approval event.
+func ParseApprovalLog(log client.Log) (*ApprovalEvent, error) {
+	token, owner, spender, value, ok, err := parseLog(log, ApprovalTopic)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &ApprovalEvent{Token: token, Owner: owner, Spender: spender, Value: value}, nil
+}
+
+// Transfers returns the TRC20 transfers made by a transaction, in the order they were made.
+// Transfers of every token are returned, callers must check the token of each.
+func Transfers(info client.TransactionInfo) ([]TransferEvent, error) {
+	logs, err := info.Logs()
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []TransferEvent
+	for _, log := range logs {
+		transfer, err := ParseTransferLog(log)
+		if err != nil {
+			return nil, err
+		}
+		if transfer != nil {
+			transfers = append(transfers, *transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// parseLog decodes an event with two indexed addresses and an unindexed amount.
+func parseLog(log client.Log, topic string) (token, a, b address.Address, value *big.Int, ok bool, err error) {
+	if len(log.Topics) != 3 || !strings.EqualFold(log.Topics[0], topic) {
+		return
+	}
+
+	if token, err = evmAddress(log.Address); err != nil {
+		return
+	}
+	if a, err = topicAddress(log.Topics[1]); err != nil {
+		return
+	}
+	if b, err = topicAddress(log.Topics[2]); err != nil {
+		return
+	}
+
+	data, err := hex.DecodeString(log.Data)
+	if err != nil {
+		return
+	}
+	if len(data) != 32 {
+		err = fmt.Errorf("trc20: event data has unexpected length (%d)", len(data))
+		return
+	}
+
+	return token, a, b, new(big.Int).SetBytes(data), true, nil
+}
+
+// topicAddress decodes an indexed address, which is its 20 byte EVM form left padded to 32
+// bytes.
+func topicAddress(topic string) (address.Address, error) {
+	if len(topic) != 64 || strings.Trim(topic[:24], "0") != "" {
+		return address.Zero, fmt.Errorf("trc20: invalid address topic (%s)", topic)
+	}
+	return evmAddress(topic[24:])
+}
+
+// evmAddress parses the 20 byte hex form of an address used in logs.
+func evmAddress(s string) (address.Address, error) {
+	return address.FromBase16(hex.EncodeToString([]byte{params.AddressPrefix}) + s)
+}
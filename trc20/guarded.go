@@ -0,0 +1,107 @@
+package trc20
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// TransferCheck is the outcome of a guarded transfer: the amount sent compared to the change
+// in the balance of the recipient.
+type TransferCheck struct {
+	TxId string
+	Info *client.TransactionInfo
+
+	// Sent is the amount transferred, Received the change in the balance of the recipient from
+	// before the transfer was broadcast to after it was confirmed, and Logged the sum of the
+	// Transfer events of the transaction to the recipient.
+	Sent     *big.Int
+	Received *big.Int
+	Logged   *big.Int
+}
+
+// Exact returns whether the recipient received exactly the amount sent.
+func (c *TransferCheck) Exact() bool {
+	return c.Sent.Cmp(c.Received) == 0
+}
+
+// Discrepancy returns the amount sent less the amount received, positive if the recipient
+// received less, such as from a fee taken by the token, and negative if it received more.
+func (c *TransferCheck) Discrepancy() *big.Int {
+	return new(big.Int).Sub(c.Sent, c.Received)
+}
+
+func (c *TransferCheck) String() string {
+	if c.Exact() {
+		return fmt.Sprintf("transfer %s received %s as sent", c.TxId, c.Sent)
+	}
+	return fmt.Sprintf("transfer %s sent %s but %s was received (%s logged)", c.TxId, c.Sent, c.Received, c.Logged)
+}
+
+// GuardedTransfer transfers an amount to an address, waits for the transfer to be confirmed as
+// set by opts, and then checks the change in the balance of the recipient against the amount
+// sent. Tokens which take a fee on transfer or rebase balances result in a check which is not
+// exact, rather than an error; an error is returned if the transfer could not be made or
+// failed.
+//
+// The balance of the recipient also changes with any other transfer to or from it while the
+// transfer is pending, the Transfer events of the transaction tell these apart from a fee
+// taken by the token.
+func (t *Token) GuardedTransfer(ctx context.Context, from account.Account, to address.Address, amount *big.Int, feeLimit params.Sun, opts client.AwaitOptions) (*TransferCheck, error) {
+	if from.Address() == to {
+		return nil, fmt.Errorf("trc20: cannot check a transfer to the sender (%s)", to.ToBase58())
+	}
+
+	before, err := t.BalanceOf(to)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := t.Transfer(from, to, amount, feeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.client.BroadcastTransaction(&tx); err != nil {
+		return nil, err
+	}
+
+	result, err := t.client.Await(ctx, tx.Id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if res := result.Info.Receipt.Result; res != "" && res != client.TxResultSuccess {
+		return nil, fmt.Errorf("trc20: transfer %s failed (%s)", tx.Id, res)
+	}
+
+	after, err := t.BalanceOf(to)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := Transfers(*result.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	logged := new(big.Int)
+	for _, transfer := range transfers {
+		if transfer.Token == t.contract && transfer.To == to {
+			logged.Add(logged, transfer.Value)
+		}
+	}
+
+	return &TransferCheck{
+		TxId:     tx.Id,
+		Info:     result.Info,
+		Sent:     new(big.Int).Set(amount),
+		Received: new(big.Int).Sub(after, before),
+		Logged:   logged,
+	}, nil
+}
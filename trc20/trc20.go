@@ -0,0 +1,361 @@
+// Package trc20 provides functionality for TRC20 fungible tokens. USDT on mainnet, which also
+// has a blacklist, is created with usdt.NewMainnet.
+package trc20
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/units"
+)
+
+// DefaultFeeLimit is the fee limit used for transactions when none is provided.
+const DefaultFeeLimit = 100 * params.SunPerTRX
+
+var (
+	nameFunction = abi.Function{
+		Name:       "name",
+		Mutability: "view",
+		Outputs:    []abi.Value{{Name: "", Type: "string"}},
+	}
+
+	symbolFunction = abi.Function{
+		Name:       "symbol",
+		Mutability: "view",
+		Outputs:    []abi.Value{{Name: "", Type: "string"}},
+	}
+
+	decimalsFunction = abi.Function{
+		Name:       "decimals",
+		Mutability: "view",
+		Outputs:    []abi.Value{{Name: "decimals", Type: abi.TypeUint256}},
+	}
+
+	totalSupplyFunction = abi.Function{
+		Name:       "totalSupply",
+		Mutability: "view",
+		Outputs:    []abi.Value{{Name: "supply", Type: abi.TypeUint256}},
+	}
+
+	balanceOfFunction = abi.Function{
+		Name:       "balanceOf",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "who", Type: "address"}},
+		Outputs:    []abi.Value{{Name: "balance", Type: abi.TypeUint256}},
+	}
+
+	allowanceFunction = abi.Function{
+		Name:       "allowance",
+		Mutability: "view",
+		Inputs:     []abi.Value{{Name: "owner", Type: "address"}, {Name: "spender", Type: "address"}},
+		Outputs:    []abi.Value{{Name: "remaining", Type: abi.TypeUint256}},
+	}
+
+	transferFunction = abi.Function{
+		Name:       "transfer",
+		Mutability: "nonpayable",
+		Inputs:     []abi.Value{{Name: "to", Type: "address"}, {Name: "value", Type: abi.TypeUint256}},
+		Outputs:    []abi.Value{{Name: "success", Type: abi.TypeBool}},
+	}
+
+	transferFromFunction = abi.Function{
+		Name:       "transferFrom",
+		Mutability: "nonpayable",
+		Inputs:     []abi.Value{{Name: "from", Type: "address"}, {Name: "to", Type: "address"}, {Name: "value", Type: abi.TypeUint256}},
+		Outputs:    []abi.Value{{Name: "success", Type: abi.TypeBool}},
+	}
+
+	approveFunction = abi.Function{
+		Name:       "approve",
+		Mutability: "nonpayable",
+		Inputs:     []abi.Value{{Name: "spender", Type: "address"}, {Name: "value", Type: abi.TypeUint256}},
+		Outputs:    []abi.Value{{Name: "success", Type: abi.TypeBool}},
+	}
+)
+
+// Token is a TRC20 contract. Its name, symbol and decimals are fetched once and cached, as
+// they do not change.
+type Token struct {
+	client   *client.Client
+	contract address.Address
+
+	mu       sync.Mutex
+	name     *string
+	symbol   *string
+	decimals *int
+}
+
+// Option configures optional behaviour of a token.
+type Option func(*Token)
+
+// WithMetadata presets the name, symbol and decimals of a well known token, so that reading
+// them makes no calls.
+func WithMetadata(name, symbol string, decimals int) Option {
+	return func(t *Token) {
+		t.name, t.symbol, t.decimals = &name, &symbol, &decimals
+	}
+}
+
+// New creates a token for the TRC20 contract at the provided address.
+func New(c *client.Client, contract address.Address, opts ...Option) *Token {
+	t := &Token{client: c, contract: contract}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Contract returns the address of the contract.
+func (t *Token) Contract() address.Address {
+	return t.contract
+}
+
+// Name returns the name of the token.
+func (t *Token) Name() (string, error) {
+	return t.cachedString(&t.name, nameFunction)
+}
+
+// Symbol returns the symbol of the token.
+func (t *Token) Symbol() (string, error) {
+	return t.cachedString(&t.symbol, symbolFunction)
+}
+
+// Decimals returns the number of decimal places of amounts of the token.
+func (t *Token) Decimals() (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.decimals != nil {
+		return *t.decimals, nil
+	}
+
+	var result struct {
+		Decimals *big.Int `abi:"decimals"`
+	}
+	if err := t.call(t.contract, decimalsFunction, &result); err != nil {
+		return 0, err
+	}
+
+	if result.Decimals == nil || !result.Decimals.IsInt64() || result.Decimals.Int64() > 77 {
+		return 0, fmt.Errorf("trc20: invalid decimals for %s", t.contract.ToBase58())
+	}
+
+	decimals := int(result.Decimals.Int64())
+	t.decimals = &decimals
+	return decimals, nil
+}
+
+// TotalSupply returns the total supply of the token in the smallest unit.
+func (t *Token) TotalSupply() (*big.Int, error) {
+	var result struct {
+		Supply *big.Int `abi:"supply"`
+	}
+	if err := t.call(t.contract, totalSupplyFunction, &result); err != nil {
+		return nil, err
+	}
+
+	return orZero(result.Supply), nil
+}
+
+// BalanceOf returns the balance of an address in the smallest unit.
+func (t *Token) BalanceOf(addr address.Address) (*big.Int, error) {
+	var result struct {
+		Balance *big.Int `abi:"balance"`
+	}
+	if err := t.call(addr, balanceOfFunction, &result, addr); err != nil {
+		return nil, err
+	}
+
+	return orZero(result.Balance), nil
+}
+
+// Allowance returns the amount a spender may transfer on behalf of an owner.
+func (t *Token) Allowance(owner, spender address.Address) (*big.Int, error) {
+	var result struct {
+		Remaining *big.Int `abi:"remaining"`
+	}
+	if err := t.call(owner, allowanceFunction, &result, owner, spender); err != nil {
+		return nil, err
+	}
+
+	return orZero(result.Remaining), nil
+}
+
+// Transfer creates and signs a transaction which transfers an amount, in the smallest unit, to
+// an address. A fee limit of zero uses DefaultFeeLimit. The transaction is not broadcast.
+func (t *Token) Transfer(from account.Account, to address.Address, amount *big.Int, feeLimit params.Sun) (tron.Transaction, error) {
+	if amount.Sign() <= 0 {
+		return tron.Transaction{}, fmt.Errorf("trc20: transfer amount must be positive (%s)", amount)
+	}
+
+	return t.send(from, transferFunction, feeLimit, to, amount)
+}
+
+// TransferFrom creates and signs a transaction which transfers an amount from an address to
+// another, using the allowance granted to the spender. A fee limit of zero uses
+// DefaultFeeLimit. The transaction is not broadcast.
+func (t *Token) TransferFrom(spender account.Account, from, to address.Address, amount *big.Int, feeLimit params.Sun) (tron.Transaction, error) {
+	if amount.Sign() <= 0 {
+		return tron.Transaction{}, fmt.Errorf("trc20: transfer amount must be positive (%s)", amount)
+	}
+
+	return t.send(spender, transferFromFunction, feeLimit, from, to, amount)
+}
+
+// Approve creates and signs a transaction which sets the amount a spender may transfer on
+// behalf of the owner, zero revoking the allowance. A fee limit of zero uses DefaultFeeLimit.
+// The transaction is not broadcast.
+func (t *Token) Approve(owner account.Account, spender address.Address, amount *big.Int, feeLimit params.Sun) (tron.Transaction, error) {
+	if amount.Sign() < 0 {
+		return tron.Transaction{}, fmt.Errorf("trc20: approval amount must not be negative (%s)", amount)
+	}
+
+	return t.send(owner, approveFunction, feeLimit, spender, amount)
+}
+
+// Format formats an amount in the smallest unit as a decimal string, such as "12.5" for
+// 12500000 of a token with six decimals.
+func (t *Token) Format(amount *big.Int) (string, error) {
+	decimals, err := t.Decimals()
+	if err != nil {
+		return "", err
+	}
+
+	return units.Format(amount, decimals), nil
+}
+
+// Amount returns an amount in the smallest unit as an amount of the token.
+func (t *Token) Amount(raw *big.Int) (units.Amount, error) {
+	decimals, err := t.Decimals()
+	if err != nil {
+		return units.Amount{}, err
+	}
+
+	symbol, err := t.Symbol()
+	if err != nil {
+		return units.Amount{}, err
+	}
+
+	return units.New(raw, decimals, symbol), nil
+}
+
+// ParseAmount parses a decimal string, such as "12.5" or "12.5 USDT", into an amount of the
+// token.
+func (t *Token) ParseAmount(str string) (units.Amount, error) {
+	decimals, err := t.Decimals()
+	if err != nil {
+		return units.Amount{}, err
+	}
+
+	symbol, err := t.Symbol()
+	if err != nil {
+		return units.Amount{}, err
+	}
+
+	return units.Parse(str, decimals, symbol)
+}
+
+// send creates and signs a transaction calling a function of the contract.
+func (t *Token) send(acc account.Account, fn abi.Function, feeLimit params.Sun, args ...interface{}) (tron.Transaction, error) {
+	if feeLimit == 0 {
+		feeLimit = DefaultFeeLimit
+	}
+
+	return t.client.CallContract(acc, client.CallContractInput{
+		Address:   t.contract,
+		Function:  fn,
+		Arguments: args,
+		FeeLimit:  uint64(feeLimit),
+	})
+}
+
+// call makes a constant call of the contract on behalf of an address.
+func (t *Token) call(owner address.Address, fn abi.Function, result interface{}, args ...interface{}) error {
+	_, err := t.client.CallContract(account.WatchOnly(owner), client.CallContractInput{
+		Address:   t.contract,
+		Function:  fn,
+		Arguments: args,
+		Result:    result,
+	})
+	return err
+}
+
+// cachedString returns a string metadata field, calling the contract the first time.
+func (t *Token) cachedString(field **string, fn abi.Function) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if *field != nil {
+		return **field, nil
+	}
+
+	results, err := t.client.TriggerSmartContract(account.WatchOnly(t.contract), client.CallContractInput{
+		Address:  t.contract,
+		Function: fn,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	bs, err := hex.DecodeString(results[0])
+	if err != nil {
+		return "", err
+	}
+
+	str, err := decodeString(bs)
+	if err != nil {
+		return "", err
+	}
+
+	*field = &str
+	return str, nil
+}
+
+// decodeString decodes a string return value. Some early tokens return their name and symbol
+// as a bytes32 rather than a string, which is decoded with its trailing zeros removed.
+func decodeString(bs []byte) (string, error) {
+	if len(bs) == 32 {
+		return string(bytes.TrimRight(bs, "\x00")), nil
+	}
+
+	if len(bs) < 64 {
+		return "", errors.New("trc20: string result is too short")
+	}
+
+	offset := new(big.Int).SetBytes(bs[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(bs)-32) {
+		return "", errors.New("trc20: string result has invalid offset")
+	}
+
+	head := bs[offset.Uint64():]
+	if new(big.Int).SetBytes(head[:24]).Sign() != 0 {
+		return "", errors.New("trc20: string result has invalid length")
+	}
+
+	n := binary.BigEndian.Uint64(head[24:32])
+	if n > uint64(len(head)-32) {
+		return "", fmt.Errorf("trc20: string result is truncated (%d)", n)
+	}
+
+	return string(head[32 : 32+n]), nil
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
@@ -0,0 +1,164 @@
+package trc721
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists metadata documents by key so that they survive restarts. Implementations
+// backed by an embedded database such as bolt can be used for large indexers.
+type Store interface {
+	// Get returns the document stored under a key, reporting false if there is none.
+	Get(key string) ([]byte, bool, error)
+
+	// Put stores a document under a key.
+	Put(key string, data []byte) error
+}
+
+// Cache is a least recently used cache of resolved metadata, optionally backed by a store. A
+// cache may be shared by tokens of different contracts, and is safe for concurrent use.
+type Cache struct {
+	size  int
+	store Store
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key      string
+	metadata *Metadata
+}
+
+// NewCache creates a cache holding at most size entries in memory, or any number if size is
+// zero. If store is not nil metadata is also written to it and read from it on a miss.
+func NewCache(size int, store Store) *Cache {
+	return &Cache{
+		size:  size,
+		store: store,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns cached metadata, or nil if there is none.
+func (c *Cache) get(key string) (*Metadata, error) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cacheEntry).metadata, nil
+	}
+	c.mu.Unlock()
+
+	if c.store == nil {
+		return nil, nil
+	}
+
+	data, ok, err := c.store.Get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	m := &Metadata{Raw: data}
+	if err := json.Unmarshal(data, m); err != nil {
+		// A corrupt document is treated as a miss so that it is resolved again.
+		return nil, nil
+	}
+
+	c.add(key, m)
+
+	return m, nil
+}
+
+// put caches metadata in memory and in the store.
+func (c *Cache) put(key string, m *Metadata) error {
+	c.add(key, m)
+
+	if c.store == nil {
+		return nil
+	}
+
+	return c.store.Put(key, m.Raw)
+}
+
+// add caches metadata in memory, evicting the least recently used entry if the cache is full.
+func (c *Cache) add(key string, m *Metadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).metadata = m
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, metadata: m})
+
+	if c.size > 0 && c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// DirStore stores each document as a file in a directory.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore creates a store in a directory, creating the directory if needed.
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+func (s *DirStore) Get(key string) ([]byte, bool, error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func (s *DirStore) Put(key string, data []byte) error {
+	// Documents are written to a temporary file and renamed into place, so that a crash never
+	// leaves a partially written document.
+	tmp, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// path returns the file of a key, named by its hash as keys may contain any characters.
+func (s *DirStore) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:])+".json")
+}
@@ -0,0 +1,131 @@
+package trc721
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+// maxMetadataSize is the largest metadata document that will be read.
+const maxMetadataSize = 1 << 20
+
+// Metadata is the standard JSON metadata of a token.
+type Metadata struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Image       string      `json:"image"`
+	ExternalURL string      `json:"external_url"`
+	Attributes  []Attribute `json:"attributes"`
+
+	// Raw is the full metadata document, including any non-standard fields.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Attribute is a trait of a token.
+type Attribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+// TokenMetadata resolves the metadata URI of a token and parses the metadata it refers to.
+// URIs may be http or https URLs, ipfs:// URIs which are fetched through the configured
+// gateways, or data URIs. Metadata is cached, see WithCache.
+func (t *Token) TokenMetadata(tokenId *big.Int) (*Metadata, error) {
+	key := t.contract.ToBase58() + "/" + tokenId.String()
+
+	m, err := t.cache.get(key)
+	if err != nil || m != nil {
+		return m, err
+	}
+
+	uri, err := t.TokenURI(tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := t.fetch(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	m = &Metadata{Raw: data}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("trc721: invalid metadata for token %s: %v", tokenId, err)
+	}
+
+	if err := t.cache.put(key, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// fetch returns the content a URI refers to.
+func (t *Token) fetch(uri string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(uri, "data:"):
+		return decodeDataURI(uri)
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(uri, "ipfs://")
+		path = strings.TrimPrefix(path, "ipfs/")
+
+		var lastErr error
+		for _, gateway := range t.gateways {
+			data, err := t.get(gateway + path)
+			if err == nil {
+				return data, nil
+			}
+			lastErr = err
+		}
+
+		if lastErr == nil {
+			return nil, fmt.Errorf("trc721: no IPFS gateways to resolve %s", uri)
+		}
+		return nil, lastErr
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		return t.get(uri)
+	default:
+		return nil, fmt.Errorf("trc721: unsupported token URI %q", uri)
+	}
+}
+
+func (t *Token) get(u string) ([]byte, error) {
+	resp, err := t.http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("trc721: unexpected status code (%d) from %s", resp.StatusCode, u)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+}
+
+// decodeDataURI decodes the content of a data URI, of the form data:[<type>][;base64],<data>.
+func decodeDataURI(uri string) ([]byte, error) {
+	i := strings.IndexByte(uri, ',')
+	if i < 0 {
+		return nil, fmt.Errorf("trc721: invalid data URI")
+	}
+
+	header, data := uri[len("data:"):i], uri[i+1:]
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+
+	s, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(s), nil
+}
@@ -0,0 +1,126 @@
+// Package trc721 provides functionality for reading TRC721 non-fungible tokens.
+package trc721
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+var tokenURIFunction = abi.Function{
+	Name:       "tokenURI",
+	Mutability: "view",
+	Inputs:     []abi.Value{{Name: "tokenId", Type: abi.TypeUint256}},
+	Outputs:    []abi.Value{{Name: "", Type: "string"}},
+}
+
+// DefaultGateways are the IPFS gateways used to resolve ipfs:// URIs, in order of preference.
+var DefaultGateways = []string{
+	"https://ipfs.io/ipfs/",
+	"https://cloudflare-ipfs.com/ipfs/",
+}
+
+// Token is a TRC721 contract.
+type Token struct {
+	client   *client.Client
+	contract address.Address
+
+	gateways []string
+	http     *http.Client
+	cache    *Cache
+}
+
+// Option configures optional behaviour of a token.
+type Option func(*Token)
+
+// WithGateways sets the IPFS gateways used to resolve ipfs:// URIs. Each gateway is a URL
+// prefix that the content id and path are appended to, and they are tried in order.
+func WithGateways(gateways ...string) Option {
+	return func(t *Token) {
+		t.gateways = gateways
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to fetch metadata.
+func WithHTTPClient(c *http.Client) Option {
+	return func(t *Token) {
+		t.http = c
+	}
+}
+
+// WithCache sets the cache that resolved metadata is kept in, which may be shared with other
+// tokens and backed by a store to persist it across restarts. By default each token caches
+// its metadata in memory for its lifetime.
+func WithCache(c *Cache) Option {
+	return func(t *Token) {
+		t.cache = c
+	}
+}
+
+// New creates a token for the TRC721 contract at the provided address.
+func New(c *client.Client, contract address.Address, opts ...Option) *Token {
+	t := &Token{
+		client:   c,
+		contract: contract,
+		gateways: DefaultGateways,
+		http:     http.DefaultClient,
+		cache:    NewCache(0, nil),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// TokenURI returns the metadata URI of a token.
+func (t *Token) TokenURI(tokenId *big.Int) (string, error) {
+	results, err := t.client.TriggerSmartContract(nil, client.CallContractInput{
+		Address:   t.contract,
+		Function:  tokenURIFunction,
+		Arguments: []interface{}{tokenId},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	bs, err := hex.DecodeString(results[0])
+	if err != nil {
+		return "", err
+	}
+
+	return decodeString(bs)
+}
+
+// decodeString decodes an ABI encoded string return value, which is the offset of the string
+// followed by its length and its bytes.
+func decodeString(bs []byte) (string, error) {
+	if len(bs) < 64 {
+		return "", errors.New("trc721: string result is too short")
+	}
+
+	offset := new(big.Int).SetBytes(bs[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(bs)-32) {
+		return "", errors.New("trc721: string result has invalid offset")
+	}
+
+	head := bs[offset.Uint64():]
+	if new(big.Int).SetBytes(head[:24]).Sign() != 0 {
+		return "", errors.New("trc721: string result has invalid length")
+	}
+
+	n := binary.BigEndian.Uint64(head[24:32])
+	if n > uint64(len(head)-32) {
+		return "", fmt.Errorf("trc721: string result is truncated (%d)", n)
+	}
+
+	return string(head[32 : 32+n]), nil
+}
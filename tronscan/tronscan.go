@@ -0,0 +1,117 @@
+// Package tronscan provides access to the verified contracts published on the Tronscan block
+// explorer.
+package tronscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+// DefaultURL is the base URL of the Tronscan API for mainnet.
+const DefaultURL = "https://apilist.tronscanapi.com"
+
+// APIKeyHeader is the header that carries the Tronscan API key.
+const APIKeyHeader = "TRON-PRO-API-KEY"
+
+// Resolver resolves the ABIs of verified contracts. It implements client.ABIResolver.
+type Resolver struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+var _ client.ABIResolver = (*Resolver)(nil)
+
+// Option configures optional behaviour of a resolver.
+type Option func(*Resolver)
+
+// WithURL sets the base URL of the Tronscan API, for example to use a testnet explorer.
+func WithURL(u string) Option {
+	return func(r *Resolver) {
+		r.url = u
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to make requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(r *Resolver) {
+		r.client = c
+	}
+}
+
+// NewResolver creates a resolver which authenticates with the provided API key. Requests
+// without a key are allowed by Tronscan but are heavily rate limited.
+func NewResolver(apiKey string, opts ...Option) *Resolver {
+	r := &Resolver{
+		url:    DefaultURL,
+		apiKey: apiKey,
+		client: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// ResolveABI returns the ABI of a contract whose source has been verified on Tronscan.
+func (r *Resolver) ResolveABI(contract address.Address) (*abi.ABI, error) {
+	q := url.Values{"contractAddress": {contract.ToBase58()}}
+
+	req, err := http.NewRequest("GET", r.url+"/api/solidity/contract/info?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.apiKey != "" {
+		req.Header.Set(APIKeyHeader, r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tronscan: unexpected status code (%d)", resp.StatusCode)
+	}
+
+	var response struct {
+		Data struct {
+			ABI json.RawMessage `json:"abi"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	data := response.Data.ABI
+	if len(data) == 0 || string(data) == `""` || string(data) == "null" {
+		return nil, fmt.Errorf("tronscan: contract %s is not verified", contract.ToBase58())
+	}
+
+	// The ABI is returned either as JSON or as a string containing JSON.
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		data = json.RawMessage(s)
+	}
+
+	var a abi.ABI
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,385 @@
+package txbuilder
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// AccountCreate builds a transaction that activates a new account.
+func (b *Builder) AccountCreate(owner, account address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.AccountCreateContract{
+		OwnerAddress:   owner[:],
+		AccountAddress: account[:],
+	})
+}
+
+// AccountUpdate builds a transaction that sets the name of an account.
+func (b *Builder) AccountUpdate(owner address.Address, name string) (tron.Transaction, error) {
+	return b.Build(&pb.AccountUpdateContract{
+		OwnerAddress: owner[:],
+		AccountName:  []byte(name),
+	})
+}
+
+// SetAccountId builds a transaction that sets the unique id of an account.
+func (b *Builder) SetAccountId(owner address.Address, id string) (tron.Transaction, error) {
+	return b.Build(&pb.SetAccountIdContract{
+		OwnerAddress: owner[:],
+		AccountId:    []byte(id),
+	})
+}
+
+// AccountPermissionUpdate builds a transaction that replaces the permissions of an account.
+// The witness permission is only valid for witness accounts and may be nil.
+func (b *Builder) AccountPermissionUpdate(owner address.Address, ownerPerm, witness *pb.Permission, actives []*pb.Permission) (tron.Transaction, error) {
+	return b.Build(&pb.AccountPermissionUpdateContract{
+		OwnerAddress: owner[:],
+		Owner:        ownerPerm,
+		Witness:      witness,
+		Actives:      actives,
+	})
+}
+
+// Transfer builds a transaction that transfers sun from the owner to another address.
+func (b *Builder) Transfer(owner, to address.Address, amount params.Sun) (tron.Transaction, error) {
+	return b.Build(&pb.TransferContract{
+		OwnerAddress: owner[:],
+		ToAddress:    to[:],
+		Amount:       int64(amount),
+	})
+}
+
+// TransferAsset builds a transaction that transfers a TRC10 asset to another address.
+func (b *Builder) TransferAsset(owner, to address.Address, assetId string, amount int64) (tron.Transaction, error) {
+	return b.Build(&pb.TransferAssetContract{
+		OwnerAddress: owner[:],
+		ToAddress:    to[:],
+		AssetName:    []byte(assetId),
+		Amount:       amount,
+	})
+}
+
+// AssetIssue builds a transaction that issues a TRC10 asset. The owner address of the
+// contract is set from owner.
+func (b *Builder) AssetIssue(owner address.Address, contract pb.AssetIssueContract) (tron.Transaction, error) {
+	contract.OwnerAddress = owner[:]
+	return b.Build(&contract)
+}
+
+// ParticipateAssetIssue builds a transaction that buys a TRC10 asset during its issuance.
+func (b *Builder) ParticipateAssetIssue(owner, issuer address.Address, assetId string, amount int64) (tron.Transaction, error) {
+	return b.Build(&pb.ParticipateAssetIssueContract{
+		OwnerAddress: owner[:],
+		ToAddress:    issuer[:],
+		AssetName:    []byte(assetId),
+		Amount:       amount,
+	})
+}
+
+// UnfreezeAsset builds a transaction that unfreezes the expired frozen supply of an asset.
+func (b *Builder) UnfreezeAsset(owner address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.UnfreezeAssetContract{
+		OwnerAddress: owner[:],
+	})
+}
+
+// UpdateAsset builds a transaction that updates the description, url and bandwidth limits
+// of an asset.
+func (b *Builder) UpdateAsset(owner address.Address, description, url string, newLimit, newPublicLimit int64) (tron.Transaction, error) {
+	return b.Build(&pb.UpdateAssetContract{
+		OwnerAddress:   owner[:],
+		Description:    []byte(description),
+		Url:            []byte(url),
+		NewLimit:       newLimit,
+		NewPublicLimit: newPublicLimit,
+	})
+}
+
+// VoteAsset builds a transaction that votes for assets.
+func (b *Builder) VoteAsset(owner address.Address, assets []address.Address, count int64) (tron.Transaction, error) {
+	m := &pb.VoteAssetContract{
+		OwnerAddress: owner[:],
+		Count:        count,
+	}
+	for _, a := range assets {
+		a := a
+		m.VoteAddress = append(m.VoteAddress, a[:])
+	}
+	return b.Build(m)
+}
+
+// FreezeBalance builds a Stake 1.0 transaction that freezes sun for a resource. The receiver
+// may be the zero address to freeze for the owner.
+func (b *Builder) FreezeBalance(owner address.Address, amount, days int64, resource pb.ResourceCode, receiver address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.FreezeBalanceContract{
+		OwnerAddress:    owner[:],
+		FrozenBalance:   amount,
+		FrozenDuration:  days,
+		Resource:        resource,
+		ReceiverAddress: optional(receiver),
+	})
+}
+
+// UnfreezeBalance builds a Stake 1.0 transaction that unfreezes sun frozen for a resource.
+func (b *Builder) UnfreezeBalance(owner address.Address, resource pb.ResourceCode, receiver address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.UnfreezeBalanceContract{
+		OwnerAddress:    owner[:],
+		Resource:        resource,
+		ReceiverAddress: optional(receiver),
+	})
+}
+
+// FreezeBalanceV2 builds a Stake 2.0 transaction that stakes sun for a resource.
+func (b *Builder) FreezeBalanceV2(owner address.Address, amount int64, resource pb.ResourceCode) (tron.Transaction, error) {
+	return b.Build(&pb.FreezeBalanceV2Contract{
+		OwnerAddress:  owner[:],
+		FrozenBalance: amount,
+		Resource:      resource,
+	})
+}
+
+// UnfreezeBalanceV2 builds a Stake 2.0 transaction that unstakes sun staked for a resource.
+func (b *Builder) UnfreezeBalanceV2(owner address.Address, amount int64, resource pb.ResourceCode) (tron.Transaction, error) {
+	return b.Build(&pb.UnfreezeBalanceV2Contract{
+		OwnerAddress:    owner[:],
+		UnfreezeBalance: amount,
+		Resource:        resource,
+	})
+}
+
+// WithdrawExpireUnfreeze builds a transaction that withdraws unstaked sun whose waiting
+// period has passed.
+func (b *Builder) WithdrawExpireUnfreeze(owner address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.WithdrawExpireUnfreezeContract{
+		OwnerAddress: owner[:],
+	})
+}
+
+// CancelAllUnfreezeV2 builds a transaction that cancels all pending unstakes.
+func (b *Builder) CancelAllUnfreezeV2(owner address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.CancelAllUnfreezeV2Contract{
+		OwnerAddress: owner[:],
+	})
+}
+
+// DelegateResource builds a transaction that delegates staked resources to another address.
+// A lock period of zero delegates without locking.
+func (b *Builder) DelegateResource(owner, receiver address.Address, amount int64, resource pb.ResourceCode, lockPeriod int64) (tron.Transaction, error) {
+	return b.Build(&pb.DelegateResourceContract{
+		OwnerAddress:    owner[:],
+		ReceiverAddress: receiver[:],
+		Balance:         amount,
+		Resource:        resource,
+		Lock:            lockPeriod > 0,
+		LockPeriod:      lockPeriod,
+	})
+}
+
+// UnDelegateResource builds a transaction that reclaims resources delegated to another address.
+func (b *Builder) UnDelegateResource(owner, receiver address.Address, amount int64, resource pb.ResourceCode) (tron.Transaction, error) {
+	return b.Build(&pb.UnDelegateResourceContract{
+		OwnerAddress:    owner[:],
+		ReceiverAddress: receiver[:],
+		Balance:         amount,
+		Resource:        resource,
+	})
+}
+
+// WithdrawBalance builds a transaction that claims voting and block rewards.
+func (b *Builder) WithdrawBalance(owner address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.WithdrawBalanceContract{
+		OwnerAddress: owner[:],
+	})
+}
+
+// WitnessCreate builds a transaction that applies to become a witness.
+func (b *Builder) WitnessCreate(owner address.Address, url string) (tron.Transaction, error) {
+	return b.Build(&pb.WitnessCreateContract{
+		OwnerAddress: owner[:],
+		Url:          []byte(url),
+	})
+}
+
+// WitnessUpdate builds a transaction that updates the url of a witness.
+func (b *Builder) WitnessUpdate(owner address.Address, url string) (tron.Transaction, error) {
+	return b.Build(&pb.WitnessUpdateContract{
+		OwnerAddress: owner[:],
+		UpdateUrl:    []byte(url),
+	})
+}
+
+// VoteWitness builds a transaction that casts votes for witnesses, replacing any previous
+// votes. Votes are ordered by witness address so that the transaction is deterministic.
+func (b *Builder) VoteWitness(owner address.Address, votes map[address.Address]int64) (tron.Transaction, error) {
+	witnesses := make([]address.Address, 0, len(votes))
+	for witness := range votes {
+		witnesses = append(witnesses, witness)
+	}
+	sort.Slice(witnesses, func(i, j int) bool {
+		return bytes.Compare(witnesses[i][:], witnesses[j][:]) < 0
+	})
+
+	m := &pb.VoteWitnessContract{
+		OwnerAddress: owner[:],
+	}
+	for _, witness := range witnesses {
+		witness := witness
+		m.Votes = append(m.Votes, &pb.Vote{VoteAddress: witness[:], VoteCount: votes[witness]})
+	}
+	return b.Build(m)
+}
+
+// UpdateBrokerage builds a transaction that sets the percentage of rewards a witness keeps.
+func (b *Builder) UpdateBrokerage(owner address.Address, brokerage int64) (tron.Transaction, error) {
+	return b.Build(&pb.UpdateBrokerageContract{
+		OwnerAddress: owner[:],
+		Brokerage:    brokerage,
+	})
+}
+
+// ProposalCreate builds a transaction that proposes changes to chain parameters.
+func (b *Builder) ProposalCreate(owner address.Address, parameters map[int64]int64) (tron.Transaction, error) {
+	return b.Build(&pb.ProposalCreateContract{
+		OwnerAddress: owner[:],
+		Parameters:   parameters,
+	})
+}
+
+// ProposalApprove builds a transaction that adds or removes approval of a proposal.
+func (b *Builder) ProposalApprove(owner address.Address, id int64, approve bool) (tron.Transaction, error) {
+	return b.Build(&pb.ProposalApproveContract{
+		OwnerAddress:  owner[:],
+		ProposalId:    id,
+		IsAddApproval: approve,
+	})
+}
+
+// ProposalDelete builds a transaction that deletes a proposal.
+func (b *Builder) ProposalDelete(owner address.Address, id int64) (tron.Transaction, error) {
+	return b.Build(&pb.ProposalDeleteContract{
+		OwnerAddress: owner[:],
+		ProposalId:   id,
+	})
+}
+
+// CreateSmartContract builds a transaction that deploys a contract. The owner and origin
+// addresses are set from owner.
+func (b *Builder) CreateSmartContract(owner address.Address, contract pb.SmartContract) (tron.Transaction, error) {
+	contract.OriginAddress = owner[:]
+	return b.Build(&pb.CreateSmartContract{
+		OwnerAddress: owner[:],
+		NewContract:  &contract,
+	})
+}
+
+// TriggerSmartContract builds a transaction that calls a contract with ABI encoded data.
+func (b *Builder) TriggerSmartContract(owner, contract address.Address, data []byte, callValue int64) (tron.Transaction, error) {
+	return b.Build(&pb.TriggerSmartContract{
+		OwnerAddress:    owner[:],
+		ContractAddress: contract[:],
+		Data:            data,
+		CallValue:       callValue,
+	})
+}
+
+// UpdateSetting builds a transaction that sets the percentage of energy paid by callers of a contract.
+func (b *Builder) UpdateSetting(owner, contract address.Address, percent int64) (tron.Transaction, error) {
+	return b.Build(&pb.UpdateSettingContract{
+		OwnerAddress:               owner[:],
+		ContractAddress:            contract[:],
+		ConsumeUserResourcePercent: percent,
+	})
+}
+
+// UpdateEnergyLimit builds a transaction that sets the energy the origin of a contract pays per call.
+func (b *Builder) UpdateEnergyLimit(owner, contract address.Address, limit int64) (tron.Transaction, error) {
+	return b.Build(&pb.UpdateEnergyLimitContract{
+		OwnerAddress:      owner[:],
+		ContractAddress:   contract[:],
+		OriginEnergyLimit: limit,
+	})
+}
+
+// ClearABI builds a transaction that clears the ABI stored for a contract.
+func (b *Builder) ClearABI(owner, contract address.Address) (tron.Transaction, error) {
+	return b.Build(&pb.ClearABIContract{
+		OwnerAddress:    owner[:],
+		ContractAddress: contract[:],
+	})
+}
+
+// ExchangeCreate builds a transaction that creates a Bancor exchange between two tokens.
+// The id "_" denotes TRX.
+func (b *Builder) ExchangeCreate(owner address.Address, firstId string, firstBalance int64, secondId string, secondBalance int64) (tron.Transaction, error) {
+	return b.Build(&pb.ExchangeCreateContract{
+		OwnerAddress:       owner[:],
+		FirstTokenId:       []byte(firstId),
+		FirstTokenBalance:  firstBalance,
+		SecondTokenId:      []byte(secondId),
+		SecondTokenBalance: secondBalance,
+	})
+}
+
+// ExchangeInject builds a transaction that adds liquidity to an exchange.
+func (b *Builder) ExchangeInject(owner address.Address, exchangeId int64, tokenId string, quant int64) (tron.Transaction, error) {
+	return b.Build(&pb.ExchangeInjectContract{
+		OwnerAddress: owner[:],
+		ExchangeId:   exchangeId,
+		TokenId:      []byte(tokenId),
+		Quant:        quant,
+	})
+}
+
+// ExchangeWithdraw builds a transaction that removes liquidity from an exchange.
+func (b *Builder) ExchangeWithdraw(owner address.Address, exchangeId int64, tokenId string, quant int64) (tron.Transaction, error) {
+	return b.Build(&pb.ExchangeWithdrawContract{
+		OwnerAddress: owner[:],
+		ExchangeId:   exchangeId,
+		TokenId:      []byte(tokenId),
+		Quant:        quant,
+	})
+}
+
+// ExchangeTransaction builds a transaction that trades against an exchange, failing if less
+// than expected is received.
+func (b *Builder) ExchangeTransaction(owner address.Address, exchangeId int64, tokenId string, quant, expected int64) (tron.Transaction, error) {
+	return b.Build(&pb.ExchangeTransactionContract{
+		OwnerAddress: owner[:],
+		ExchangeId:   exchangeId,
+		TokenId:      []byte(tokenId),
+		Quant:        quant,
+		Expected:     expected,
+	})
+}
+
+// MarketSellAsset builds a transaction that places an order on the on-chain market.
+func (b *Builder) MarketSellAsset(owner address.Address, sellId string, sellQuantity int64, buyId string, buyQuantity int64) (tron.Transaction, error) {
+	return b.Build(&pb.MarketSellAssetContract{
+		OwnerAddress:      owner[:],
+		SellTokenId:       []byte(sellId),
+		SellTokenQuantity: sellQuantity,
+		BuyTokenId:        []byte(buyId),
+		BuyTokenQuantity:  buyQuantity,
+	})
+}
+
+// MarketCancelOrder builds a transaction that cancels an order on the on-chain market.
+func (b *Builder) MarketCancelOrder(owner address.Address, orderId []byte) (tron.Transaction, error) {
+	return b.Build(&pb.MarketCancelOrderContract{
+		OwnerAddress: owner[:],
+		OrderId:      orderId,
+	})
+}
+
+// optional returns the bytes of an address, or nil for the zero address so the field is omitted.
+func optional(addr address.Address) []byte {
+	if addr == address.Zero {
+		return nil
+	}
+	return addr[:]
+}
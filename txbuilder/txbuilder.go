@@ -0,0 +1,135 @@
+// Package txbuilder provides functionality for building Tron transactions offline, without
+// asking a node to create them.
+package txbuilder
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// MaxExpiration is the furthest in the future that a node will accept a transaction expiring.
+const MaxExpiration = params.MaxExpiration
+
+// Builder builds transactions that reference a recent block. The reference block protects
+// against replaying the transaction on a fork, so it must be one of the last 65536 blocks
+// of the chain the transaction is broadcast to, see params.RefBlockWindow.
+type Builder struct {
+	refBlockBytes []byte
+	refBlockHash  []byte
+
+	expiration   time.Duration
+	feeLimit     int64
+	data         []byte
+	permissionId int64
+	allowMulti   bool
+
+	// Now returns the current time, it exists so that transactions can be built reproducibly.
+	now func() time.Time
+}
+
+// New creates a builder for transactions which reference the provided block.
+func New(ref tron.BlockHeaderOnly) (*Builder, error) {
+	id, err := hex.DecodeString(ref.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(id) != 32 {
+		return nil, fmt.Errorf("txbuilder: block id is invalid length (%d)", len(id))
+	}
+
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], ref.BlockHeader.RawData.Number)
+
+	return &Builder{
+		refBlockBytes: num[6:8],
+		refBlockHash:  id[8:16],
+		expiration:    params.DefaultExpiration,
+		now:           time.Now,
+	}, nil
+}
+
+// Expiration sets how long after being built transactions expire. The default is one minute.
+func (b *Builder) Expiration(d time.Duration) *Builder {
+	b.expiration = d
+	return b
+}
+
+// FeeLimit sets the maximum amount of sun that smart contract transactions may burn.
+func (b *Builder) FeeLimit(sun int64) *Builder {
+	b.feeLimit = sun
+	return b
+}
+
+// Memo sets the arbitrary data attached to transactions.
+func (b *Builder) Memo(data []byte) *Builder {
+	b.data = data
+	return b
+}
+
+// PermissionId sets the permission that transactions are signed under, which is only needed
+// for accounts with multi-signature permissions.
+func (b *Builder) PermissionId(id int64) *Builder {
+	b.permissionId = id
+	return b
+}
+
+// Build builds an unsigned transaction for a contract.
+func (b *Builder) Build(contract pb.ContractMessage) (tron.Transaction, error) {
+	return b.build([]pb.ContractMessage{contract})
+}
+
+// BuildMulti builds an unsigned transaction carrying several contracts. The protocol allows
+// this but java-tron rejects any transaction that does not have exactly one contract, so a
+// *pb.ContractCountError is returned unless AllowMultipleContracts has been called.
+func (b *Builder) BuildMulti(contracts ...pb.ContractMessage) (tron.Transaction, error) {
+	if len(contracts) != 1 && !b.allowMulti {
+		return tron.Transaction{}, &pb.ContractCountError{Count: len(contracts)}
+	}
+	return b.build(contracts)
+}
+
+// AllowMultipleContracts permits BuildMulti to build transactions with several contracts,
+// for networks that are known to accept them.
+func (b *Builder) AllowMultipleContracts() *Builder {
+	b.allowMulti = true
+	return b
+}
+
+func (b *Builder) build(contracts []pb.ContractMessage) (tron.Transaction, error) {
+	if len(contracts) == 0 {
+		return tron.Transaction{}, &pb.ContractCountError{}
+	}
+
+	if b.expiration <= 0 || b.expiration > MaxExpiration {
+		return tron.Transaction{}, fmt.Errorf("txbuilder: expiration must be within %s", MaxExpiration)
+	}
+
+	now := b.now()
+	raw := &pb.TransactionRaw{
+		RefBlockBytes: b.refBlockBytes,
+		RefBlockHash:  b.refBlockHash,
+		Expiration:    millis(now.Add(b.expiration)),
+		Data:          b.data,
+		Timestamp:     millis(now),
+		FeeLimit:      b.feeLimit,
+	}
+
+	for _, contract := range contracts {
+		c := pb.NewContract(contract)
+		c.PermissionId = b.permissionId
+		raw.Contracts = append(raw.Contracts, c)
+	}
+
+	return pb.ToTransaction(&pb.Transaction{RawData: raw})
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,223 @@
+package txbuilder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/pb"
+)
+
+var (
+	owner    = mustAddress("TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW")
+	other    = mustAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	contract = mustAddress("TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8")
+)
+
+func mustAddress(str string) address.Address {
+	addr, err := address.FromBase58(str)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+func testBuilder(t *testing.T) *Builder {
+	var ref tron.BlockHeaderOnly
+	ref.Id = "0000000002faf0807dc2f1ab7b5b4a5a2fd1cc4a46ba7e6a7f3ccb8f1e5b8f5d"
+	ref.BlockHeader.RawData.Number = 50000000
+
+	b, err := New(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1600000000, 0)
+	b.now = func() time.Time { return now }
+	return b
+}
+
+// TestRoundTrip builds a transaction for each contract type and checks that decoding its raw
+// data and re-encoding it gives the same bytes, id and contract.
+func TestRoundTrip(t *testing.T) {
+	perm := &pb.Permission{
+		Type:           pb.ActivePermission,
+		Id:             2,
+		PermissionName: "active",
+		Threshold:      2,
+		Operations:     bytes.Repeat([]byte{0xff}, 32),
+		Keys:           []*pb.Key{{Address: owner[:], Weight: 1}, {Address: other[:], Weight: 1}},
+	}
+
+	tests := []struct {
+		want  pb.ContractType
+		build func(b *Builder) (tron.Transaction, error)
+	}{
+		{pb.AccountCreateContractType, func(b *Builder) (tron.Transaction, error) { return b.AccountCreate(owner, other) }},
+		{pb.AccountUpdateContractType, func(b *Builder) (tron.Transaction, error) { return b.AccountUpdate(owner, "name") }},
+		{pb.SetAccountIdContractType, func(b *Builder) (tron.Transaction, error) { return b.SetAccountId(owner, "account-id") }},
+		{pb.AccountPermissionUpdateContractType, func(b *Builder) (tron.Transaction, error) {
+			ownerPerm := &pb.Permission{Type: pb.OwnerPermission, PermissionName: "owner", Threshold: 1, Keys: []*pb.Key{{Address: owner[:], Weight: 1}}}
+			return b.AccountPermissionUpdate(owner, ownerPerm, nil, []*pb.Permission{perm})
+		}},
+		{pb.TransferContractType, func(b *Builder) (tron.Transaction, error) { return b.Transfer(owner, other, 1000000) }},
+		{pb.TransferAssetContractType, func(b *Builder) (tron.Transaction, error) { return b.TransferAsset(owner, other, "1002000", 5) }},
+		{pb.AssetIssueContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.AssetIssue(owner, pb.AssetIssueContract{
+				Name:         []byte("Token"),
+				Abbr:         []byte("TKN"),
+				TotalSupply:  1000000,
+				FrozenSupply: []*pb.FrozenSupply{{FrozenAmount: 10, FrozenDays: 1}},
+				TrxNum:       1,
+				Num:          1,
+				StartTime:    1600000100000,
+				EndTime:      1600000200000,
+				Description:  []byte("description"),
+				Url:          []byte("https://example.com"),
+			})
+		}},
+		{pb.ParticipateAssetIssueContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.ParticipateAssetIssue(owner, other, "1002000", 10)
+		}},
+		{pb.UnfreezeAssetContractType, func(b *Builder) (tron.Transaction, error) { return b.UnfreezeAsset(owner) }},
+		{pb.UpdateAssetContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.UpdateAsset(owner, "description", "https://example.com", 100, 200)
+		}},
+		{pb.VoteAssetContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.VoteAsset(owner, []address.Address{other, contract}, 3)
+		}},
+		{pb.FreezeBalanceContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.FreezeBalance(owner, 1000000, 3, pb.ResourceEnergy, other)
+		}},
+		{pb.UnfreezeBalanceContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.UnfreezeBalance(owner, pb.ResourceEnergy, other)
+		}},
+		{pb.FreezeBalanceV2ContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.FreezeBalanceV2(owner, 1000000, pb.ResourceEnergy)
+		}},
+		{pb.UnfreezeBalanceV2ContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.UnfreezeBalanceV2(owner, 1000000, pb.ResourceBandwidth)
+		}},
+		{pb.WithdrawExpireUnfreezeContractType, func(b *Builder) (tron.Transaction, error) { return b.WithdrawExpireUnfreeze(owner) }},
+		{pb.CancelAllUnfreezeV2ContractType, func(b *Builder) (tron.Transaction, error) { return b.CancelAllUnfreezeV2(owner) }},
+		{pb.DelegateResourceContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.DelegateResource(owner, other, 1000000, pb.ResourceEnergy, 1200)
+		}},
+		{pb.UnDelegateResourceContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.UnDelegateResource(owner, other, 1000000, pb.ResourceEnergy)
+		}},
+		{pb.WithdrawBalanceContractType, func(b *Builder) (tron.Transaction, error) { return b.WithdrawBalance(owner) }},
+		{pb.WitnessCreateContractType, func(b *Builder) (tron.Transaction, error) { return b.WitnessCreate(owner, "https://example.com") }},
+		{pb.WitnessUpdateContractType, func(b *Builder) (tron.Transaction, error) { return b.WitnessUpdate(owner, "https://example.org") }},
+		{pb.VoteWitnessContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.VoteWitness(owner, map[address.Address]int64{other: 2, contract: 1})
+		}},
+		{pb.UpdateBrokerageContractType, func(b *Builder) (tron.Transaction, error) { return b.UpdateBrokerage(owner, 20) }},
+		{pb.ProposalCreateContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.ProposalCreate(owner, map[int64]int64{0: 1000000, 9: 1})
+		}},
+		{pb.ProposalApproveContractType, func(b *Builder) (tron.Transaction, error) { return b.ProposalApprove(owner, 7, true) }},
+		{pb.ProposalDeleteContractType, func(b *Builder) (tron.Transaction, error) { return b.ProposalDelete(owner, 7) }},
+		{pb.CreateSmartContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.CreateSmartContract(owner, pb.SmartContract{
+				Abi:                        []byte{},
+				Bytecode:                   []byte{0x60, 0x80, 0x60, 0x40},
+				ConsumeUserResourcePercent: 100,
+				Name:                       "Contract",
+				OriginEnergyLimit:          10000000,
+			})
+		}},
+		{pb.TriggerSmartContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.FeeLimit(100000000).TriggerSmartContract(owner, contract, []byte{0xa9, 0x05, 0x9c, 0xbb}, 0)
+		}},
+		{pb.UpdateSettingContractType, func(b *Builder) (tron.Transaction, error) { return b.UpdateSetting(owner, contract, 50) }},
+		{pb.UpdateEnergyLimitContractType, func(b *Builder) (tron.Transaction, error) { return b.UpdateEnergyLimit(owner, contract, 5000000) }},
+		{pb.ClearABIContractType, func(b *Builder) (tron.Transaction, error) { return b.ClearABI(owner, contract) }},
+		{pb.ExchangeCreateContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.ExchangeCreate(owner, "_", 1000000, "1002000", 100)
+		}},
+		{pb.ExchangeInjectContractType, func(b *Builder) (tron.Transaction, error) { return b.ExchangeInject(owner, 1, "_", 1000) }},
+		{pb.ExchangeWithdrawContractType, func(b *Builder) (tron.Transaction, error) { return b.ExchangeWithdraw(owner, 1, "_", 1000) }},
+		{pb.ExchangeTransactionContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.ExchangeTransaction(owner, 1, "_", 1000, 1)
+		}},
+		{pb.MarketSellAssetContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.MarketSellAsset(owner, "_", 1000000, "1002000", 100)
+		}},
+		{pb.MarketCancelOrderContractType, func(b *Builder) (tron.Transaction, error) {
+			return b.MarketCancelOrder(owner, bytes.Repeat([]byte{0x01}, 32))
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want.String(), func(t *testing.T) {
+			tx, err := test.build(testBuilder(t).Memo([]byte("memo")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rawHex string
+			if err := json.Unmarshal(*tx.RawDataHex, &rawHex); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := hex.DecodeString(rawHex)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := pb.FromTransaction(&tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := m.RawData.Marshal(); !bytes.Equal(got, raw) {
+				t.Fatalf("raw data re-encodes to %x, want %x", got, raw)
+			}
+
+			id, err := tx.ComputeId()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tx.Id {
+				t.Fatalf("id is %s, want %s", tx.Id, id)
+			}
+
+			if len(m.RawData.Contracts) != 1 {
+				t.Fatalf("has %d contracts, want 1", len(m.RawData.Contracts))
+			}
+			c := m.RawData.Contracts[0]
+			if c.Type != test.want {
+				t.Fatalf("contract is %s, want %s", c.Type, test.want)
+			}
+			if want := test.want.TypeUrl(); c.Parameter.TypeUrl != want {
+				t.Fatalf("type url is %s, want %s", c.Parameter.TypeUrl, want)
+			}
+
+			msg, err := c.Unpack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := msg.Marshal(); !bytes.Equal(got, c.Parameter.Value) {
+				t.Fatalf("contract re-encodes to %x, want %x", got, c.Parameter.Value)
+			}
+
+			again := reflect.New(reflect.TypeOf(msg).Elem()).Interface().(pb.ContractMessage)
+			if err := again.Unmarshal(msg.Marshal()); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(again, msg) {
+				t.Fatalf("contract decodes to %+v, want %+v", again, msg)
+			}
+
+			if !strings.Contains(string(raw), "memo") {
+				t.Fatal("memo is missing from the raw data")
+			}
+		})
+	}
+}
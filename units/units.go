@@ -0,0 +1,188 @@
+// Package units provides fixed-point amounts of tokens, which convert between the integer
+// amounts of the smallest unit used on chain and decimal strings such as "12.5 USDT", without
+// the rounding errors of floating point.
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MaxDecimals is the most decimal places an amount may have, the number of digits of the
+// largest uint256.
+const MaxDecimals = 77
+
+// Amount is an amount of a token with a number of decimal places. The zero value is zero of
+// a token with no decimals and no symbol. Amounts are immutable, arithmetic returns new
+// amounts.
+type Amount struct {
+	raw      *big.Int
+	decimals int
+	symbol   string
+}
+
+// New returns an amount from its value in the smallest unit.
+func New(raw *big.Int, decimals int, symbol string) Amount {
+	return Amount{raw: new(big.Int).Set(raw), decimals: decimals, symbol: symbol}
+}
+
+// FromInt64 returns an amount from its value in the smallest unit, such as a TRC10 balance.
+func FromInt64(raw int64, decimals int, symbol string) Amount {
+	return Amount{raw: big.NewInt(raw), decimals: decimals, symbol: symbol}
+}
+
+// Parse parses a decimal string, such as "12.5" or "12.5 USDT", into an amount of a token
+// with a number of decimal places. A symbol, if present, must match the symbol of the token,
+// ignoring case. More decimal places than the token has is an error rather than being
+// rounded.
+func Parse(str string, decimals int, symbol string) (Amount, error) {
+	if decimals < 0 || decimals > MaxDecimals {
+		return Amount{}, fmt.Errorf("units: invalid decimals (%d)", decimals)
+	}
+
+	fields := strings.Fields(str)
+	switch {
+	case len(fields) == 2 && strings.EqualFold(fields[1], symbol):
+	case len(fields) == 2:
+		return Amount{}, fmt.Errorf("units: amount is of %s, not %s (%s)", fields[1], symbol, str)
+	case len(fields) != 1:
+		return Amount{}, fmt.Errorf("units: invalid amount (%s)", str)
+	}
+
+	num := fields[0]
+	whole, frac := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		whole, frac = num[:i], num[i+1:]
+	}
+
+	if len(frac) > decimals {
+		return Amount{}, fmt.Errorf("units: amount has more than %d decimal places (%s)", decimals, str)
+	}
+
+	if whole == "" || whole == "-" || whole == "+" || strings.ContainsAny(frac, "+-") {
+		return Amount{}, fmt.Errorf("units: invalid amount (%s)", str)
+	}
+
+	raw, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("units: invalid amount (%s)", str)
+	}
+
+	return Amount{raw: raw, decimals: decimals, symbol: symbol}, nil
+}
+
+// Raw returns the value of the amount in the smallest unit.
+func (a Amount) Raw() *big.Int {
+	if a.raw == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.raw)
+}
+
+// Int64 returns the value of the amount in the smallest unit, or an error if it does not fit
+// in an int64, as TRC10 amounts must.
+func (a Amount) Int64() (int64, error) {
+	raw := a.Raw()
+	if !raw.IsInt64() {
+		return 0, fmt.Errorf("units: amount overflows int64 (%s)", a)
+	}
+	return raw.Int64(), nil
+}
+
+// Uint64 returns the value of the amount in the smallest unit, or an error if it is negative
+// or does not fit in a uint64.
+func (a Amount) Uint64() (uint64, error) {
+	raw := a.Raw()
+	if !raw.IsUint64() {
+		return 0, fmt.Errorf("units: amount is negative or overflows uint64 (%s)", a)
+	}
+	return raw.Uint64(), nil
+}
+
+// Decimals returns the number of decimal places of the token.
+func (a Amount) Decimals() int {
+	return a.decimals
+}
+
+// Symbol returns the symbol of the token.
+func (a Amount) Symbol() string {
+	return a.symbol
+}
+
+// Sign returns -1, 0 or 1 as the amount is negative, zero or positive.
+func (a Amount) Sign() int {
+	return a.Raw().Sign()
+}
+
+// Add returns the sum of two amounts of the same token.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.compatible(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{raw: new(big.Int).Add(a.Raw(), b.Raw()), decimals: a.decimals, symbol: a.symbol}, nil
+}
+
+// Sub returns the difference of two amounts of the same token.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if err := a.compatible(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{raw: new(big.Int).Sub(a.Raw(), b.Raw()), decimals: a.decimals, symbol: a.symbol}, nil
+}
+
+// Mul returns the amount multiplied by an integer.
+func (a Amount) Mul(n int64) Amount {
+	return Amount{raw: new(big.Int).Mul(a.Raw(), big.NewInt(n)), decimals: a.decimals, symbol: a.symbol}
+}
+
+// Cmp compares two amounts of the same token, returning -1, 0 or 1 as a is less than, equal
+// to or greater than b.
+func (a Amount) Cmp(b Amount) (int, error) {
+	if err := a.compatible(b); err != nil {
+		return 0, err
+	}
+	return a.Raw().Cmp(b.Raw()), nil
+}
+
+func (a Amount) compatible(b Amount) error {
+	if a.decimals != b.decimals || a.symbol != b.symbol {
+		return fmt.Errorf("units: cannot combine amounts of different tokens (%s, %s)", a, b)
+	}
+	return nil
+}
+
+// Format formats the amount as a decimal string without its symbol, such as "12.5", with
+// trailing zeros removed.
+func (a Amount) Format() string {
+	return Format(a.Raw(), a.decimals)
+}
+
+// String formats the amount as a decimal string followed by its symbol, if it has one.
+func (a Amount) String() string {
+	if a.symbol == "" {
+		return a.Format()
+	}
+	return a.Format() + " " + a.symbol
+}
+
+// Format formats an amount in the smallest unit as a decimal string with a number of decimal
+// places, such as "12.5" for 12500000 with six decimals.
+func Format(raw *big.Int, decimals int) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+
+	abs := new(big.Int).Abs(raw)
+	whole, frac := new(big.Int).QuoRem(abs, unit, new(big.Int))
+
+	str := whole.String()
+	if frac.Sign() != 0 {
+		digits := fmt.Sprintf("%0*s", decimals, frac.String())
+		str += "." + strings.TrimRight(digits, "0")
+	}
+
+	if raw.Sign() < 0 {
+		str = "-" + str
+	}
+
+	return str
+}
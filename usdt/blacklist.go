@@ -0,0 +1,89 @@
+package usdt
+
+import (
+	"fmt"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+)
+
+// Methods that stablecoins expose to query their blacklists, each taking an address and
+// returning a bool.
+const (
+	USDTBlackListMethod = "isBlackListed"
+	USDCBlackListMethod = "isBlacklisted"
+)
+
+// USDC is the contract address of USDC on mainnet.
+var USDC = mustParse("TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8")
+
+// BlackListedError is returned when a transfer is from or to an address that the issuer of a
+// stablecoin has blacklisted, as the contract would revert the transfer.
+type BlackListedError struct {
+	Token   address.Address
+	Address address.Address
+}
+
+func (e *BlackListedError) Error() string {
+	return fmt.Sprintf("usdt: address %s is blacklisted by %s", e.Address.ToBase58(), e.Token.ToBase58())
+}
+
+// Blacklist queries the blacklist of a stablecoin.
+type Blacklist struct {
+	client   *client.Client
+	contract address.Address
+	function abi.Function
+}
+
+// NewBlacklist creates a blacklist for the stablecoin at the provided address which is queried
+// with the provided method, such as USDTBlackListMethod or USDCBlackListMethod.
+func NewBlacklist(c *client.Client, contract address.Address, method string) *Blacklist {
+	return &Blacklist{
+		client:   c,
+		contract: contract,
+		function: abi.Function{
+			Name:       method,
+			Mutability: "view",
+			Inputs:     []abi.Value{{Name: "", Type: "address"}},
+			Outputs:    []abi.Value{{Name: "blacklisted", Type: abi.TypeBool}},
+		},
+	}
+}
+
+// IsBlackListed returns whether an address is blacklisted.
+func (b *Blacklist) IsBlackListed(addr address.Address) (bool, error) {
+	var result struct {
+		BlackListed bool `abi:"blacklisted"`
+	}
+
+	_, err := b.client.CallContract(account.WatchOnly(addr), client.CallContractInput{
+		Address:   b.contract,
+		Function:  b.function,
+		Arguments: []interface{}{addr},
+		Result:    &result,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result.BlackListed, nil
+}
+
+// Check returns a *BlackListedError for the first of the addresses, typically the sender and
+// recipient of a transfer, which is blacklisted.
+func (b *Blacklist) Check(addrs ...address.Address) error {
+	for _, addr := range addrs {
+		blackListed, err := b.IsBlackListed(addr)
+		if err != nil {
+			return err
+		}
+
+		if blackListed {
+			return &BlackListedError{Token: b.contract, Address: addr}
+		}
+	}
+
+	return nil
+}
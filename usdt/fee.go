@@ -0,0 +1,64 @@
+package usdt
+
+import (
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Typical resources consumed by a transfer. Transfers to an address which has never held USDT
+// use more energy, as the contract stores a new balance. Energy can change with the dynamic
+// energy model of the contract, so these are defaults for estimation rather than exact costs.
+const (
+	TransferEnergy          = 64285
+	TransferEnergyNewHolder = 130285
+	TransferBandwidth       = 345
+)
+
+// DefaultFeeLimit is the fee limit used for transfers when none is provided, enough for a
+// transfer to a new holder with headroom for energy price increases.
+const DefaultFeeLimit = 100 * params.SunPerTRX
+
+// Fee is an estimate of the resources consumed by a transfer and the TRX that will be burnt
+// for those not covered by the sender's staked and free resources.
+type Fee struct {
+	Energy    int64
+	Bandwidth int64
+
+	// Burn is the TRX burnt for energy and bandwidth the sender does not have.
+	Burn params.Sun
+}
+
+// EstimateTransferFee estimates the fee of a transfer, using the current energy and bandwidth
+// prices and the resources available to the sender.
+func (t *Token) EstimateTransferFee(from, to address.Address) (*Fee, error) {
+	balance, err := t.BalanceOf(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := &Fee{Energy: TransferEnergy, Bandwidth: TransferBandwidth}
+	if balance.Sign() == 0 {
+		fee.Energy = TransferEnergyNewHolder
+	}
+
+	chain, err := t.client.GetChainParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := t.client.GetAccountResource(from)
+	if err != nil {
+		return nil, err
+	}
+
+	if missing := fee.Energy - resource.EnergyRemaining(); missing > 0 {
+		fee.Burn += params.Sun(missing * chain.EnergyFee())
+	}
+
+	// Bandwidth is either covered entirely by free or staked bandwidth or paid for in full.
+	if resource.FreeNetRemaining() < fee.Bandwidth && resource.NetRemaining() < fee.Bandwidth {
+		fee.Burn += params.Sun(fee.Bandwidth * chain.TransactionFee())
+	}
+
+	return fee, nil
+}
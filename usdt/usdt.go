@@ -0,0 +1,143 @@
+// Package usdt provides functionality for the TRC20 USDT token, preconfigured with its contract
+// addresses and decimals.
+package usdt
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/trc20"
+	"github.com/go-chain/go-tron/units"
+)
+
+// Contract addresses of USDT on each network.
+var (
+	Mainnet = mustParse("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	Shasta  = mustParse("TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs")
+	Nile    = mustParse("TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf")
+)
+
+// Decimals is the number of decimal places of USDT amounts.
+const Decimals = 6
+
+// Name and Symbol are the name and symbol of USDT.
+const (
+	Name   = "Tether USD"
+	Symbol = "USDT"
+)
+
+func mustParse(str string) address.Address {
+	addr, err := address.FromBase58(str)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// ForChainId returns the contract address of USDT on the network with the provided chain id,
+// see params for the chain ids of each network.
+func ForChainId(chainId int64) (address.Address, error) {
+	switch chainId {
+	case params.MainnetChainId:
+		return Mainnet, nil
+	case params.ShastaChainId:
+		return Shasta, nil
+	case params.NileChainId:
+		return Nile, nil
+	default:
+		return address.Zero, fmt.Errorf("usdt: no contract for chain id %#x", chainId)
+	}
+}
+
+// Token is the USDT contract on a network. It is a TRC20 token with its metadata preset, which
+// can also check blacklists and estimate transfer fees.
+type Token struct {
+	*trc20.Token
+
+	client *client.Client
+
+	// Blacklist is the blacklist checked before transfers, if enabled.
+	blacklist *Blacklist
+}
+
+// Option configures optional behaviour of a token.
+type Option func(*Token)
+
+// WithBlacklistCheck checks that neither the sender nor the recipient of a transfer is
+// blacklisted before creating it, so that a transfer which would revert fails with a
+// *BlackListedError rather than burning its fee.
+func WithBlacklistCheck() Option {
+	return func(t *Token) {
+		t.blacklist = NewBlacklist(t.client, t.Contract(), USDTBlackListMethod)
+	}
+}
+
+// New creates a token for the USDT contract at the provided address, typically one of
+// Mainnet, Shasta or Nile.
+func New(c *client.Client, contract address.Address, opts ...Option) *Token {
+	t := &Token{
+		Token:  trc20.New(c, contract, trc20.WithMetadata(Name, Symbol, Decimals)),
+		client: c,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// NewMainnet creates a token for USDT on mainnet, the canonical contract with six decimals. It
+// replaces the trc20.USDT preset, adding the blacklist check and fee estimation.
+func NewMainnet(c *client.Client, opts ...Option) *Token {
+	return New(c, Mainnet, opts...)
+}
+
+// IsBlackListed returns whether the issuer has blacklisted an address, which prevents it from
+// sending or receiving USDT.
+func (t *Token) IsBlackListed(addr address.Address) (bool, error) {
+	return NewBlacklist(t.client, t.Contract(), USDTBlackListMethod).IsBlackListed(addr)
+}
+
+// Transfer creates and signs a transaction which transfers an amount, in the smallest unit, to
+// an address, after checking the blacklist if enabled. A fee limit of zero uses
+// DefaultFeeLimit. The transaction is not broadcast.
+func (t *Token) Transfer(from account.Account, to address.Address, amount *big.Int, feeLimit params.Sun) (tron.Transaction, error) {
+	if t.blacklist != nil {
+		if err := t.blacklist.Check(from.Address(), to); err != nil {
+			return tron.Transaction{}, err
+		}
+	}
+
+	if feeLimit == 0 {
+		feeLimit = DefaultFeeLimit
+	}
+
+	return t.Token.Transfer(from, to, amount, feeLimit)
+}
+
+// Amount returns an amount in the smallest unit as an amount of USDT.
+func Amount(raw *big.Int) units.Amount {
+	return units.New(raw, Decimals, Symbol)
+}
+
+// Format formats an amount in the smallest unit as a decimal string of USDT, such as
+// "12.5" for 12500000.
+func Format(amount *big.Int) string {
+	return units.Format(amount, Decimals)
+}
+
+// Parse parses a decimal string of USDT, such as "12.5" or "12.5 USDT", into an amount in the
+// smallest unit. More than six decimal places is an error rather than being rounded.
+func Parse(str string) (*big.Int, error) {
+	amount, err := units.Parse(str, Decimals, Symbol)
+	if err != nil {
+		return nil, err
+	}
+	return amount.Raw(), nil
+}
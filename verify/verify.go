@@ -0,0 +1,328 @@
+// Package verify checks the signatures of blocks and transactions, spreading the work across
+// a pool of workers so that verification keeps up with scanning on multi-core machines.
+package verify
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+)
+
+// Error is returned when a signature is invalid.
+type Error struct {
+	// Block is the height of the block that was being verified, if any.
+	Block uint64
+
+	// TxId is the id of the transaction with the invalid signature, empty if it was the
+	// signature of the block.
+	TxId string
+
+	Reason string
+}
+
+func (e *Error) Error() string {
+	if e.TxId == "" {
+		return fmt.Sprintf("verify: block %d has invalid signature: %s", e.Block, e.Reason)
+	}
+	return fmt.Sprintf("verify: transaction %s has invalid signature: %s", e.TxId, e.Reason)
+}
+
+// BlockSignature checks that a block header was signed by its witness.
+func BlockSignature(header *tron.BlockHeader) error {
+	fail := func(reason string) error {
+		return &Error{Block: header.RawData.Number, Reason: reason}
+	}
+
+	raw, err := pb.MarshalBlockHeaderRaw(header)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	sig, err := hex.DecodeString(header.WitnessSignature)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	witness, err := address.DecodeString(header.RawData.WitnessAddress)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	hash := tron.HashTransaction(raw)
+	signer, err := recoverSigner(hash[:], sig)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	if string(signer[:]) != string(witness) {
+		return fail(fmt.Sprintf("signed by %s rather than the witness", signer.ToBase58()))
+	}
+
+	return nil
+}
+
+// TransactionSigners returns the addresses which signed a transaction.
+func TransactionSigners(tx *tron.Transaction) ([]address.Address, error) {
+	hash, err := hex.DecodeString(tx.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tx.Signatures) == 0 {
+		return nil, errors.New("transaction is not signed")
+	}
+
+	signers := make([]address.Address, 0, len(tx.Signatures))
+	for _, str := range tx.Signatures {
+		sig, err := hex.DecodeString(str)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := recoverSigner(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+// TransactionSignature checks that every signature of a transaction is valid for its id, and
+// that the id is that of its raw data. If owner is true the owner of the contract must be one
+// of the signers when the transaction is signed under the owner permission, which holds for
+// accounts whose owner permission has not been changed.
+func TransactionSignature(tx *tron.Transaction, owner bool) error {
+	fail := func(reason string) error {
+		return &Error{TxId: tx.Id, Reason: reason}
+	}
+
+	id, err := tx.ComputeId()
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	if id != tx.Id {
+		return fail("id is not the hash of its raw data")
+	}
+
+	signers, err := TransactionSigners(tx)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	if !owner {
+		return nil
+	}
+
+	m, err := pb.FromTransaction(tx)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	if m.RawData == nil || len(m.RawData.Contracts) != 1 || m.RawData.Contracts[0].PermissionId != 0 {
+		return nil
+	}
+
+	msg, err := m.RawData.Contracts[0].Unpack()
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	ownerAddress := ownerOf(msg)
+	for _, signer := range signers {
+		if string(signer[:]) == string(ownerAddress) {
+			return nil
+		}
+	}
+
+	return fail("not signed by the owner")
+}
+
+// ownerOf returns the owner of a contract, which every contract message has as its
+// OwnerAddress field.
+func ownerOf(msg pb.ContractMessage) []byte {
+	field := reflect.ValueOf(msg).Elem().FieldByName("OwnerAddress")
+	if !field.IsValid() {
+		return nil
+	}
+	return field.Bytes()
+}
+
+// recoverSigner returns the address which produced a signature of a hash.
+func recoverSigner(hash, sig []byte) (address.Address, error) {
+	if len(sig) != params.SignatureLength {
+		return address.Zero, fmt.Errorf("signature is invalid length (%d)", len(sig))
+	}
+
+	// Signatures are produced with a recovery id of 27 or 28, as with Ethereum, but are
+	// recovered with 0 or 1.
+	normalized := make([]byte, len(sig))
+	copy(normalized, sig)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, normalized)
+	if err != nil {
+		return address.Zero, err
+	}
+
+	return address.FromPublicKey(pub), nil
+}
+
+// DefaultBatchSize is the number of signatures handed to a worker at a time, see
+// WithBatchSize.
+const DefaultBatchSize = 32
+
+// Verifier verifies the signatures of blocks with a pool of workers. A verifier is safe for
+// concurrent use.
+type Verifier struct {
+	workers   int
+	batchSize int
+	owner     bool
+}
+
+// Option configures optional behaviour of a verifier.
+type Option func(*Verifier)
+
+// WithWorkers sets the number of signatures verified concurrently, by default the number of
+// CPUs.
+func WithWorkers(n int) Option {
+	return func(v *Verifier) {
+		v.workers = n
+	}
+}
+
+// WithBatchSize sets the number of signatures handed to a worker at a time. Larger batches
+// reduce coordination between workers at the cost of balancing the work less evenly.
+func WithBatchSize(n int) Option {
+	return func(v *Verifier) {
+		v.batchSize = n
+	}
+}
+
+// WithOwnerCheck requires the owner of each transaction signed under the owner permission to
+// be one of its signers, see TransactionSignature.
+func WithOwnerCheck() Option {
+	return func(v *Verifier) {
+		v.owner = true
+	}
+}
+
+// New creates a verifier.
+func New(opts ...Option) *Verifier {
+	v := &Verifier{
+		workers:   runtime.NumCPU(),
+		batchSize: DefaultBatchSize,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.workers < 1 {
+		v.workers = 1
+	}
+
+	if v.batchSize < 1 {
+		v.batchSize = 1
+	}
+
+	return v
+}
+
+// VerifyBlock verifies the signature of a block and of each of its transactions.
+func (v *Verifier) VerifyBlock(ctx context.Context, block *tron.Block) error {
+	return v.VerifyBlocks(ctx, []tron.Block{*block})
+}
+
+// VerifyBlocks verifies the signature of each block and of each of their transactions. If any
+// are invalid the error of the earliest found, in block and transaction order, is returned.
+func (v *Verifier) VerifyBlocks(ctx context.Context, blocks []tron.Block) error {
+	var checks []func() error
+	for i := range blocks {
+		block := &blocks[i]
+		checks = append(checks, func() error {
+			return BlockSignature(&block.BlockHeader)
+		})
+
+		for j := range block.Transactions {
+			tx := &block.Transactions[j]
+			checks = append(checks, func() error {
+				if err := TransactionSignature(tx, v.owner); err != nil {
+					if e, ok := err.(*Error); ok {
+						e.Block = block.BlockHeader.RawData.Number
+					}
+					return err
+				}
+				return nil
+			})
+		}
+	}
+
+	return v.run(ctx, checks)
+}
+
+// run runs the checks in batches across the workers.
+func (v *Verifier) run(ctx context.Context, checks []func() error) error {
+	errs := make([]error, len(checks))
+
+	batches := make(chan int)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < v.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range batches {
+				end := start + v.batchSize
+				if end > len(checks) {
+					end = len(checks)
+				}
+
+				for i := start; i < end; i++ {
+					if errs[i] = checks[i](); errs[i] != nil {
+						cancel()
+						break
+					}
+				}
+			}
+		}()
+	}
+
+feed:
+	for start := 0; start < len(checks); start += v.batchSize {
+		select {
+		case batches <- start:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// The context is cancelled on the first failure, so an error from it is only returned
+	// when no check failed.
+	return ctx.Err()
+}
@@ -0,0 +1,89 @@
+package verify
+
+import (
+	"context"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chain/go-tron"
+	"github.com/go-chain/go-tron/account"
+	"github.com/go-chain/go-tron/params"
+	"github.com/go-chain/go-tron/pb"
+	"github.com/go-chain/go-tron/txbuilder"
+)
+
+const privKey = "000000000000000000000000000000000000000000000000000000000000010f"
+
+// signedBlock builds a block of signed transfers whose header is signed by its witness.
+func signedBlock(b *testing.B) tron.Block {
+	acc, err := account.FromPrivateKeyHex(privKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var ref tron.BlockHeaderOnly
+	ref.Id = strings.Repeat("0", 16) + strings.Repeat("ab", 24)
+	ref.BlockHeader.RawData.Number = 1
+	ref.BlockHeader.RawData.Timestamp = 1600000000000
+	ref.BlockHeader.RawData.WitnessAddress = acc.Address().ToBase16()
+
+	builder, err := txbuilder.New(ref)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	block := tron.Block{Id: ref.Id, BlockHeader: ref.BlockHeader}
+	for i := 0; i < 200; i++ {
+		tx, err := builder.Transfer(acc.Address(), acc.Address(), params.Sun(i+1))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := acc.Sign(&tx); err != nil {
+			b.Fatal(err)
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	key, err := crypto.HexToECDSA(privKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	raw, err := pb.MarshalBlockHeaderRaw(&block.BlockHeader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	hash := tron.HashTransaction(raw)
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		b.Fatal(err)
+	}
+	block.BlockHeader.WitnessSignature = hex.EncodeToString(sig)
+
+	return block
+}
+
+func benchmarkVerifyBlock(b *testing.B, workers int) {
+	block := signedBlock(b)
+	v := New(WithWorkers(workers), WithOwnerCheck())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.VerifyBlock(context.Background(), &block); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyBlock(b *testing.B) {
+	benchmarkVerifyBlock(b, 1)
+}
+
+func BenchmarkVerifyBlockParallel(b *testing.B) {
+	benchmarkVerifyBlock(b, runtime.NumCPU())
+}
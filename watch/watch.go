@@ -0,0 +1,218 @@
+// Package watch monitors the values returned by view functions of contracts, such as paused(),
+// owner() or feeRate(), and reports when they change, so that teams can be alerted to changes
+// to their own contracts or to contracts they depend on.
+package watch
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/go-chain/go-tron/abi"
+	"github.com/go-chain/go-tron/address"
+	"github.com/go-chain/go-tron/client"
+	"github.com/go-chain/go-tron/params"
+)
+
+// Probe is a view function of a contract whose value is watched.
+type Probe struct {
+	// Name identifies the probe in snapshots and changes, it defaults to the address of the
+	// contract and the signature of the function, such as "T...:paused()".
+	Name string
+
+	Contract  address.Address
+	Function  abi.Function
+	Arguments []interface{}
+}
+
+func (p Probe) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Contract.ToBase58() + ":" + p.Function.Signature()
+}
+
+// Change is a change to the value of a probe between two polls.
+type Change struct {
+	Probe Probe
+
+	// Old and New are the values before and after the change, formatted by the output types
+	// of the function.
+	Old, New string
+
+	Time time.Time
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s changed from %s to %s", c.Probe.name(), c.Old, c.New)
+}
+
+// Snapshot is the value of each probe by name.
+type Snapshot map[string]string
+
+// Option configures optional behaviour of a monitor.
+type Option func(*Monitor)
+
+// WithMulticall reads every probe in a single call of a Multicall2 contract at an address,
+// rather than with a request each. Functions whose value depends on the caller should not be
+// watched with a multicall contract.
+func WithMulticall(multicall address.Address) Option {
+	return func(m *Monitor) {
+		m.multicall = multicall
+	}
+}
+
+// WithSnapshot sets the values which the first poll is compared to, typically the snapshot
+// saved by a previous run, so that changes made while the monitor was not running are
+// reported. By default the first poll only records the values.
+func WithSnapshot(s Snapshot) Option {
+	return func(m *Monitor) {
+		for name, v := range s {
+			m.values[name] = v
+		}
+	}
+}
+
+// Monitor polls the values of probes and reports changes. A monitor is not safe for concurrent
+// use.
+type Monitor struct {
+	client    *client.Client
+	probes    []Probe
+	multicall address.Address
+
+	values Snapshot
+}
+
+// New creates a monitor for probes.
+func New(c *client.Client, probes []Probe, opts ...Option) *Monitor {
+	m := &Monitor{
+		client: c,
+		probes: probes,
+		values: make(Snapshot, len(probes)),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Snapshot returns the last value read of each probe.
+func (m *Monitor) Snapshot() Snapshot {
+	s := make(Snapshot, len(m.values))
+	for name, v := range m.values {
+		s[name] = v
+	}
+	return s
+}
+
+// Poll reads every probe and returns those whose value changed since it was last read. Probes
+// which could not be read keep their previous value and the first such error is returned,
+// along with the changes of the others.
+func (m *Monitor) Poll(ctx context.Context) ([]Change, error) {
+	calls := make([]client.Call, len(m.probes))
+	for i, p := range m.probes {
+		calls[i] = client.Call{
+			Address:   p.Contract,
+			Function:  p.Function,
+			Arguments: p.Arguments,
+		}
+	}
+
+	results, err := m.client.Multicall(ctx, calls, client.MulticallOptions{Contract: m.multicall})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var changes []Change
+	var failed error
+	for i, p := range m.probes {
+		if results[i].Err != nil {
+			if failed == nil {
+				failed = fmt.Errorf("watch: reading %s: %v", p.name(), results[i].Err)
+			}
+			continue
+		}
+
+		name := p.name()
+		value := format(p.Function, results[i].Data)
+
+		old, seen := m.values[name]
+		m.values[name] = value
+		if seen && old != value {
+			changes = append(changes, Change{Probe: p, Old: old, New: value, Time: now})
+		}
+	}
+
+	return changes, failed
+}
+
+// Run polls every interval until the context is done, calling alert for each change and
+// onError, if not nil, when a poll fails.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, alert func(Change), onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := m.Poll(ctx)
+		for _, c := range changes {
+			alert(c)
+		}
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// format formats the return data of a function by its output types. Values of elementary
+// static types are formatted individually and separated by commas, return data with other
+// types such as strings and arrays is formatted as hex.
+func format(fn abi.Function, data []byte) string {
+	if len(data) < len(fn.Outputs)*32 {
+		return "0x" + hex.EncodeToString(data)
+	}
+
+	values := make([]string, len(fn.Outputs))
+	for i, out := range fn.Outputs {
+		word := data[i*32 : (i+1)*32]
+
+		t := string(out.Type)
+		switch {
+		case strings.ContainsAny(t, "[("):
+			return "0x" + hex.EncodeToString(data)
+		case t == "bool":
+			values[i] = fmt.Sprint(word[31] != 0)
+		case t == "address":
+			var addr address.Address
+			addr[0] = params.AddressPrefix
+			copy(addr[1:], word[12:])
+			values[i] = addr.ToBase58()
+		case strings.HasPrefix(t, "uint"):
+			values[i] = new(big.Int).SetBytes(word).String()
+		case strings.HasPrefix(t, "int"):
+			n := new(big.Int).SetBytes(word)
+			if word[0]&0x80 != 0 {
+				n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+			}
+			values[i] = n.String()
+		case strings.HasPrefix(t, "bytes") && t != "bytes":
+			values[i] = "0x" + hex.EncodeToString(word)
+		default:
+			return "0x" + hex.EncodeToString(data)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}